@@ -50,3 +50,12 @@ func MustSetCell(c *canvas.Canvas, p image.Point, r rune, opts ...cell.Option) i
 	}
 	return cells
 }
+
+// MustCell returns the cell or panics.
+func MustCell(c *canvas.Canvas, p image.Point) *cell.Cell {
+	cl, err := c.Cell(p)
+	if err != nil {
+		panic(fmt.Sprintf("canvas.Cell => unexpected error: %v", err))
+	}
+	return cl
+}
@@ -28,6 +28,9 @@ func (cc Color) String() string {
 	if n, ok := colorNames[cc]; ok {
 		return n
 	}
+	if r, g, b, ok := cc.RGB24(); ok {
+		return fmt.Sprintf("ColorRGB24(%d, %d, %d)", r, g, b)
+	}
 	return fmt.Sprintf("Color:%d", cc)
 }
 
@@ -89,11 +92,17 @@ func ColorRGB6(r, g, b int) Color {
 	return Color(0x10 + 36*r + 6*g + b + 1) // Colors are off-by-one due to ColorDefault being zero.
 }
 
+// colorRGB24Flag marks colors created by ColorRGB24, these carry the 24 bit
+// RGB value in their lower 24 bits.
+const colorRGB24Flag Color = 1 << 24
+
 // ColorRGB24 sets a color using the 24 bit web color scheme.
-// Make sure your terminal is set to the terminalapi.ColorMode256 mode.
 // The provided values (r, g, b) must be in the range 0-255.
 // Larger or smaller values will be reset to the default color.
 //
+// Terminals that don't support 24 bit colors display the nearest color
+// available in their terminalapi.ColorMode instead.
+//
 // For reference on these colors see the RGB column in:
 // https://jonasjacek.github.io/colors/
 func ColorRGB24(r, g, b int) Color {
@@ -102,5 +111,15 @@ func ColorRGB24(r, g, b int) Color {
 			return ColorDefault
 		}
 	}
-	return ColorRGB6(r/51, g/51, b/51)
+	return colorRGB24Flag | Color(r<<16|g<<8|b)
+}
+
+// RGB24 returns the red, green and blue components of a color created by
+// ColorRGB24. The returned ok is false for all other colors.
+func (cc Color) RGB24() (r, g, b int, ok bool) {
+	if cc < colorRGB24Flag || cc >= colorRGB24Flag<<1 {
+		return 0, 0, 0, false
+	}
+	v := int(cc - colorRGB24Flag)
+	return v >> 16, (v >> 8) & 0xff, v & 0xff, true
 }
@@ -177,18 +177,18 @@ func TestColorRGB24(t *testing.T) {
 			want: ColorDefault,
 		},
 		{
-			desc: "translates black",
+			desc: "encodes black",
 			r:    0,
 			g:    0,
 			b:    0,
-			want: Color(17),
+			want: Color(0x1000000),
 		},
 		{
-			desc: "adds one to value",
+			desc: "encodes the components",
 			r:    95,
 			g:    255,
 			b:    135,
-			want: Color(85),
+			want: Color(0x15fff87),
 		},
 	}
 
@@ -201,3 +201,82 @@ func TestColorRGB24(t *testing.T) {
 		})
 	}
 }
+
+func TestRGB24(t *testing.T) {
+	tests := []struct {
+		desc    string
+		color   Color
+		r, g, b int
+		ok      bool
+	}{
+		{
+			desc:  "default color",
+			color: ColorDefault,
+		},
+		{
+			desc:  "system color",
+			color: ColorRed,
+		},
+		{
+			desc:  "numbered color",
+			color: ColorNumber(255),
+		},
+		{
+			desc:  "24 bit color",
+			color: ColorRGB24(95, 255, 135),
+			r:     95,
+			g:     255,
+			b:     135,
+			ok:    true,
+		},
+		{
+			desc:  "24 bit white",
+			color: ColorRGB24(255, 255, 255),
+			r:     255,
+			g:     255,
+			b:     255,
+			ok:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, g, b, ok := tc.color.RGB24()
+			if r != tc.r || g != tc.g || b != tc.b || ok != tc.ok {
+				t.Errorf("RGB24 => (%v, %v, %v, %v), want (%v, %v, %v, %v)", r, g, b, ok, tc.r, tc.g, tc.b, tc.ok)
+			}
+		})
+	}
+}
+
+func TestColorString(t *testing.T) {
+	tests := []struct {
+		desc  string
+		color Color
+		want  string
+	}{
+		{
+			desc:  "named color",
+			color: ColorRed,
+			want:  "ColorRed",
+		},
+		{
+			desc:  "numbered color",
+			color: ColorNumber(42),
+			want:  "Color:43",
+		},
+		{
+			desc:  "24 bit color",
+			color: ColorRGB24(1, 2, 3),
+			want:  "ColorRGB24(1, 2, 3)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.color.String(); got != tc.want {
+				t.Errorf("String => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
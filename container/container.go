@@ -28,7 +28,9 @@ import (
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/area"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
 )
 
 // Container wraps either sub containers or widgets and positions them on the
@@ -52,6 +54,11 @@ type Container struct {
 	// area is the area of the terminal this container has access to.
 	area image.Rectangle
 
+	// pressed is the container whose widget received the last press of the
+	// left mouse button that wasn't released yet, nil if there is no such
+	// container. Only set on the root container.
+	pressed *Container
+
 	// opts are the options provided to the container.
 	opts *options
 }
@@ -175,6 +182,21 @@ func (c *Container) Draw() error {
 	return drawTree(c)
 }
 
+// FocusedWidget returns the widget placed in the container that currently has
+// the keyboard focus or nil if that container has no widget.
+// Can be called on any container in the tree.
+func (c *Container) FocusedWidget() widgetapi.Widget {
+	return c.focusTracker.active().opts.widget
+}
+
+// FocusedArea returns the area of the terminal occupied by the container that
+// currently has the keyboard focus. The area is determined when the
+// containers are drawn, i.e. it is the area from the last call to Draw().
+// Can be called on any container in the tree.
+func (c *Container) FocusedArea() image.Rectangle {
+	return c.focusTracker.active().area
+}
+
 // Keyboard is used to forward a keyboard event to the container.
 // Keyboard events are forwarded to the widget in the currently focused
 // container, assuming that the widget registered for keyboard events.
@@ -194,31 +216,77 @@ func (c *Container) Keyboard(k *terminalapi.Keyboard) error {
 // registered for mouse events, the mouse event is further forwarded to that
 // widget. Only mouse events that fall within the widget's canvas are forwarded
 // and the coordinates are adjusted relative to the widget's canvas.
+//
+// The only exception is the release of the left mouse button, which is also
+// forwarded to the widget that received the press of the button, even if it
+// falls outside of the widget's canvas. This allows the widgets to tell a
+// click from a press that was released elsewhere.
 func (c *Container) Mouse(m *terminalapi.Mouse) error {
 	c.focusTracker.mouse(m)
 
-	target := pointCont(c, m.Position)
-	if target == nil { // Ignore mouse clicks where no containers are.
+	root := rootCont(c)
+	pressed := root.pressed
+	if m.Button == mouse.ButtonRelease {
+		root.pressed = nil
+	}
+
+	target, err := mouseTarget(c, m.Position)
+	if err != nil {
+		return err
+	}
+	if target != nil && m.Button == mouse.ButtonLeft && pressed == nil {
+		root.pressed = target
+	}
+	if m.Button == mouse.ButtonRelease && pressed != nil && pressed != target {
+		if err := pressed.widgetMouse(m); err != nil {
+			return err
+		}
+	}
+	if target == nil {
 		return nil
 	}
+	return target.widgetMouse(m)
+}
+
+// mouseTarget returns the container whose widget registered for mouse events
+// and should receive a mouse event at the point. Returns nil if the point falls
+// outside of the canvas of all such widgets.
+func mouseTarget(c *Container, p image.Point) (*Container, error) {
+	target := pointCont(c, p)
+	if target == nil { // Ignore mouse clicks where no containers are.
+		return nil, nil
+	}
 	w := target.opts.widget
 	if w == nil || !w.Options().WantMouse {
-		return nil
+		return nil, nil
 	}
 
 	// Ignore clicks falling outside of the container.
-	if !m.Position.In(target.usable()) {
-		return nil
+	if !p.In(target.usable()) {
+		return nil, nil
 	}
 
 	// Ignore clicks falling outside of the widget's canvas.
 	wa, err := target.widgetArea()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !m.Position.In(wa) {
+	if !p.In(wa) {
+		return nil, nil
+	}
+	return target, nil
+}
+
+// widgetMouse forwards the mouse event to the widget in the container.
+func (c *Container) widgetMouse(m *terminalapi.Mouse) error {
+	w := c.opts.widget
+	if w == nil || !w.Options().WantMouse {
 		return nil
 	}
+	wa, err := c.widgetArea()
+	if err != nil {
+		return err
+	}
 
 	// The sent mouse coordinate is relative to the widget canvas, i.e. zero
 	// based, even though the widget might not be in the top left corner on the
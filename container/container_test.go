@@ -27,6 +27,7 @@ import (
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/faketerm"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/fakewidget"
 )
@@ -437,6 +438,109 @@ func TestNew(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "fails on a nil theme",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Theme(nil),
+				)
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "inherits border and focused color from the theme",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(draw.LineStyleLight),
+					Theme(&theme.Theme{
+						Border:        cell.ColorRed,
+						FocusedBorder: cell.ColorBlue,
+					}),
+					SplitVertical(
+						Left(
+							Border(draw.LineStyleLight),
+						),
+						Right(
+							Border(draw.LineStyleLight),
+							BorderColor(cell.ColorGreen),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "explicit colors override the theme regardless of the order",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(draw.LineStyleLight),
+					BorderColor(cell.ColorGreen),
+					FocusedColor(cell.ColorMagenta),
+					Theme(&theme.Theme{
+						Border:        cell.ColorRed,
+						FocusedBorder: cell.ColorBlue,
+					}),
+					SplitVertical(
+						Left(
+							Border(draw.LineStyleLight),
+						),
+						Right(
+							Border(draw.LineStyleLight),
+							Theme(&theme.Theme{
+								Border: cell.ColorCyan,
+							}),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "splitting a container removes the widget",
 			termSize: image.Point{10, 10},
@@ -905,6 +1009,44 @@ func TestMouse(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "release forwarded also to the widget that received the press",
+			termSize: image.Point{50, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: true})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: true})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{30, 5}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					widgetapi.Options{WantMouse: true},
+					&terminalapi.Mouse{Position: image.Point{30, 5}, Button: mouse.ButtonRelease},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
+					widgetapi.Options{WantMouse: true},
+					&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonRelease},
+				)
+				return ft
+			},
+		},
 		{
 			desc:     "mouse poisition adjusted relative to widget's canvas, horizontal offset",
 			termSize: image.Point{30, 20},
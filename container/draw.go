@@ -25,6 +25,7 @@ import (
 	"github.com/mum4k/termdash/canvas"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/widgetapi"
 )
 
 // drawTree draws this container and all of its sub containers.
@@ -32,8 +33,14 @@ func drawTree(c *Container) error {
 	var errStr string
 
 	root := rootCont(c)
+	// The terminal isn't required to occupy the whole screen, e.g. an inline
+	// terminal only reports the lines it renders into. The layout is always
+	// recalculated from the currently reported size.
 	size := root.term.Size()
 	root.area = image.Rect(0, 0, size.X, size.Y)
+	if root.area.Empty() {
+		return nil // Nowhere to draw.
+	}
 
 	preOrder(root, &errStr, visitFunc(func(c *Container) error {
 		first, second, err := c.split()
@@ -71,16 +78,21 @@ func drawBorder(c *Container) error {
 		return err
 	}
 
-	var cOpts []cell.Option
+	var cOpts, titleOpts []cell.Option
 	if c.focusTracker.isActive(c) {
 		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.focusedColor))
+		titleOpts = cOpts
 	} else {
 		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.borderColor))
+		titleOpts = cOpts
+		if t := c.opts.inherited.theme; t != nil {
+			titleOpts = []cell.Option{cell.FgColor(t.Title)}
+		}
 	}
 
 	if err := draw.Border(cvs, ar,
 		draw.BorderLineStyle(c.opts.border),
-		draw.BorderTitle(c.opts.borderTitle, draw.OverrunModeThreeDot, cOpts...),
+		draw.BorderTitle(c.opts.borderTitle, draw.OverrunModeThreeDot, titleOpts...),
 		draw.BorderTitleAlign(c.opts.borderTitleHAlign),
 		draw.BorderCellOpts(cOpts...),
 	); err != nil {
@@ -118,6 +130,12 @@ func drawWidget(c *Container) error {
 		return err
 	}
 
+	if tw, ok := c.opts.widget.(widgetapi.Themed); ok {
+		tw.SetTheme(c.opts.inherited.theme)
+	}
+	if fw, ok := c.opts.widget.(widgetapi.Focusable); ok {
+		fw.SetFocused(c.focusTracker.isActive(c))
+	}
 	if err := c.opts.widget.Draw(cvs); err != nil {
 		return err
 	}
@@ -23,7 +23,10 @@ import (
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/draw/testdraw"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/fakewidget"
 )
@@ -791,3 +794,195 @@ func TestDrawHandlesTerminalResize(t *testing.T) {
 		})
 	}
 }
+
+// smallTerminal is a fake terminal that reports a size smaller than its
+// buffer, like an inline terminal that occupies only a part of the screen.
+type smallTerminal struct {
+	*faketerm.Terminal
+	size image.Point
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (st *smallTerminal) Size() image.Point {
+	return st.size
+}
+
+func TestDrawRespectsTerminalSize(t *testing.T) {
+	tests := []struct {
+		desc     string
+		bufSize  image.Point
+		termSize image.Point
+		want     func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "draws only within the reported size",
+			bufSize:  image.Point{20, 10},
+			termSize: image.Point{20, 5},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 20, 5),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(1, 1, 19, 4)),
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "draws nothing when the reported size is empty",
+			bufSize:  image.Point{20, 10},
+			termSize: image.Point{20, 0},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := faketerm.MustNew(tc.bufSize)
+			st := &smallTerminal{
+				Terminal: got,
+				size:     tc.termSize,
+			}
+			c, err := New(
+				st,
+				Border(draw.LineStyleLight),
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+// themedWidget is a fake widget that records the theme it received.
+type themedWidget struct {
+	*fakewidget.Mirror
+	theme *theme.Theme
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (tw *themedWidget) SetTheme(t *theme.Theme) {
+	tw.theme = t
+}
+
+func TestDrawTheme(t *testing.T) {
+	th := &theme.Theme{
+		Border:        cell.ColorRed,
+		FocusedBorder: cell.ColorBlue,
+		Title:         cell.ColorGreen,
+	}
+	ft := faketerm.MustNew(image.Point{20, 7})
+	left := &themedWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	right := &themedWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	c, err := New(
+		ft,
+		Theme(th),
+		SplitVertical(
+			Left(
+				Border(draw.LineStyleLight),
+				BorderTitle("ab"),
+				PlaceWidget(left),
+			),
+			Right(
+				PlaceWidget(right),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := c.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if left.theme != th || right.theme != th {
+		t.Errorf("Draw => widgets received themes %p and %p, want %p", left.theme, right.theme, th)
+	}
+
+	want := faketerm.MustNew(ft.Size())
+	cvs := testcanvas.MustNew(want.Area())
+	testdraw.MustBorder(
+		cvs,
+		image.Rect(0, 0, 10, 7),
+		draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+		draw.BorderTitle("ab", draw.OverrunModeThreeDot, cell.FgColor(cell.ColorGreen)),
+	)
+	testcanvas.MustApply(cvs, want)
+	fakewidget.MustDraw(want, testcanvas.MustNew(image.Rect(1, 1, 9, 6)), widgetapi.Options{})
+	fakewidget.MustDraw(want, testcanvas.MustNew(image.Rect(10, 0, 20, 7)), widgetapi.Options{})
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
+
+// focusableWidget is a fake widget that records whether it was focused.
+type focusableWidget struct {
+	*fakewidget.Mirror
+	focused bool
+}
+
+// SetFocused implements widgetapi.Focusable.SetFocused.
+func (fw *focusableWidget) SetFocused(focused bool) {
+	fw.focused = focused
+}
+
+func TestDrawFocused(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 10})
+	left := &focusableWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	right := &focusableWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	c, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				PlaceWidget(left),
+			),
+			Right(
+				PlaceWidget(right),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if err := c.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if left.focused || right.focused {
+		t.Errorf("Draw => widgets focused %v and %v, want neither while the root container is focused", left.focused, right.focused)
+	}
+
+	for _, m := range []*terminalapi.Mouse{
+		{Position: image.Point{15, 5}, Button: mouse.ButtonLeft},
+		{Position: image.Point{15, 5}, Button: mouse.ButtonRelease},
+	} {
+		if err := c.Mouse(m); err != nil {
+			t.Fatalf("Mouse => unexpected error: %v", err)
+		}
+	}
+	if err := c.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if left.focused || !right.focused {
+		t.Errorf("Draw => widgets focused %v and %v, want only the right one", left.focused, right.focused)
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/faketerm"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/fakewidget"
 )
 
 // pointCase is a test case for the pointCont function.
@@ -423,3 +425,48 @@ func TestFocusTrackerMouse(t *testing.T) {
 		})
 	}
 }
+
+func TestFocused(t *testing.T) {
+	ft, err := faketerm.New(image.Point{20, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	left := fakewidget.New(widgetapi.Options{})
+	root, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				PlaceWidget(left),
+			),
+			Right(),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := root.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if got := root.FocusedWidget(); got != nil {
+		t.Errorf("FocusedWidget => %v, want nil initially", got)
+	}
+	if got, want := root.FocusedArea(), image.Rect(0, 0, 20, 10); got != want {
+		t.Errorf("FocusedArea => %v, want %v", got, want)
+	}
+
+	for _, ev := range []*terminalapi.Mouse{
+		{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+		{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+	} {
+		if err := root.Mouse(ev); err != nil {
+			t.Fatalf("Mouse => unexpected error: %v", err)
+		}
+	}
+	if got := root.FocusedWidget(); got != left {
+		t.Errorf("FocusedWidget => %v, want %v", got, left)
+	}
+	if got, want := root.FocusedArea(), image.Rect(0, 0, 10, 10); got != want {
+		t.Errorf("FocusedArea => %v, want %v", got, want)
+	}
+}
@@ -17,11 +17,13 @@ package container
 // options.go defines container options.
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -63,6 +65,12 @@ type options struct {
 	border            draw.LineStyle
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
+
+	// borderColorSet and focusedColorSet indicate that the BorderColor and
+	// FocusedColor options were provided to this container, these colors
+	// take precedence over the theme.
+	borderColorSet  bool
+	focusedColorSet bool
 }
 
 // inherited contains options that are inherited by child containers.
@@ -71,6 +79,8 @@ type inherited struct {
 	borderColor cell.Color
 	// focusedColor is the color used for the border when focused.
 	focusedColor cell.Color
+	// theme is the theme passed to the widgets, nil if not set.
+	theme *theme.Theme
 }
 
 // newOptions returns a new options instance with the default values.
@@ -268,6 +278,7 @@ func BorderTitleAlignRight() Option {
 func BorderColor(color cell.Color) Option {
 	return option(func(c *Container) error {
 		c.opts.inherited.borderColor = color
+		c.opts.borderColorSet = true
 		return nil
 	})
 }
@@ -278,6 +289,31 @@ func BorderColor(color cell.Color) Option {
 func FocusedColor(color cell.Color) Option {
 	return option(func(c *Container) error {
 		c.opts.inherited.focusedColor = color
+		c.opts.focusedColorSet = true
+		return nil
+	})
+}
+
+// Theme sets the theme of the container. The theme sets the color of the
+// border, of the border when focused and of the border title. Widgets placed
+// in the container that implement widgetapi.Themed use the theme for all the
+// colors that weren't provided to them explicitly.
+// The BorderColor and FocusedColor options provided to the same container
+// override the colors from the theme regardless of the order of the options.
+// The theme overrides the border colors inherited from the parent container.
+// This option is inherited to sub containers created by container splits.
+func Theme(t *theme.Theme) Option {
+	return option(func(c *Container) error {
+		if t == nil {
+			return errors.New("the theme cannot be nil")
+		}
+		c.opts.inherited.theme = t
+		if !c.opts.borderColorSet {
+			c.opts.inherited.borderColor = t.Border
+		}
+		if !c.opts.focusedColorSet {
+			c.opts.inherited.focusedColor = t.FocusedBorder
+		}
 		return nil
 	})
 }
@@ -148,6 +148,65 @@ func BrailleCircle(bc *braille.Canvas, mid image.Point, radius int, opts ...Brai
 	return nil
 }
 
+// BrailleCircleMidAndRadius given an area of a braille canvas, determines the
+// mid point in pixels and radius to draw the largest circle that fits.
+// The circle's mid point is always positioned on the {0,1} pixel in the chosen
+// cell so that any text inside of it can be visually centered.
+func BrailleCircleMidAndRadius(ar image.Rectangle) (image.Point, int) {
+	mid := image.Point{ar.Dx() / 2, ar.Dy() / 2}
+	if mid.X%2 != 0 {
+		mid.X--
+	}
+	switch mid.Y % 4 {
+	case 0:
+		mid.Y++
+	case 2:
+		mid.Y--
+	case 3:
+		mid.Y -= 2
+	}
+
+	// Calculate radius based on the smaller axis.
+	var radius int
+	if ar.Dx() < ar.Dy() {
+		if mid.X < ar.Dx()/2 {
+			radius = mid.X
+		} else {
+			radius = ar.Dx() - mid.X - 1
+		}
+	} else {
+		if mid.Y < ar.Dy()/2 {
+			radius = mid.Y
+		} else {
+			radius = ar.Dy() - mid.Y - 1
+		}
+	}
+	return mid, radius
+}
+
+// BrailleCircleTextCells given the mid point and radius of a circle on a
+// braille canvas, returns the number of cells that are available for text
+// within the circle and the coordinates of the first cell.
+// These coordinates are for a normal (non-braille) canvas.
+// That is the cells that do not contain any of the circle points. This is
+// important since normal characters and braille characters cannot share the
+// same cell.
+func BrailleCircleTextCells(mid image.Point, radius int) (int, image.Point) {
+	if radius < 3 {
+		return 0, image.Point{0, 0}
+	}
+	// Pixels available for the text only.
+	// Subtract one for the circle itself.
+	pixels := radius*2 - 1
+
+	startPixel := image.Point{mid.X - pixels/2, mid.Y}
+	startCell := image.Point{
+		startPixel.X / braille.ColMult,
+		mid.Y / braille.RowMult,
+	}
+	return pixels / braille.ColMult, startCell
+}
+
 // drawPoints draws the points onto the canvas.
 func drawPoints(bc *braille.Canvas, points []image.Point, opt *brailleCircleOptions) error {
 	for _, p := range points {
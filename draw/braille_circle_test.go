@@ -1189,3 +1189,123 @@ func TestBrailleCircle(t *testing.T) {
 		})
 	}
 }
+
+func TestBrailleCircleMidAndRadius(t *testing.T) {
+	tests := []struct {
+		desc      string
+		pixelArea image.Rectangle
+		wantMid   image.Point
+		wantR     int
+	}{
+		{
+			desc:      "middle on X falls on beginning of cell",
+			pixelArea: image.Rect(0, 0, 4, 3),
+			wantMid:   image.Point{2, 1},
+			wantR:     1,
+		},
+		{
+			desc:      "middle on X falls on end of cell and is adjusted",
+			pixelArea: image.Rect(0, 0, 3, 3),
+			wantMid:   image.Point{0, 1},
+			wantR:     1,
+		},
+		{
+			desc:      "middle on Y falls on 1st cell pixel, adjusted",
+			pixelArea: image.Rect(0, 0, 4, 16),
+			wantMid:   image.Point{2, 9},
+			wantR:     1,
+		},
+		{
+			desc:      "middle on Y falls on 2nd cell pixel, left as is",
+			pixelArea: image.Rect(0, 0, 4, 10),
+			wantMid:   image.Point{2, 5},
+			wantR:     1,
+		},
+		{
+			desc:      "middle on Y falls on 3rd cell pixel, adjusted",
+			pixelArea: image.Rect(0, 0, 4, 12),
+			wantMid:   image.Point{2, 5},
+			wantR:     1,
+		},
+		{
+			desc:      "middle on Y falls on 4th cell pixel, adjusted",
+			pixelArea: image.Rect(0, 0, 4, 30),
+			wantMid:   image.Point{2, 13},
+			wantR:     1,
+		},
+		{
+			desc:      "Dx less than Dy, mid falls before half",
+			pixelArea: image.Rect(0, 0, 14, 40),
+			wantMid:   image.Point{6, 21},
+			wantR:     6,
+		},
+		{
+			desc:      "Dx less than Dy, mid falls on half",
+			pixelArea: image.Rect(0, 0, 20, 40),
+			wantMid:   image.Point{10, 21},
+			wantR:     9,
+		},
+		{
+			desc:      "Dy less than Dx, mid falls before half",
+			pixelArea: image.Rect(0, 0, 20, 20),
+			wantMid:   image.Point{10, 9},
+			wantR:     9,
+		},
+		{
+			desc:      "Dy less than Dx, mid falls on half",
+			pixelArea: image.Rect(0, 0, 20, 18),
+			wantMid:   image.Point{10, 9},
+			wantR:     8,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotMid, gotR := BrailleCircleMidAndRadius(tc.pixelArea)
+			if gotMid != tc.wantMid || gotR != tc.wantR {
+				t.Errorf("BrailleCircleMidAndRadius => %v, %v, want %v, %v", gotMid, gotR, tc.wantMid, tc.wantR)
+			}
+		})
+	}
+}
+
+func TestBrailleCircleTextCells(t *testing.T) {
+	tests := []struct {
+		desc      string
+		mid       image.Point
+		radius    int
+		wantCells int
+		wantFirst image.Point
+	}{
+		{
+			desc:      "radius too small",
+			mid:       image.Point{1, 0},
+			radius:    2,
+			wantCells: 0,
+			wantFirst: image.Point{0, 0},
+		},
+		{
+			desc:      "radius of three",
+			mid:       image.Point{2, 1},
+			radius:    3,
+			wantCells: 2,
+			wantFirst: image.Point{0, 0},
+		},
+		{
+			desc:      "radius of four",
+			mid:       image.Point{20, 10},
+			radius:    4,
+			wantCells: 3,
+			wantFirst: image.Point{8, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotCells, gotFirst := BrailleCircleTextCells(tc.mid, tc.radius)
+			if gotCells != tc.wantCells || !gotFirst.Eq(tc.wantFirst) {
+				t.Errorf("BrailleCircleTextCells => %v, %v, want %v, %v", gotCells, gotFirst, tc.wantCells, tc.wantFirst)
+			}
+		})
+	}
+}
@@ -128,6 +128,13 @@ func BrailleLine(bc *braille.Canvas, start, end image.Point, opts ...BrailleLine
 	return nil
 }
 
+// BrailleLinePoints returns the pixels that BrailleLine sets when drawing a
+// line segment between the two provided points. Useful when the line forms a
+// border for BrailleFill.
+func BrailleLinePoints(start, end image.Point) []image.Point {
+	return brailleLinePoints(start, end)
+}
+
 // brailleLinePoints returns the points to set when drawing the line.
 func brailleLinePoints(start, end image.Point) []image.Point {
 	// Implements Bresenham's line algorithm.
@@ -18,6 +18,7 @@ import (
 	"image"
 	"testing"
 
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/area"
 	"github.com/mum4k/termdash/canvas/braille"
 	"github.com/mum4k/termdash/canvas/braille/testbraille"
@@ -455,3 +456,46 @@ func TestBrailleLine(t *testing.T) {
 		})
 	}
 }
+
+func TestBrailleLinePoints(t *testing.T) {
+	tests := []struct {
+		desc  string
+		start image.Point
+		end   image.Point
+		want  []image.Point
+	}{
+		{
+			desc:  "single point",
+			start: image.Point{1, 1},
+			end:   image.Point{1, 1},
+			want:  []image.Point{{1, 1}},
+		},
+		{
+			desc:  "horizontal line",
+			start: image.Point{0, 1},
+			end:   image.Point{2, 1},
+			want:  []image.Point{{0, 1}, {1, 1}, {2, 1}},
+		},
+		{
+			desc:  "vertical line, start below the end",
+			start: image.Point{0, 2},
+			end:   image.Point{0, 0},
+			want:  []image.Point{{0, 0}, {0, 1}, {0, 2}},
+		},
+		{
+			desc:  "diagonal line",
+			start: image.Point{0, 0},
+			end:   image.Point{2, 2},
+			want:  []image.Point{{0, 0}, {1, 1}, {2, 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := BrailleLinePoints(tc.start, tc.end)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("BrailleLinePoints => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ringbuffer implements a buffer of float64 samples with a bounded
+// size that maintains the minimum and the maximum of the stored samples and
+// of the stored positive samples.
+// Useful for widgets that display live data streamed one sample at a time.
+package ringbuffer
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	capacity int
+	maxAge   time.Duration
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.capacity <= 0 {
+		return fmt.Errorf("invalid Capacity %d, must be a positive number", o.capacity)
+	}
+	if o.maxAge < 0 {
+		return fmt.Errorf("invalid MaxAge %v, must be a positive duration or zero", o.maxAge)
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DefaultCapacity is the maximum number of samples held by buffers created
+// without the Capacity option.
+const DefaultCapacity = 10000
+
+// Capacity sets the maximum number of samples the buffer holds. When the
+// buffer is full, appending a sample drops the oldest one.
+// Must be a positive number, defaults to DefaultCapacity.
+func Capacity(n int) Option {
+	return option(func(opts *options) {
+		opts.capacity = n
+	})
+}
+
+// MaxAge sets the maximum age of the samples in the buffer. Appending a
+// sample drops all the samples that are older than the appended sample by
+// more than the duration.
+// Zero means the age of samples isn't limited, which is the default.
+func MaxAge(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.maxAge = d
+	})
+}
+
+// minGrowth is the smallest size of the storage when it grows.
+const minGrowth = 16
+
+// entry is one sample in the monotonic queues that track the minimum and the
+// maximum.
+type entry struct {
+	// seq is the sequence number of the sample.
+	seq uint64
+	// value is the value of the sample.
+	value float64
+}
+
+// monoQueue is a monotonic queue of samples. Each appended sample removes
+// the samples at the back of the queue that it supersedes, so the sample at
+// the front is the minimum or the maximum of all the samples in the queue.
+type monoQueue struct {
+	// entries in the queue, the front of the queue is at index head.
+	entries []entry
+	head    int
+	// supersedes returns true if the value v makes the queued value q
+	// irrelevant.
+	supersedes func(v, q float64) bool
+}
+
+// push appends the sample to the back of the queue.
+func (mq *monoQueue) push(e entry) {
+	for len(mq.entries) > mq.head && mq.supersedes(e.value, mq.entries[len(mq.entries)-1].value) {
+		mq.entries = mq.entries[:len(mq.entries)-1]
+	}
+	mq.entries = append(mq.entries, e)
+}
+
+// dropBefore removes the samples with sequence numbers smaller than seq from
+// the front of the queue.
+func (mq *monoQueue) dropBefore(seq uint64) {
+	for mq.head < len(mq.entries) && mq.entries[mq.head].seq < seq {
+		mq.head++
+	}
+	// Reclaim the space taken by the removed samples once they make up at
+	// least half of the queue, which keeps the cost amortized constant.
+	if mq.head > 0 && mq.head >= len(mq.entries)/2 {
+		n := copy(mq.entries, mq.entries[mq.head:])
+		mq.entries = mq.entries[:n]
+		mq.head = 0
+	}
+}
+
+// front returns the sample at the front of the queue.
+// Returns false if the queue is empty.
+func (mq *monoQueue) front() (entry, bool) {
+	if mq.head >= len(mq.entries) {
+		return entry{}, false
+	}
+	return mq.entries[mq.head], true
+}
+
+// reset removes all samples from the queue.
+func (mq *monoQueue) reset() {
+	mq.entries = nil
+	mq.head = 0
+}
+
+// Buffer is a ring buffer of float64 samples.
+// Appending a sample, determining the minimum and the maximum of all the
+// samples or of the positive samples all have amortized constant cost. The memory used by the buffer doesn't grow beyond
+// what is needed to store the samples allowed by the options.
+//
+// This object is not thread-safe.
+type Buffer struct {
+	// values and times store the samples and the times when they were taken.
+	// The oldest sample is at index start.
+	values []float64
+	times  []time.Time
+	start  int
+	// size is the number of stored samples.
+	size int
+
+	// nextSeq is the sequence number of the next appended sample.
+	// The stored samples have sequence numbers nextSeq-size up to nextSeq-1.
+	nextSeq uint64
+
+	// minQ and maxQ track the minimum and the maximum sample.
+	minQ *monoQueue
+	maxQ *monoQueue
+	// posMinQ and posMaxQ track the minimum and the maximum positive sample.
+	posMinQ *monoQueue
+	posMaxQ *monoQueue
+
+	opts *options
+}
+
+// New returns a new empty Buffer.
+func New(opts ...Option) (*Buffer, error) {
+	opt := &options{
+		capacity: DefaultCapacity,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Buffer{
+		minQ:    newMinQueue(),
+		maxQ:    newMaxQueue(),
+		posMinQ: newMinQueue(),
+		posMaxQ: newMaxQueue(),
+		opts:    opt,
+	}, nil
+}
+
+// newMinQueue returns a monotonic queue that tracks the minimum.
+func newMinQueue() *monoQueue {
+	return &monoQueue{
+		supersedes: func(v, q float64) bool { return v <= q },
+	}
+}
+
+// newMaxQueue returns a monotonic queue that tracks the maximum.
+func newMaxQueue() *monoQueue {
+	return &monoQueue{
+		supersedes: func(v, q float64) bool { return v >= q },
+	}
+}
+
+// Append appends the sample to the buffer at the current time.
+func (b *Buffer) Append(v float64) {
+	b.AppendAt(time.Now(), v)
+}
+
+// AppendAt appends the sample taken at the specified time to the buffer.
+// Samples older than the one appended by more than the MaxAge are dropped.
+// NaN samples are stored, but they are ignored by MinMax and PositiveMinMax.
+func (b *Buffer) AppendAt(t time.Time, v float64) {
+	if b.size == b.opts.capacity {
+		b.dropOldest()
+	}
+	if b.size == len(b.values) {
+		b.grow()
+	}
+
+	i := (b.start + b.size) % len(b.values)
+	b.values[i] = v
+	b.times[i] = t
+	b.size++
+	if !math.IsNaN(v) {
+		e := entry{seq: b.nextSeq, value: v}
+		b.minQ.push(e)
+		b.maxQ.push(e)
+		if v > 0 {
+			b.posMinQ.push(e)
+			b.posMaxQ.push(e)
+		}
+	}
+	b.nextSeq++
+
+	if b.opts.maxAge > 0 {
+		for b.size > 0 && t.Sub(b.times[b.start]) > b.opts.maxAge {
+			b.dropOldest()
+		}
+	}
+}
+
+// grow increases the size of the storage, so that it can accommodate at least
+// one more sample.
+func (b *Buffer) grow() {
+	n := 2 * len(b.values)
+	if n < minGrowth {
+		n = minGrowth
+	}
+	if c := b.opts.capacity; n > c {
+		n = c
+	}
+
+	values := make([]float64, n)
+	times := make([]time.Time, n)
+	for i := 0; i < b.size; i++ {
+		j := (b.start + i) % len(b.values)
+		values[i] = b.values[j]
+		times[i] = b.times[j]
+	}
+	b.values = values
+	b.times = times
+	b.start = 0
+}
+
+// dropOldest removes the oldest sample from the buffer.
+func (b *Buffer) dropOldest() {
+	b.start = (b.start + 1) % len(b.values)
+	b.size--
+	oldest := b.nextSeq - uint64(b.size)
+	for _, mq := range b.queues() {
+		mq.dropBefore(oldest)
+	}
+}
+
+// queues returns all the monotonic queues of the buffer.
+func (b *Buffer) queues() []*monoQueue {
+	return []*monoQueue{b.minQ, b.maxQ, b.posMinQ, b.posMaxQ}
+}
+
+// Len returns the number of samples in the buffer.
+func (b *Buffer) Len() int {
+	return b.size
+}
+
+// Dropped returns the number of samples that were appended to the buffer and
+// are no longer stored in it.
+func (b *Buffer) Dropped() int {
+	return int(b.nextSeq) - b.size
+}
+
+// Values returns a copy of the samples in the buffer, oldest first.
+func (b *Buffer) Values() []float64 {
+	return b.Last(b.size)
+}
+
+// Last returns a copy of the n most recently appended samples, oldest first.
+// Returns all the samples if the buffer holds less than n samples.
+func (b *Buffer) Last(n int) []float64 {
+	if n > b.size {
+		n = b.size
+	}
+	if n <= 0 {
+		return nil
+	}
+	res := make([]float64, n)
+	skip := b.size - n
+	for i := range res {
+		res[i] = b.values[(b.start+skip+i)%len(b.values)]
+	}
+	return res
+}
+
+// MinMax returns the smallest and the largest sample in the buffer, NaN
+// samples are ignored. The returned ok is false if the buffer doesn't contain
+// any samples other than NaN.
+func (b *Buffer) MinMax() (min, max float64, ok bool) {
+	return queuesMinMax(b.minQ, b.maxQ)
+}
+
+// PositiveMinMax returns the smallest and the largest positive sample in the
+// buffer. The returned ok is false if the buffer doesn't contain any positive
+// samples.
+func (b *Buffer) PositiveMinMax() (min, max float64, ok bool) {
+	return queuesMinMax(b.posMinQ, b.posMaxQ)
+}
+
+// queuesMinMax returns the samples at the front of the two queues.
+func queuesMinMax(minQ, maxQ *monoQueue) (min, max float64, ok bool) {
+	minE, ok := minQ.front()
+	if !ok {
+		return 0, 0, false
+	}
+	maxE, _ := maxQ.front()
+	return minE.value, maxE.value, true
+}
+
+// Clear removes all the samples from the buffer.
+func (b *Buffer) Clear() {
+	b.values = nil
+	b.times = nil
+	b.start = 0
+	b.size = 0
+	for _, mq := range b.queues() {
+		mq.reset()
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ringbuffer
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// sample is a sample appended to the buffer in a test.
+type sample struct {
+	// at is the time of the sample relative to the epoch.
+	at    time.Duration
+	value float64
+}
+
+func TestBuffer(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		desc       string
+		opts       []Option
+		samples    []sample
+		clear      bool
+		last       int
+		wantValues []float64
+		wantLast   []float64
+		// wantDropped is the expected number of dropped samples.
+		wantDropped int
+		wantMin     float64
+		wantMax     float64
+		wantOK      bool
+		wantErr     bool
+	}{
+		{
+			desc:    "fails on negative capacity",
+			opts:    []Option{Capacity(-1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero capacity",
+			opts:    []Option{Capacity(0)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative max age",
+			opts:    []Option{MaxAge(-1)},
+			wantErr: true,
+		},
+		{
+			desc: "empty buffer",
+			last: 1,
+		},
+		{
+			desc: "buffer without options keeps all the samples",
+			samples: []sample{
+				{0, 3}, {0, 1}, {0, 2},
+			},
+			last:       2,
+			wantValues: []float64{3, 1, 2},
+			wantLast:   []float64{1, 2},
+			wantMin:    1,
+			wantMax:    3,
+			wantOK:     true,
+		},
+		{
+			desc: "last returns all the samples when asked for more",
+			samples: []sample{
+				{0, 3}, {0, 1},
+			},
+			last:       5,
+			wantValues: []float64{3, 1},
+			wantLast:   []float64{3, 1},
+			wantMin:    1,
+			wantMax:    3,
+			wantOK:     true,
+		},
+		{
+			desc: "capacity drops the oldest samples",
+			opts: []Option{Capacity(3)},
+			samples: []sample{
+				{0, 10}, {0, -10}, {0, 1}, {0, 2}, {0, 3},
+			},
+			last:        1,
+			wantValues:  []float64{1, 2, 3},
+			wantLast:    []float64{3},
+			wantDropped: 2,
+			wantMin:     1,
+			wantMax:     3,
+			wantOK:      true,
+		},
+		{
+			desc: "max age drops the old samples",
+			opts: []Option{MaxAge(2 * time.Second)},
+			samples: []sample{
+				{0, 10}, {time.Second, 1}, {2 * time.Second, 2}, {3 * time.Second, 3},
+			},
+			wantValues:  []float64{1, 2, 3},
+			wantDropped: 1,
+			wantMin:     1,
+			wantMax:     3,
+			wantOK:      true,
+		},
+		{
+			desc: "both capacity and max age",
+			opts: []Option{Capacity(2), MaxAge(time.Minute)},
+			samples: []sample{
+				{0, 10}, {time.Second, 1}, {2 * time.Second, 2},
+			},
+			wantValues:  []float64{1, 2},
+			wantDropped: 1,
+			wantMin:     1,
+			wantMax:     2,
+			wantOK:      true,
+		},
+		{
+			desc: "NaN samples are stored, but ignored by MinMax",
+			samples: []sample{
+				{0, nan}, {0, 5}, {0, nan}, {0, -5},
+			},
+			wantValues: []float64{nan, 5, nan, -5},
+			wantMin:    -5,
+			wantMax:    5,
+			wantOK:     true,
+		},
+		{
+			desc: "only NaN samples",
+			samples: []sample{
+				{0, nan},
+			},
+			wantValues: []float64{nan},
+		},
+		{
+			desc: "clear removes all the samples",
+			opts: []Option{Capacity(3)},
+			samples: []sample{
+				{0, 1}, {0, 2},
+			},
+			clear:       true,
+			wantDropped: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, s := range tc.samples {
+				b.AppendAt(time.Unix(0, 0).Add(s.at), s.value)
+			}
+			if tc.clear {
+				b.Clear()
+			}
+
+			if got, want := b.Len(), len(tc.wantValues); got != want {
+				t.Errorf("Len => %d, want %d", got, want)
+			}
+			if diff := pretty.Compare(tc.wantValues, b.Values()); diff != "" {
+				t.Errorf("Values => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if got, want := b.Dropped(), tc.wantDropped; got != want {
+				t.Errorf("Dropped => %d, want %d", got, want)
+			}
+			if tc.last > 0 {
+				if diff := pretty.Compare(tc.wantLast, b.Last(tc.last)); diff != "" {
+					t.Errorf("Last => unexpected diff (-want, +got):\n%s", diff)
+				}
+			}
+			gotMin, gotMax, gotOK := b.MinMax()
+			if gotMin != tc.wantMin || gotMax != tc.wantMax || gotOK != tc.wantOK {
+				t.Errorf("MinMax => (%v, %v, %v), want (%v, %v, %v)", gotMin, gotMax, gotOK, tc.wantMin, tc.wantMax, tc.wantOK)
+			}
+		})
+	}
+}
+
+// TestMinMaxMatchesValues verifies the incrementally maintained minimum and
+// maximum of all and of the positive samples against the stored samples over
+// many appends.
+func TestMinMaxMatchesValues(t *testing.T) {
+	for _, capacity := range []int{1, 2, 7, 64} {
+		b, err := New(Capacity(capacity))
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+
+		r := rand.New(rand.NewSource(int64(capacity)))
+		for i := 0; i < 1000; i++ {
+			b.Append(float64(r.Intn(100) - 50))
+
+			values := b.Values()
+			wantMin, wantMax := math.Inf(1), math.Inf(-1)
+			wantPosMin, wantPosMax, wantPosOK := math.Inf(1), math.Inf(-1), false
+			for _, v := range values {
+				wantMin = math.Min(wantMin, v)
+				wantMax = math.Max(wantMax, v)
+				if v > 0 {
+					wantPosMin = math.Min(wantPosMin, v)
+					wantPosMax = math.Max(wantPosMax, v)
+					wantPosOK = true
+				}
+			}
+			gotMin, gotMax, ok := b.MinMax()
+			if !ok || gotMin != wantMin || gotMax != wantMax {
+				t.Fatalf("capacity %d, append %d: MinMax => (%v, %v, %v), want (%v, %v, true) for values %v", capacity, i, gotMin, gotMax, ok, wantMin, wantMax, values)
+			}
+			if !wantPosOK {
+				wantPosMin, wantPosMax = 0, 0
+			}
+			gotMin, gotMax, ok = b.PositiveMinMax()
+			if ok != wantPosOK || gotMin != wantPosMin || gotMax != wantPosMax {
+				t.Fatalf("capacity %d, append %d: PositiveMinMax => (%v, %v, %v), want (%v, %v, %v) for values %v", capacity, i, gotMin, gotMax, ok, wantPosMin, wantPosMax, wantPosOK, values)
+			}
+		}
+		if got := len(b.values); got > capacity {
+			t.Errorf("capacity %d: the storage grew to %d samples", capacity, got)
+		}
+	}
+}
+
+func TestDefaultCapacity(t *testing.T) {
+	b, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	for i := 0; i < DefaultCapacity+10; i++ {
+		b.Append(float64(i))
+	}
+
+	if got, want := b.Len(), DefaultCapacity; got != want {
+		t.Errorf("Len => %d, want %d", got, want)
+	}
+	if got, want := b.Dropped(), 10; got != want {
+		t.Errorf("Dropped => %d, want %d", got, want)
+	}
+	gotMin, gotMax, _ := b.MinMax()
+	if wantMin, wantMax := 10.0, float64(DefaultCapacity+9); gotMin != wantMin || gotMax != wantMax {
+		t.Errorf("MinMax => (%v, %v), want (%v, %v)", gotMin, gotMax, wantMin, wantMax)
+	}
+}
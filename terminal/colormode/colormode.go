@@ -0,0 +1,160 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colormode detects the color capabilities of the terminal and
+// converts cell colors to the nearest colors the terminal can display.
+package colormode
+
+import (
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// numberedModes are the color modes in which some of the 256 numbered colors
+// are outside of the range of the mode.
+var numberedModes = []terminalapi.ColorMode{
+	terminalapi.ColorMode16,
+	terminalapi.ColorModeNormal,
+}
+
+// numberedColors are the numbered colors converted to the color modes in
+// numberedModes, indexed by the color number. Since these are fixed, they
+// are converted once instead of being cached.
+var numberedColors = func() map[terminalapi.ColorMode]*[256]cell.Color {
+	res := map[terminalapi.ColorMode]*[256]cell.Color{}
+	for _, cm := range numberedModes {
+		var colors [256]cell.Color
+		for n := range colors {
+			colors[n] = convert(cell.ColorNumber(n), cm)
+		}
+		res[cm] = &colors
+	}
+	return res
+}()
+
+// convKey is a key in the cache of converted colors.
+type convKey struct {
+	c  cell.Color
+	cm terminalapi.ColorMode
+}
+
+// maxCachedColors is the maximum number of converted colors in the cache.
+// Gradients of 24 bit colors can contain any number of distinct colors, so
+// the cache is cleared once it reaches this size.
+const maxCachedColors = 4096
+
+// convCache caches the converted 24 bit colors, since finding the nearest
+// color requires a search of the palette.
+var convCache = struct {
+	mu     sync.Mutex
+	colors map[convKey]cell.Color
+}{
+	colors: map[convKey]cell.Color{},
+}
+
+// Convert converts the color to the nearest color available in the color
+// mode. The returned color is interpreted the same way the termbox terminal
+// interprets colors in that mode, i.e. colors in ColorMode216 and
+// ColorModeGrayscale are zero based.
+//
+// Colors created by cell.ColorRGB24 and numbered colors outside of the range
+// of the color mode are replaced by the perceptually closest color of the
+// xterm palette that is within the range. Numbered colors outside of the
+// xterm palette, e.g. negative ones, are replaced by cell.ColorDefault.
+// Colors in ColorMode216 and ColorModeGrayscale are only converted if they
+// were created by cell.ColorRGB24, since the numbered colors already refer to
+// the reduced palette. All colors are converted to cell.ColorDefault in
+// ColorModeMonochrome. Colors in an unknown color mode are returned
+// unchanged.
+func Convert(c cell.Color, cm terminalapi.ColorMode) cell.Color {
+	if c == cell.ColorDefault {
+		return c
+	}
+
+	if _, _, _, isRGB := c.RGB24(); !isRGB {
+		// Colors are off-by-one due to ColorDefault being zero.
+		n := int(c) - 1
+		if colors, ok := numberedColors[cm]; ok && n >= 0 && n <= 255 {
+			return colors[n]
+		}
+		// Doesn't search the palette, numbered colors are either within
+		// the range of the remaining modes or outside of the palette.
+		return convert(c, cm)
+	}
+	if cm == terminalapi.ColorModeRGB || cm == terminalapi.ColorModeMonochrome {
+		return convert(c, cm)
+	}
+
+	key := convKey{c, cm}
+	convCache.mu.Lock()
+	defer convCache.mu.Unlock()
+	if res, ok := convCache.colors[key]; ok {
+		return res
+	}
+	res := convert(c, cm)
+	if len(convCache.colors) >= maxCachedColors {
+		convCache.colors = map[convKey]cell.Color{}
+	}
+	convCache.colors[key] = res
+	return res
+}
+
+// convert implements Convert without caching.
+func convert(c cell.Color, cm terminalapi.ColorMode) cell.Color {
+	_, _, _, isRGB := c.RGB24()
+	// Colors are off-by-one due to ColorDefault being zero.
+	n := int(c) - 1
+
+	switch cm {
+	case terminalapi.ColorModeRGB:
+		return c
+
+	case terminalapi.ColorMode256:
+		return reduce(c, isRGB || n < 0 || n > 255, 16, 255, 0)
+
+	case terminalapi.ColorMode216:
+		return reduce(c, isRGB, 16, 231, 16)
+
+	case terminalapi.ColorModeGrayscale:
+		return reduce(c, isRGB, 232, 255, 232)
+
+	case terminalapi.ColorMode16:
+		return reduce(c, isRGB || n < 0 || n > 15, 0, 15, 0)
+
+	case terminalapi.ColorModeNormal:
+		return reduce(c, isRGB || n < 0 || n > 7, 0, 7, 0)
+
+	case terminalapi.ColorModeMonochrome:
+		return cell.ColorDefault
+
+	default:
+		return c
+	}
+}
+
+// reduce returns the color unchanged unless needed is true, in which case it
+// returns the palette color in the range [min, max] closest to the color.
+// The offset is subtracted from the number of the returned color.
+func reduce(c cell.Color, needed bool, min, max, offset int) cell.Color {
+	if !needed {
+		return c
+	}
+	r, g, b, ok := RGB(c)
+	if !ok {
+		return cell.ColorDefault
+	}
+	return cell.ColorNumber(nearest(r, g, b, min, max) - offset)
+}
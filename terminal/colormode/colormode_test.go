@@ -0,0 +1,231 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colormode
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		desc  string
+		color cell.Color
+		cm    terminalapi.ColorMode
+		want  cell.Color
+	}{
+		{
+			desc:  "default color is never converted",
+			color: cell.ColorDefault,
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "RGB mode keeps 24 bit colors",
+			color: cell.ColorRGB24(1, 2, 3),
+			cm:    terminalapi.ColorModeRGB,
+			want:  cell.ColorRGB24(1, 2, 3),
+		},
+		{
+			desc:  "RGB mode keeps numbered colors",
+			color: cell.ColorNumber(200),
+			cm:    terminalapi.ColorModeRGB,
+			want:  cell.ColorNumber(200),
+		},
+		{
+			desc:  "256 mode keeps numbered colors",
+			color: cell.ColorNumber(200),
+			cm:    terminalapi.ColorMode256,
+			want:  cell.ColorNumber(200),
+		},
+		{
+			desc:  "256 mode maps exact cube color",
+			color: cell.ColorRGB24(95, 255, 135),
+			cm:    terminalapi.ColorMode256,
+			want:  cell.ColorNumber(84),
+		},
+		{
+			desc:  "256 mode maps gray to the grayscale ramp",
+			color: cell.ColorRGB24(128, 128, 128),
+			cm:    terminalapi.ColorMode256,
+			want:  cell.ColorNumber(244),
+		},
+		{
+			desc:  "256 mode maps pure red to the cube",
+			color: cell.ColorRGB24(255, 0, 0),
+			cm:    terminalapi.ColorMode256,
+			want:  cell.ColorNumber(196),
+		},
+		{
+			desc:  "216 mode maps to zero based cube colors",
+			color: cell.ColorRGB24(255, 0, 0),
+			cm:    terminalapi.ColorMode216,
+			want:  cell.ColorNumber(180),
+		},
+		{
+			desc:  "216 mode keeps numbered colors",
+			color: cell.ColorNumber(5),
+			cm:    terminalapi.ColorMode216,
+			want:  cell.ColorNumber(5),
+		},
+		{
+			desc:  "grayscale mode maps to zero based gray colors",
+			color: cell.ColorRGB24(128, 128, 128),
+			cm:    terminalapi.ColorModeGrayscale,
+			want:  cell.ColorNumber(12),
+		},
+		{
+			desc:  "16 mode keeps bright colors",
+			color: cell.ColorNumber(9),
+			cm:    terminalapi.ColorMode16,
+			want:  cell.ColorNumber(9),
+		},
+		{
+			desc:  "16 mode maps cube colors",
+			color: cell.ColorNumber(196),
+			cm:    terminalapi.ColorMode16,
+			want:  cell.ColorNumber(9),
+		},
+		{
+			desc:  "16 mode maps 24 bit colors",
+			color: cell.ColorRGB24(250, 250, 250),
+			cm:    terminalapi.ColorMode16,
+			want:  cell.ColorNumber(15),
+		},
+		{
+			desc:  "normal mode keeps system colors",
+			color: cell.ColorRed,
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorRed,
+		},
+		{
+			desc:  "normal mode maps bright colors",
+			color: cell.ColorNumber(9),
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorRed,
+		},
+		{
+			desc:  "normal mode maps 24 bit colors",
+			color: cell.ColorRGB24(0, 10, 250),
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorBlue,
+		},
+		{
+			desc:  "normal mode maps white to the system white",
+			color: cell.ColorRGB24(255, 255, 255),
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorWhite,
+		},
+		{
+			desc:  "monochrome mode drops colors",
+			color: cell.ColorRGB24(255, 0, 0),
+			cm:    terminalapi.ColorModeMonochrome,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "out of range colors become the default color",
+			color: cell.Color(1000),
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "negative colors become the default color in the normal mode",
+			color: cell.Color(-5),
+			cm:    terminalapi.ColorModeNormal,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "negative colors become the default color in 16 color mode",
+			color: cell.Color(-5),
+			cm:    terminalapi.ColorMode16,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "negative colors become the default color in 256 color mode",
+			color: cell.Color(-1),
+			cm:    terminalapi.ColorMode256,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "unknown color mode keeps colors",
+			color: cell.ColorRGB24(1, 2, 3),
+			cm:    terminalapi.ColorMode(-1),
+			want:  cell.ColorRGB24(1, 2, 3),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Twice to exercise the cache.
+			for i := 0; i < 2; i++ {
+				got := Convert(tc.color, tc.cm)
+				if got != tc.want {
+					t.Errorf("Convert(%v, %v) => %v, want %v", tc.color, tc.cm, got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertCacheIsBounded(t *testing.T) {
+	for r := 0; r < 256; r += 4 {
+		for g := 0; g < 256; g += 4 {
+			for _, cm := range []terminalapi.ColorMode{terminalapi.ColorMode256, terminalapi.ColorModeNormal} {
+				Convert(cell.ColorRGB24(r, g, 0), cm)
+			}
+		}
+	}
+	for n := 0; n < 256; n++ {
+		Convert(cell.ColorNumber(n), terminalapi.ColorMode16)
+	}
+
+	convCache.mu.Lock()
+	defer convCache.mu.Unlock()
+	if got := len(convCache.colors); got > maxCachedColors {
+		t.Errorf("len(convCache.colors) => %d, want at most %d", got, maxCachedColors)
+	}
+}
+
+func TestRGB(t *testing.T) {
+	tests := []struct {
+		color   cell.Color
+		r, g, b int
+		ok      bool
+	}{
+		{cell.ColorDefault, 0, 0, 0, false},
+		{cell.ColorBlack, 0, 0, 0, true},
+		{cell.ColorRed, 205, 0, 0, true},
+		{cell.ColorNumber(15), 255, 255, 255, true},
+		{cell.ColorNumber(16), 0, 0, 0, true},
+		{cell.ColorNumber(84), 95, 255, 135, true},
+		{cell.ColorNumber(231), 255, 255, 255, true},
+		{cell.ColorNumber(232), 8, 8, 8, true},
+		{cell.ColorNumber(255), 238, 238, 238, true},
+		{cell.ColorRGB24(1, 2, 3), 1, 2, 3, true},
+		{cell.Color(300), 0, 0, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.color), func(t *testing.T) {
+			r, g, b, ok := RGB(tc.color)
+			if r != tc.r || g != tc.g || b != tc.b || ok != tc.ok {
+				t.Errorf("RGB(%v) => (%v, %v, %v, %v), want (%v, %v, %v, %v)", tc.color, r, g, b, ok, tc.r, tc.g, tc.b, tc.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colormode
+
+// detect.go detects the color mode supported by the terminal.
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// Detect returns the color mode supported by the terminal the program runs
+// in.
+//
+// The detection follows the common conventions, in the order of precedence:
+//   - The NO_COLOR environment variable disables colors.
+//   - The COLORTERM environment variable set to "truecolor" or "24bit"
+//     indicates support for 24 bit colors.
+//   - The name of the terminal in the TERM environment variable, e.g.
+//     "xterm-256color" or "dumb".
+//   - The "colors" capability in the terminfo database.
+//
+// Returns terminalapi.ColorModeNormal if the color mode cannot be detected.
+func Detect() terminalapi.ColorMode {
+	return detect(os.Getenv)
+}
+
+// detect implements Detect, getenv returns the values of the environment
+// variables.
+func detect(getenv func(string) string) terminalapi.ColorMode {
+	if getenv("NO_COLOR") != "" {
+		return terminalapi.ColorModeMonochrome
+	}
+
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return terminalapi.ColorModeRGB
+	}
+
+	term := getenv("TERM")
+	switch {
+	case term == "dumb":
+		return terminalapi.ColorModeMonochrome
+	case strings.HasSuffix(term, "-truecolor"),
+		strings.HasSuffix(term, "-24bit"),
+		strings.HasSuffix(term, "-direct"):
+		return terminalapi.ColorModeRGB
+	case strings.HasSuffix(term, "-256color"):
+		return terminalapi.ColorMode256
+	case strings.HasSuffix(term, "-16color"):
+		return terminalapi.ColorMode16
+	}
+
+	data, err := readTerminfo(term, terminfoDirs(getenv))
+	if err != nil {
+		return terminalapi.ColorModeNormal
+	}
+	colors, err := terminfoColors(data)
+	if err != nil {
+		return terminalapi.ColorModeNormal
+	}
+	return fromColors(colors)
+}
+
+// fromColors returns the color mode that supports the provided number of
+// colors.
+func fromColors(colors int) terminalapi.ColorMode {
+	switch {
+	case colors >= 1<<24:
+		return terminalapi.ColorModeRGB
+	case colors >= 256:
+		return terminalapi.ColorMode256
+	case colors >= 16:
+		return terminalapi.ColorMode16
+	case colors >= 8:
+		return terminalapi.ColorModeNormal
+	case colors < 0:
+		// The capability isn't set, not the same as a monochrome terminal.
+		return terminalapi.ColorModeNormal
+	default:
+		return terminalapi.ColorModeMonochrome
+	}
+}
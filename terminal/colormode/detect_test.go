@@ -0,0 +1,194 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colormode
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// compileTerminfo returns a minimal compiled terminfo file with the provided
+// value of the "colors" capability. Uses the 32 bit format if ext is true.
+func compileTerminfo(name string, colors int, ext bool) []byte {
+	magic, numLen := terminfoMagic, 2
+	if ext {
+		magic, numLen = terminfoMagic32, 4
+	}
+	names := append([]byte(name), 0)
+	bools := []byte{1, 0} // Odd total length of names and bools requires padding.
+	if (len(names)+len(bools))%2 == 0 {
+		bools = append(bools, 0)
+	}
+	const numsCount = terminfoColorsIdx + 1
+
+	var data []byte
+	for _, v := range []int{magic, len(names), len(bools), numsCount, 0, 0} {
+		data = append(data, 0, 0)
+		binary.LittleEndian.PutUint16(data[len(data)-2:], uint16(v))
+	}
+	data = append(data, names...)
+	data = append(data, bools...)
+	data = append(data, 0) // Padding.
+	for i := 0; i < numsCount; i++ {
+		v := -1
+		if i == terminfoColorsIdx {
+			v = colors
+		}
+		num := make([]byte, numLen)
+		if ext {
+			binary.LittleEndian.PutUint32(num, uint32(int32(v)))
+		} else {
+			binary.LittleEndian.PutUint16(num, uint16(int16(v)))
+		}
+		data = append(data, num...)
+	}
+	return data
+}
+
+func TestDetect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminfo")
+	if err != nil {
+		t.Fatalf("TempDir => unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []struct {
+		subdir string
+		name   string
+		data   []byte
+	}{
+		{"t", "tdtest-mono", compileTerminfo("tdtest-mono", 2, false)},
+		{"t", "tdtest-eight", compileTerminfo("tdtest-eight", 8, false)},
+		{"74", "tdtest-hex", compileTerminfo("tdtest-hex", 88, false)},
+		{"t", "tdtest-direct", compileTerminfo("tdtest-direct", 1<<24, true)},
+		{"t", "tdtest-nocolors", compileTerminfo("tdtest-nocolors", -1, false)},
+		{"t", "tdtest-corrupt", []byte{1, 2, 3}},
+	}
+	for _, f := range files {
+		d := filepath.Join(dir, f.subdir)
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatalf("MkdirAll => unexpected error: %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(d, f.name), f.data, 0644); err != nil {
+			t.Fatalf("WriteFile => unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		desc string
+		env  map[string]string
+		want terminalapi.ColorMode
+	}{
+		{
+			desc: "nothing set",
+			want: terminalapi.ColorModeNormal,
+		},
+		{
+			desc: "NO_COLOR takes precedence",
+			env: map[string]string{
+				"NO_COLOR":  "1",
+				"COLORTERM": "truecolor",
+			},
+			want: terminalapi.ColorModeMonochrome,
+		},
+		{
+			desc: "COLORTERM truecolor",
+			env: map[string]string{
+				"COLORTERM": "truecolor",
+				"TERM":      "xterm",
+			},
+			want: terminalapi.ColorModeRGB,
+		},
+		{
+			desc: "COLORTERM 24bit",
+			env:  map[string]string{"COLORTERM": "24bit"},
+			want: terminalapi.ColorModeRGB,
+		},
+		{
+			desc: "dumb terminal",
+			env:  map[string]string{"TERM": "dumb"},
+			want: terminalapi.ColorModeMonochrome,
+		},
+		{
+			desc: "direct color terminal name",
+			env:  map[string]string{"TERM": "xterm-direct"},
+			want: terminalapi.ColorModeRGB,
+		},
+		{
+			desc: "256 color terminal name",
+			env:  map[string]string{"TERM": "screen-256color"},
+			want: terminalapi.ColorMode256,
+		},
+		{
+			desc: "16 color terminal name",
+			env:  map[string]string{"TERM": "rxvt-16color"},
+			want: terminalapi.ColorMode16,
+		},
+		{
+			desc: "terminfo with two colors",
+			env:  map[string]string{"TERM": "tdtest-mono", "TERMINFO": dir},
+			want: terminalapi.ColorModeMonochrome,
+		},
+		{
+			desc: "terminfo with eight colors",
+			env:  map[string]string{"TERM": "tdtest-eight", "TERMINFO": dir},
+			want: terminalapi.ColorModeNormal,
+		},
+		{
+			desc: "terminfo in a hexadecimal directory",
+			env:  map[string]string{"TERM": "tdtest-hex", "TERMINFO": dir},
+			want: terminalapi.ColorMode16,
+		},
+		{
+			desc: "terminfo with 32 bit numbers",
+			env:  map[string]string{"TERM": "tdtest-direct", "TERMINFO_DIRS": ":" + dir},
+			want: terminalapi.ColorModeRGB,
+		},
+		{
+			desc: "terminfo without the colors capability",
+			env:  map[string]string{"TERM": "tdtest-nocolors", "TERMINFO": dir},
+			want: terminalapi.ColorModeNormal,
+		},
+		{
+			desc: "corrupt terminfo",
+			env:  map[string]string{"TERM": "tdtest-corrupt", "TERMINFO": dir},
+			want: terminalapi.ColorModeNormal,
+		},
+		{
+			desc: "unknown terminal",
+			env:  map[string]string{"TERM": "tdtest-unknown", "TERMINFO": dir},
+			want: terminalapi.ColorModeNormal,
+		},
+		{
+			desc: "terminal name with a path is rejected",
+			env:  map[string]string{"TERM": "../t/tdtest-mono", "TERMINFO": dir},
+			want: terminalapi.ColorModeNormal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			getenv := func(k string) string { return tc.env[k] }
+			if got := detect(getenv); got != tc.want {
+				t.Errorf("detect => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colormode
+
+// palette.go defines the RGB values of the numbered terminal colors.
+
+import (
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// systemColors are the RGB values of the 16 "system" colors, i.e. the
+// colors 0-15 of the xterm palette.
+var systemColors = [16][3]int{
+	{0, 0, 0},
+	{205, 0, 0},
+	{0, 205, 0},
+	{205, 205, 0},
+	{0, 0, 238},
+	{205, 0, 205},
+	{0, 205, 205},
+	{229, 229, 229},
+	{127, 127, 127},
+	{255, 0, 0},
+	{0, 255, 0},
+	{255, 255, 0},
+	{92, 92, 255},
+	{255, 0, 255},
+	{0, 255, 255},
+	{255, 255, 255},
+}
+
+// cubeLevels are the intensities of the 6x6x6 color cube.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// xtermRGB returns the RGB value of the xterm color with the provided number.
+// The number must be in the range 0-255.
+func xtermRGB(n int) (r, g, b int) {
+	switch {
+	case n < 16:
+		c := systemColors[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[(n/6)%6], cubeLevels[n%6]
+	default:
+		v := 8 + (n-232)*10
+		return v, v, v
+	}
+}
+
+// RGB returns the RGB value the color is displayed with by a terminal that
+// uses the xterm palette, i.e. the value of colors created by
+// cell.ColorRGB24 or the palette entry of the system and numbered colors.
+// Returns false for cell.ColorDefault and colors outside of the palette.
+func RGB(c cell.Color) (r, g, b int, ok bool) {
+	if r, g, b, ok := c.RGB24(); ok {
+		return r, g, b, true
+	}
+	// Colors are off-by-one due to ColorDefault being zero.
+	n := int(c) - 1
+	if n < 0 || n > 255 {
+		return 0, 0, 0, false
+	}
+	r, g, b = xtermRGB(n)
+	return r, g, b, true
+}
+
+// lab is a color in the CIELAB color space, where the euclidean distance of
+// two colors approximates the difference perceived by the human eye.
+type lab struct {
+	l, a, b float64
+}
+
+// newLab converts the sRGB color to the CIELAB color space using the D65
+// white point.
+func newLab(r, g, b int) lab {
+	linear := func(c int) float64 {
+		v := float64(c) / 255
+		if v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	rl, gl, bl := linear(r), linear(g), linear(b)
+
+	x := (0.4124*rl + 0.3576*gl + 0.1805*bl) / 0.95047
+	y := 0.2126*rl + 0.7152*gl + 0.0722*bl
+	z := (0.0193*rl + 0.1192*gl + 0.9505*bl) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389.0 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27.0*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{
+		l: 116*fy - 16,
+		a: 500 * (fx - fy),
+		b: 200 * (fy - fz),
+	}
+}
+
+// distance returns the squared CIE76 color difference of the two colors.
+func (l lab) distance(other lab) float64 {
+	dl, da, db := l.l-other.l, l.a-other.a, l.b-other.b
+	return dl*dl + da*da + db*db
+}
+
+// paletteLab are the xterm palette colors converted to the CIELAB color
+// space, indexed by the color number.
+var paletteLab = func() [256]lab {
+	var res [256]lab
+	for n := range res {
+		res[n] = newLab(xtermRGB(n))
+	}
+	return res
+}()
+
+// nearest returns the number of the palette color in the range [min, max]
+// that is perceptually closest to the provided RGB value.
+func nearest(r, g, b, min, max int) int {
+	target := newLab(r, g, b)
+	best := min
+	bestDist := math.Inf(1)
+	for n := min; n <= max; n++ {
+		if d := target.distance(paletteLab[n]); d < bestDist {
+			best = n
+			bestDist = d
+		}
+	}
+	return best
+}
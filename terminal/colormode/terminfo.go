@@ -0,0 +1,125 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colormode
+
+// terminfo.go reads the number of colors from compiled terminfo files.
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// terminfoMagic identifies the legacy terminfo format with 16 bit
+	// numbers.
+	terminfoMagic = 0432
+	// terminfoMagic32 identifies the extended terminfo format with 32 bit
+	// numbers.
+	terminfoMagic32 = 01036
+
+	// terminfoHeaderLen is the length of the terminfo header in bytes.
+	terminfoHeaderLen = 12
+	// terminfoColorsIdx is the index of the "colors" capability among the
+	// numeric capabilities.
+	terminfoColorsIdx = 13
+)
+
+// terminfoDirs returns the directories that are searched for terminfo files
+// in the order of preference, see terminfo(5).
+func terminfoDirs(getenv func(string) string) []string {
+	var dirs []string
+	if d := getenv("TERMINFO"); d != "" {
+		dirs = append(dirs, d)
+	}
+	if h := getenv("HOME"); h != "" {
+		dirs = append(dirs, filepath.Join(h, ".terminfo"))
+	}
+	if ds := getenv("TERMINFO_DIRS"); ds != "" {
+		for _, d := range filepath.SplitList(ds) {
+			if d == "" {
+				d = "/usr/share/terminfo"
+			}
+			dirs = append(dirs, d)
+		}
+	}
+	return append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo")
+}
+
+// readTerminfo reads the compiled terminfo file of the terminal from the
+// first directory that has it.
+func readTerminfo(term string, dirs []string) ([]byte, error) {
+	if term == "" || term[0] == '.' || filepath.Base(term) != term {
+		return nil, fmt.Errorf("invalid terminal name %q", term)
+	}
+	for _, d := range dirs {
+		// Some systems use the first letter of the name as the
+		// subdirectory, others its hexadecimal value.
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			data, err := ioutil.ReadFile(filepath.Join(d, sub, term))
+			if err == nil {
+				return data, nil
+			}
+			if !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+	}
+	return nil, fmt.Errorf("no terminfo file found for terminal %q", term)
+}
+
+// terminfoColors returns the value of the "colors" capability from the
+// compiled terminfo file. Returns -1 if the capability isn't set.
+func terminfoColors(data []byte) (int, error) {
+	if len(data) < terminfoHeaderLen {
+		return 0, errors.New("the terminfo file is too short to contain the header")
+	}
+	header := make([]int, terminfoHeaderLen/2)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+
+	var numLen int
+	switch header[0] {
+	case terminfoMagic:
+		numLen = 2
+	case terminfoMagic32:
+		numLen = 4
+	default:
+		return 0, fmt.Errorf("unsupported terminfo magic number %#o", header[0])
+	}
+
+	namesLen, boolsLen, numsCount := header[1], header[2], header[3]
+	if numsCount <= terminfoColorsIdx {
+		return -1, nil
+	}
+	off := terminfoHeaderLen + namesLen + boolsLen
+	// The numbers section starts on an even byte.
+	if off%2 != 0 {
+		off++
+	}
+	off += terminfoColorsIdx * numLen
+	if off+numLen > len(data) {
+		return 0, errors.New("the terminfo file is too short to contain the numbers section")
+	}
+
+	if numLen == 2 {
+		return int(int16(binary.LittleEndian.Uint16(data[off:]))), nil
+	}
+	return int(int32(binary.LittleEndian.Uint32(data[off:]))), nil
+}
@@ -0,0 +1,357 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketerm
+
+// golden.go serializes the content of fake terminals into golden files.
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/cell"
+)
+
+// update when set, makes Golden rewrite the golden files instead of comparing
+// them.
+var update = flag.Bool("update", false, "rewrite the golden files of the faketerm.Golden tests")
+
+// Golden file format.
+//
+// The runes section contains one line per row of the terminal framed by '|'.
+// Cells without a rune are printed as spaces. The styles section uses the
+// same layout and contains one character per cell identifying its cell
+// options, the legend section maps the characters to the cell options.
+// E.g. for a terminal with size 3x1:
+//
+//	size: 3x1
+//	runes:
+//	|ab |
+//	styles:
+//	|a. |
+//	legend:
+//	.: fg=ColorDefault bg=ColorDefault
+//	a: fg=ColorRed bg=ColorDefault
+const (
+	sizeHeader   = "size: "
+	runesHeader  = "runes:"
+	stylesHeader = "styles:"
+	legendHeader = "legend:"
+	rowFrame     = '|'
+)
+
+// defaultStyle identifies the cell options with default colors.
+const defaultStyle = '.'
+
+// styleChars are the characters that identify the other cell options in the
+// order of their first appearance in the buffer.
+const styleChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// styleText returns the legend text describing the cell options.
+func styleText(o *cell.Options) string {
+	return fmt.Sprintf("fg=%v bg=%v", o.FgColor, o.BgColor)
+}
+
+// MarshalGolden serializes the content of the buffer into the text format of
+// golden files.
+func MarshalGolden(b cell.Buffer) ([]byte, error) {
+	size := b.Size()
+	var runes, styles bytes.Buffer
+	legend := map[string]rune{
+		styleText(cell.NewOptions()): defaultStyle,
+	}
+	var legendOrder []string
+
+	for row := 0; row < size.Y; row++ {
+		runes.WriteRune(rowFrame)
+		styles.WriteRune(rowFrame)
+		for col := 0; col < size.X; col++ {
+			c := b[col][row]
+			st := styleText(c.Opts)
+			ch, ok := legend[st]
+			if !ok {
+				if len(legend)-1 >= len(styleChars) {
+					return nil, fmt.Errorf("the buffer uses more than the %d supported distinct cell options", len(styleChars))
+				}
+				ch = rune(styleChars[len(legend)-1])
+				legend[st] = ch
+				legendOrder = append(legendOrder, st)
+			}
+			styles.WriteRune(ch)
+
+			partial, err := b.IsPartial(image.Point{col, row})
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case partial:
+				// Already printed as part of the wide rune in the previous cell.
+			case c.Rune == 0:
+				runes.WriteRune(' ')
+			default:
+				runes.WriteRune(c.Rune)
+			}
+		}
+		runes.WriteString(string(rowFrame) + "\n")
+		styles.WriteString(string(rowFrame) + "\n")
+	}
+
+	var res bytes.Buffer
+	fmt.Fprintf(&res, "%s%dx%d\n", sizeHeader, size.X, size.Y)
+	fmt.Fprintf(&res, "%s\n%s", runesHeader, runes.String())
+	fmt.Fprintf(&res, "%s\n%s", stylesHeader, styles.String())
+	fmt.Fprintf(&res, "%s\n", legendHeader)
+	fmt.Fprintf(&res, "%c: %s\n", defaultStyle, styleText(cell.NewOptions()))
+	for _, st := range legendOrder {
+		fmt.Fprintf(&res, "%c: %s\n", legend[st], st)
+	}
+	return res.Bytes(), nil
+}
+
+// UnmarshalGolden parses the text format of golden files and returns a new
+// fake terminal with the content.
+func UnmarshalGolden(data []byte) (*Terminal, error) {
+	var lines []string
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	next := func(what string) (string, error) {
+		if len(lines) == 0 {
+			return "", fmt.Errorf("unexpected end of the golden file, expected %s", what)
+		}
+		l := lines[0]
+		lines = lines[1:]
+		return l, nil
+	}
+	expect := func(header string) error {
+		l, err := next(header)
+		if err != nil {
+			return err
+		}
+		if l != header {
+			return fmt.Errorf("unexpected line %q, expected %q", l, header)
+		}
+		return nil
+	}
+
+	l, err := next(sizeHeader)
+	if err != nil {
+		return nil, err
+	}
+	var size image.Point
+	if _, err := fmt.Sscanf(l, sizeHeader+"%dx%d", &size.X, &size.Y); err != nil {
+		return nil, fmt.Errorf("invalid size line %q: %v", l, err)
+	}
+	t, err := New(size)
+	if err != nil {
+		return nil, err
+	}
+	b := t.BackBuffer()
+
+	if err := expect(runesHeader); err != nil {
+		return nil, err
+	}
+	runeRows := make([][]rune, size.Y)
+	for row := range runeRows {
+		l, err := next("a row of runes")
+		if err != nil {
+			return nil, err
+		}
+		runeRows[row], err = unframe(l)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expect(stylesHeader); err != nil {
+		return nil, err
+	}
+	styleRows := make([][]rune, size.Y)
+	for row := range styleRows {
+		l, err := next("a row of styles")
+		if err != nil {
+			return nil, err
+		}
+		styleRows[row], err = unframe(l)
+		if err != nil {
+			return nil, err
+		}
+		if len(styleRows[row]) != size.X {
+			return nil, fmt.Errorf("row %d of styles has %d cells, expected %d", row, len(styleRows[row]), size.X)
+		}
+	}
+
+	if err := expect(legendHeader); err != nil {
+		return nil, err
+	}
+	legend := map[rune]*cell.Options{}
+	for len(lines) > 0 {
+		l, _ := next("")
+		if l == "" {
+			continue
+		}
+		parts := strings.SplitN(l, ": ", 2)
+		if len(parts) != 2 || len([]rune(parts[0])) != 1 {
+			return nil, fmt.Errorf("invalid legend line %q", l)
+		}
+		opts, err := parseStyle(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid legend line %q: %v", l, err)
+		}
+		legend[[]rune(parts[0])[0]] = opts
+	}
+
+	for row := 0; row < size.Y; row++ {
+		for col, st := range styleRows[row] {
+			opts, ok := legend[st]
+			if !ok {
+				return nil, fmt.Errorf("style %q of cell (%d,%d) isn't in the legend", st, col, row)
+			}
+			b[col][row].Opts = cell.NewOptions(cell.FgColor(opts.FgColor), cell.BgColor(opts.BgColor))
+		}
+
+		col := 0
+		for _, r := range runeRows[row] {
+			if col >= size.X {
+				return nil, fmt.Errorf("row %d of runes is wider than %d cells", row, size.X)
+			}
+			if r != ' ' {
+				b[col][row].Rune = r
+			}
+			col += runewidth.RuneWidth(r)
+		}
+		if col != size.X {
+			return nil, fmt.Errorf("row %d of runes has %d cells, expected %d", row, col, size.X)
+		}
+	}
+	return t, nil
+}
+
+// unframe returns the runes of the line between the row frames.
+func unframe(line string) ([]rune, error) {
+	rs := []rune(line)
+	if len(rs) < 2 || rs[0] != rowFrame || rs[len(rs)-1] != rowFrame {
+		return nil, fmt.Errorf("row %q must start and end with %q", line, rowFrame)
+	}
+	return rs[1 : len(rs)-1], nil
+}
+
+// parseStyle parses the legend text created by styleText.
+func parseStyle(text string) (*cell.Options, error) {
+	fgIdx := strings.Index(text, "fg=")
+	bgIdx := strings.Index(text, " bg=")
+	if fgIdx != 0 || bgIdx < 0 {
+		return nil, errors.New("expected format fg=<color> bg=<color>")
+	}
+	fg, err := parseColor(text[len("fg="):bgIdx])
+	if err != nil {
+		return nil, err
+	}
+	bg, err := parseColor(text[bgIdx+len(" bg="):])
+	if err != nil {
+		return nil, err
+	}
+	return cell.NewOptions(cell.FgColor(fg), cell.BgColor(bg)), nil
+}
+
+// colorsByName maps the names of the named colors to their values.
+var colorsByName = func() map[string]cell.Color {
+	res := map[string]cell.Color{}
+	for c := cell.ColorDefault; c <= cell.ColorWhite; c++ {
+		res[c.String()] = c
+	}
+	return res
+}()
+
+// parseColor parses the color text created by cell.Color.String.
+func parseColor(text string) (cell.Color, error) {
+	if c, ok := colorsByName[text]; ok {
+		return c, nil
+	}
+	if strings.HasPrefix(text, "Color:") {
+		n, err := strconv.Atoi(strings.TrimPrefix(text, "Color:"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid color %q: %v", text, err)
+		}
+		return cell.Color(n), nil
+	}
+	var r, g, b int
+	if _, err := fmt.Sscanf(text, "ColorRGB24(%d, %d, %d)", &r, &g, &b); err == nil {
+		return cell.ColorRGB24(r, g, b), nil
+	}
+	return 0, fmt.Errorf("unknown color %q", text)
+}
+
+// Golden compares the content of the terminal with the golden file at the
+// provided path, usually a file in the testdata directory of the package.
+// Returns an error describing the differences in the same format as Diff if
+// the content doesn't match.
+//
+// If the tests run with the -update flag, the golden file is rewritten with
+// the content of the terminal instead. The flag is registered by this
+// package.
+func Golden(path string, got *Terminal) error {
+	gotData, err := MarshalGolden(got.BackBuffer())
+	if err != nil {
+		return err
+	}
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, gotData, 0644)
+	}
+
+	wantData, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%v, run the tests with the -update flag to create the golden file", err)
+	}
+	if bytes.Equal(gotData, wantData) {
+		return nil
+	}
+
+	want, err := UnmarshalGolden(wantData)
+	if err != nil {
+		return fmt.Errorf("unable to parse the golden file %q: %v", path, err)
+	}
+	// Normalize the terminal, so that the diff ignores differences that
+	// aren't represented in the golden files, e.g. space runes.
+	gotNorm, err := UnmarshalGolden(gotData)
+	if err != nil {
+		return err
+	}
+	if want.Size() != gotNorm.Size() {
+		return fmt.Errorf("the golden file %q has terminal size %v, got %v, run the tests with the -update flag to rewrite it", path, want.Size(), gotNorm.Size())
+	}
+	diff := Diff(want, gotNorm)
+	if diff == "" {
+		return fmt.Errorf("the golden file %q doesn't match the expected format, run the tests with the -update flag to rewrite it", path)
+	}
+	return fmt.Errorf("the terminal doesn't match the golden file %q, run the tests with the -update flag to rewrite it: %s", path, diff)
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketerm
+
+import (
+	"image"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// goldenTerm returns a terminal with content that exercises the golden file
+// format.
+func goldenTerm() *Terminal {
+	ft := MustNew(image.Point{6, 2})
+	mustSetCell := func(p image.Point, r rune, opts ...cell.Option) {
+		if err := ft.SetCell(p, r, opts...); err != nil {
+			panic(err)
+		}
+	}
+	mustSetCell(image.Point{0, 0}, 'a', cell.FgColor(cell.ColorRed))
+	mustSetCell(image.Point{1, 0}, '世', cell.BgColor(cell.ColorNumber(42)))
+	mustSetCell(image.Point{3, 0}, '|')
+	mustSetCell(image.Point{5, 1}, 'z', cell.FgColor(cell.ColorRGB24(1, 2, 3)), cell.BgColor(cell.ColorRed))
+	return ft
+}
+
+// goldenText is the serialized content of goldenTerm.
+const goldenText = `size: 6x2
+runes:
+|a世|  |
+|     z|
+styles:
+|ab....|
+|.....c|
+legend:
+.: fg=ColorDefault bg=ColorDefault
+a: fg=ColorRed bg=ColorDefault
+b: fg=ColorDefault bg=Color:43
+c: fg=ColorRGB24(1, 2, 3) bg=ColorRed
+`
+
+func TestMarshalGolden(t *testing.T) {
+	got, err := MarshalGolden(goldenTerm().BackBuffer())
+	if err != nil {
+		t.Fatalf("MarshalGolden => unexpected error: %v", err)
+	}
+	if string(got) != goldenText {
+		t.Errorf("MarshalGolden =>\n%s\nwant:\n%s", got, goldenText)
+	}
+}
+
+func TestMarshalGoldenTooManyStyles(t *testing.T) {
+	ft := MustNew(image.Point{100, 1})
+	for col := 0; col < 100; col++ {
+		if err := ft.SetCell(image.Point{col, 0}, 'x', cell.FgColor(cell.ColorNumber(col))); err != nil {
+			t.Fatalf("SetCell => unexpected error: %v", err)
+		}
+	}
+	if _, err := MarshalGolden(ft.BackBuffer()); err == nil {
+		t.Errorf("MarshalGolden => got nil error, want an error")
+	}
+}
+
+func TestUnmarshalGolden(t *testing.T) {
+	tests := []struct {
+		desc    string
+		data    string
+		want    *Terminal
+		wantErr bool
+	}{
+		{
+			desc: "parses serialized terminal",
+			data: goldenText,
+			want: goldenTerm(),
+		},
+		{
+			desc:    "fails on empty data",
+			data:    "",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on invalid size",
+			data:    "size: ax1\n",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on missing rows",
+			data:    "size: 1x2\nruns:\n| |\n",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on row without frame",
+			data:    "size: 1x1\nrunes:\n \nstyles:\n|.|\nlegend:\n",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on row of runes that is too short",
+			data:    "size: 2x1\nrunes:\n| |\nstyles:\n|..|\nlegend:\n.: fg=ColorDefault bg=ColorDefault\n",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on row of styles that is too short",
+			data:    "size: 2x1\nrunes:\n|  |\nstyles:\n|.|\nlegend:\n.: fg=ColorDefault bg=ColorDefault\n",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on style missing in the legend",
+			data:    "size: 1x1\nrunes:\n| |\nstyles:\n|a|\nlegend:\n.: fg=ColorDefault bg=ColorDefault\n",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on unknown color",
+			data:    "size: 1x1\nrunes:\n| |\nstyles:\n|.|\nlegend:\n.: fg=Purple bg=ColorDefault\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := UnmarshalGolden([]byte(tc.data))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("UnmarshalGolden => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := Diff(tc.want, got); diff != "" {
+				t.Errorf("UnmarshalGolden => %v", diff)
+			}
+		})
+	}
+}
+
+func TestGolden(t *testing.T) {
+	if err := Golden(filepath.Join("testdata", "golden.golden"), goldenTerm()); err != nil {
+		t.Errorf("Golden => unexpected error: %v", err)
+	}
+
+	changed := goldenTerm()
+	if err := changed.SetCell(image.Point{0, 1}, 'x'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	err := Golden(filepath.Join("testdata", "golden.golden"), changed)
+	if err == nil || !strings.Contains(err.Error(), "found differences") {
+		t.Errorf("Golden => %v, want an error describing the differences", err)
+	}
+
+	resized := MustNew(image.Point{1, 1})
+	if err := Golden(filepath.Join("testdata", "golden.golden"), resized); err == nil {
+		t.Errorf("Golden => got nil error for a terminal of a different size, want an error")
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golden")
+	if err != nil {
+		t.Fatalf("TempDir => unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "new.golden")
+
+	if err := Golden(path, goldenTerm()); err == nil {
+		t.Errorf("Golden => got nil error for a missing golden file, want an error")
+	}
+
+	defer func(prev bool) { *update = prev }(*update)
+	*update = true
+	if err := Golden(path, goldenTerm()); err != nil {
+		t.Fatalf("Golden with -update => unexpected error: %v", err)
+	}
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile => unexpected error: %v", err)
+	}
+	if string(got) != goldenText {
+		t.Errorf("Golden with -update wrote:\n%s\nwant:\n%s", got, goldenText)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inline
+
+// ansi.go renders the content of the terminal using ANSI escape sequences.
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/colormode"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// ANSI escape sequences used by the inline terminal.
+const (
+	resetSGR    = "\x1b[0m"
+	clearToEOL  = "\x1b[K"
+	cursorDown  = "\x1b[B"
+	cursorUpN   = "\x1b[%dA"
+	cursorDownN = "\x1b[%dB"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// colorSGR returns the parameters of the Select Graphic Rendition sequence
+// that sets the foreground (if fg is true) or the background color.
+// The cell color is first converted to the nearest color available in the
+// color mode and then interpreted the same way the termbox terminal
+// interprets it.
+func colorSGR(cm terminalapi.ColorMode, c cell.Color, fg bool) (string, error) {
+	base := 3
+	if !fg {
+		base = 4
+	}
+	deflt := fmt.Sprintf("%d9", base)
+
+	c = colormode.Convert(c, cm)
+	// Colors are off-by-one due to ColorDefault being zero.
+	n := int(c) - 1
+	switch cm {
+	case terminalapi.ColorModeMonochrome:
+		return deflt, nil
+	case terminalapi.ColorModeNormal:
+		if n < 0 || n > 7 {
+			return deflt, nil
+		}
+		return fmt.Sprintf("%d%d", base, n), nil
+	case terminalapi.ColorMode16:
+		if n < 0 || n > 15 {
+			return deflt, nil
+		}
+		if n > 7 {
+			// The bright colors use the aixterm sequences 90-97 and 100-107.
+			return fmt.Sprintf("%d", (base+6)*10+n-8), nil
+		}
+		return fmt.Sprintf("%d%d", base, n), nil
+	case terminalapi.ColorModeRGB:
+		if r, g, b, ok := c.RGB24(); ok {
+			return fmt.Sprintf("%d8;2;%d;%d;%d", base, r, g, b), nil
+		}
+		if n < 0 || n > 255 {
+			return deflt, nil
+		}
+	case terminalapi.ColorMode256:
+		if n < 0 || n > 255 {
+			return deflt, nil
+		}
+	case terminalapi.ColorMode216:
+		if n < 0 || n > 215 {
+			return deflt, nil
+		}
+		n += 16
+	case terminalapi.ColorModeGrayscale:
+		if n < 0 || n > 23 {
+			return deflt, nil
+		}
+		n += 232
+	default:
+		return "", fmt.Errorf("unsupported color mode %v", cm)
+	}
+	return fmt.Sprintf("%d8;5;%d", base, n), nil
+}
+
+// renderLines renders each row of the buffer into a line of text containing
+// the runes and the escape sequences that set their colors.
+func renderLines(b cell.Buffer, cm terminalapi.ColorMode) ([]string, error) {
+	size := b.Size()
+	var lines []string
+	for row := 0; row < size.Y; row++ {
+		var line bytes.Buffer
+		var cur *cell.Options
+		for col := 0; col < size.X; col++ {
+			p := image.Point{col, row}
+			partial, err := b.IsPartial(p)
+			if err != nil {
+				return nil, err
+			}
+			if partial {
+				continue
+			}
+
+			c := b[col][row]
+			if cur == nil || *cur != *c.Opts {
+				fg, err := colorSGR(cm, c.Opts.FgColor, true)
+				if err != nil {
+					return nil, err
+				}
+				bg, err := colorSGR(cm, c.Opts.BgColor, false)
+				if err != nil {
+					return nil, err
+				}
+				fmt.Fprintf(&line, "\x1b[%s;%sm", fg, bg)
+				cur = c.Opts
+			}
+
+			r := c.Rune
+			if r == 0 {
+				r = ' '
+			}
+			line.WriteRune(r)
+		}
+		lines = append(lines, line.String())
+	}
+	return lines, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inline
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+func TestColorSGR(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cm      terminalapi.ColorMode
+		color   cell.Color
+		fg      bool
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:    "fails on unsupported color mode",
+			cm:      terminalapi.ColorMode(-1),
+			wantErr: true,
+		},
+		{
+			desc:  "default foreground color",
+			cm:    terminalapi.ColorMode256,
+			color: cell.ColorDefault,
+			fg:    true,
+			want:  "39",
+		},
+		{
+			desc:  "default background color",
+			cm:    terminalapi.ColorMode256,
+			color: cell.ColorDefault,
+			want:  "49",
+		},
+		{
+			desc:  "system color in normal mode",
+			cm:    terminalapi.ColorModeNormal,
+			color: cell.ColorGreen,
+			fg:    true,
+			want:  "32",
+		},
+		{
+			desc:  "color out of range of the normal mode is degraded",
+			cm:    terminalapi.ColorModeNormal,
+			color: cell.ColorNumber(100),
+			want:  "43",
+		},
+		{
+			desc:  "bright color in 16 color mode",
+			cm:    terminalapi.ColorMode16,
+			color: cell.ColorNumber(9),
+			fg:    true,
+			want:  "91",
+		},
+		{
+			desc:  "bright background color in 16 color mode",
+			cm:    terminalapi.ColorMode16,
+			color: cell.ColorNumber(15),
+			want:  "107",
+		},
+		{
+			desc:  "system color in 16 color mode",
+			cm:    terminalapi.ColorMode16,
+			color: cell.ColorBlue,
+			fg:    true,
+			want:  "34",
+		},
+		{
+			desc:  "24 bit color in RGB mode",
+			cm:    terminalapi.ColorModeRGB,
+			color: cell.ColorRGB24(1, 2, 3),
+			fg:    true,
+			want:  "38;2;1;2;3",
+		},
+		{
+			desc:  "numbered color in RGB mode",
+			cm:    terminalapi.ColorModeRGB,
+			color: cell.ColorNumber(100),
+			want:  "48;5;100",
+		},
+		{
+			desc:  "24 bit color in 256 mode is degraded",
+			cm:    terminalapi.ColorMode256,
+			color: cell.ColorRGB24(255, 0, 0),
+			fg:    true,
+			want:  "38;5;196",
+		},
+		{
+			desc:  "monochrome mode ignores colors",
+			cm:    terminalapi.ColorModeMonochrome,
+			color: cell.ColorRed,
+			fg:    true,
+			want:  "39",
+		},
+		{
+			desc:  "color in 256 mode",
+			cm:    terminalapi.ColorMode256,
+			color: cell.ColorNumber(100),
+			fg:    true,
+			want:  "38;5;100",
+		},
+		{
+			desc:  "color in 216 mode is offset",
+			cm:    terminalapi.ColorMode216,
+			color: cell.ColorNumber(0),
+			want:  "48;5;16",
+		},
+		{
+			desc:  "color in grayscale mode is offset",
+			cm:    terminalapi.ColorModeGrayscale,
+			color: cell.ColorNumber(23),
+			fg:    true,
+			want:  "38;5;255",
+		},
+		{
+			desc:  "color out of range of the grayscale mode",
+			cm:    terminalapi.ColorModeGrayscale,
+			color: cell.ColorNumber(24),
+			fg:    true,
+			want:  "39",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := colorSGR(tc.cm, tc.color, tc.fg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("colorSGR => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want {
+				t.Errorf("colorSGR => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderLines(t *testing.T) {
+	b, err := cell.NewBuffer(image.Point{3, 2})
+	if err != nil {
+		t.Fatalf("NewBuffer => unexpected error: %v", err)
+	}
+	if _, err := b.SetCell(image.Point{0, 0}, '世'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if _, err := b.SetCell(image.Point{2, 0}, 'a', cell.BgColor(cell.ColorRed)); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+
+	got, err := renderLines(b, terminalapi.ColorModeNormal)
+	if err != nil {
+		t.Fatalf("renderLines => unexpected error: %v", err)
+	}
+	want := []string{
+		"\x1b[39;49m世\x1b[39;41ma",
+		"\x1b[39;49m   ",
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("renderLines => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
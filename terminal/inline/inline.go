@@ -0,0 +1,288 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package inline implements a terminal that renders into a fixed number of lines
+below the current cursor position.
+
+Unlike the full screen terminals, the inline terminal doesn't switch to the
+alternate screen. Each flush redraws the reserved lines in place and when the
+terminal is closed, the cursor is moved below the last frame which remains in
+the scrollback of the terminal emulator. This is useful for command line tools
+that want to display progress without taking over the whole screen.
+
+The inline terminal only produces output, it doesn't receive any keyboard or
+mouse events.
+*/
+package inline
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/colormode"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*Terminal)
+}
+
+// option implements Option.
+type option func(*Terminal)
+
+// set implements Option.set.
+func (o option) set(t *Terminal) {
+	o(t)
+}
+
+// Output sets the writer the terminal renders into.
+// Defaults to os.Stdout.
+func Output(w io.Writer) Option {
+	return option(func(t *Terminal) {
+		t.out = w
+	})
+}
+
+// DefaultWidth is the width used when the Width option isn't provided and
+// the width of the output can't be determined, e.g. when the output isn't a
+// terminal.
+const DefaultWidth = 80
+
+// Width sets the width of the terminal in cells.
+// If not provided, the width of the output terminal is used, except on
+// Windows where DefaultWidth is used.
+func Width(w int) Option {
+	return option(func(t *Terminal) {
+		t.width = w
+	})
+}
+
+// ColorMode sets the terminal color mode.
+// Defaults to the color mode detected by colormode.Detect.
+func ColorMode(cm terminalapi.ColorMode) Option {
+	return option(func(t *Terminal) {
+		t.colorMode = cm
+	})
+}
+
+// Terminal renders into a fixed number of lines below the current cursor
+// position. This object is thread-safe.
+// Implements terminalapi.Terminal.
+type Terminal struct {
+	// out is where the terminal renders.
+	out io.Writer
+
+	// back is the back buffer modified by calls to SetCell and Clear.
+	back cell.Buffer
+	// lines are the rendered lines from the previous flush.
+	// Nil until the first flush which reserves the lines on the screen.
+	lines []string
+
+	// closed indicates that Close() was called.
+	closed bool
+
+	// mu protects the Terminal.
+	mu sync.Mutex
+
+	// Options.
+	width     int
+	colorMode terminalapi.ColorMode
+}
+
+// New returns a new inline Terminal that occupies the provided number of
+// lines. If the output is a terminal with fewer lines, the number of lines is
+// reduced to fit it, since lines scrolled off the screen can't be updated.
+// Call Close() when the terminal isn't required anymore.
+func New(height int, opts ...Option) (*Terminal, error) {
+	if min := 1; height < min {
+		return nil, fmt.Errorf("invalid height %d, must be at least %d", height, min)
+	}
+
+	t := &Terminal{
+		out:       os.Stdout,
+		colorMode: colormode.Detect(),
+	}
+	for _, opt := range opts {
+		opt.set(t)
+	}
+
+	screen, ok := screenSize(t.out)
+	switch {
+	case t.width == 0 && ok:
+		t.width = screen.X
+	case t.width == 0:
+		t.width = DefaultWidth
+	}
+	if ok && height > screen.Y {
+		height = screen.Y
+	}
+	// Validates the color mode.
+	if _, err := colorSGR(t.colorMode, cell.ColorDefault, true); err != nil {
+		return nil, err
+	}
+
+	b, err := cell.NewBuffer(image.Point{t.width, height})
+	if err != nil {
+		return nil, err
+	}
+	t.back = b
+	return t, nil
+}
+
+// screenSize returns the size of the screen if the writer is a terminal.
+func screenSize(w io.Writer) (image.Point, bool) {
+	f, ok := w.(*os.File)
+	if !ok {
+		return image.ZP, false
+	}
+	size, ok := fdSize(f.Fd())
+	if !ok || size.X < 1 || size.Y < 1 {
+		return image.ZP, false
+	}
+	return size, true
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.back.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, err := cell.NewBuffer(t.back.Size())
+	if err != nil {
+		return err
+	}
+	for _, col := range b {
+		for _, c := range col {
+			c.Apply(opts...)
+		}
+	}
+	t.back = b
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+// Only the lines that changed since the previous flush are redrawn. After the
+// flush the cursor is parked at the beginning of the first line.
+func (t *Terminal) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return errors.New("the terminal is closed")
+	}
+
+	lines, err := renderLines(t.back, t.colorMode)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(t.out)
+	first := t.lines == nil
+	if first {
+		w.WriteString(hideCursor)
+	}
+	for i, l := range lines {
+		if first || l != t.lines[i] {
+			w.WriteString("\r")
+			w.WriteString(l)
+			w.WriteString(resetSGR + clearToEOL)
+		}
+		if i == len(lines)-1 {
+			break
+		}
+		if first {
+			// The new lines scroll the screen if needed to make space.
+			w.WriteString("\r\n")
+		} else {
+			w.WriteString(cursorDown)
+		}
+	}
+	if n := len(lines) - 1; n > 0 {
+		fmt.Fprintf(w, cursorUpN, n)
+	}
+	w.WriteString("\r")
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	t.lines = lines
+	return nil
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+// The inline terminal always hides the cursor, this is a no-op.
+func (t *Terminal) SetCursor(p image.Point) {}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+// The inline terminal always hides the cursor, this is a no-op.
+func (t *Terminal) HideCursor() {}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.back.SetCell(p, r, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+// The inline terminal doesn't receive any input events, this blocks until the
+// context expires.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	<-ctx.Done()
+	return terminalapi.NewErrorf("unable to pull the next event: %v", ctx.Err())
+}
+
+// Close closes the terminal, should be called when the terminal isn't required
+// anymore. Moves the cursor below the last flushed frame, leaving the frame in
+// the scrollback of the terminal emulator.
+// Implements terminalapi.Terminal.Close.
+func (t *Terminal) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return
+	}
+	t.closed = true
+	if t.lines == nil {
+		return
+	}
+
+	w := bufio.NewWriter(t.out)
+	if n := len(t.lines) - 1; n > 0 {
+		fmt.Fprintf(w, cursorDownN, n)
+	}
+	w.WriteString("\r\n" + resetSGR + showCursor)
+	w.Flush()
+}
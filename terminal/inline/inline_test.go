@@ -0,0 +1,205 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inline
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+func TestNew(t *testing.T) {
+	f, err := ioutil.TempFile("", "inline")
+	if err != nil {
+		t.Fatalf("TempFile => unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	tests := []struct {
+		desc     string
+		height   int
+		opts     []Option
+		wantSize image.Point
+		wantErr  bool
+	}{
+		{
+			desc:    "fails on zero height",
+			height:  0,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on unsupported color mode",
+			height:  1,
+			opts:    []Option{ColorMode(terminalapi.ColorMode(-1))},
+			wantErr: true,
+		},
+		{
+			desc:     "uses the default width when the output isn't a terminal",
+			height:   2,
+			opts:     []Option{Output(&bytes.Buffer{})},
+			wantSize: image.Point{DefaultWidth, 2},
+		},
+		{
+			desc:     "uses the default width when the output is a file that isn't a terminal",
+			height:   2,
+			opts:     []Option{Output(f)},
+			wantSize: image.Point{DefaultWidth, 2},
+		},
+		{
+			desc:   "uses the provided width",
+			height: 3,
+			opts: []Option{
+				Output(&bytes.Buffer{}),
+				Width(10),
+			},
+			wantSize: image.Point{10, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := New(tc.height, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if size := got.Size(); size != tc.wantSize {
+				t.Errorf("Size => %v, want %v", size, tc.wantSize)
+			}
+		})
+	}
+}
+
+func TestFlushAndClose(t *testing.T) {
+	var out bytes.Buffer
+	term, err := New(2, Output(&out), Width(3), ColorMode(terminalapi.ColorMode256))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	// The following steps aren't hermetic, they share the same terminal in
+	// order to verify that only the changes are redrawn.
+	tests := []struct {
+		desc   string
+		action func() error
+		want   string
+	}{
+		{
+			desc: "first flush reserves the lines",
+			action: func() error {
+				if err := term.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				return term.Flush()
+			},
+			want: hideCursor +
+				"\r\x1b[39;49ma  " + resetSGR + clearToEOL + "\r\n" +
+				"\r\x1b[39;49m   " + resetSGR + clearToEOL +
+				"\x1b[1A\r",
+		},
+		{
+			desc: "redraws only the changed lines",
+			action: func() error {
+				if err := term.SetCell(image.Point{1, 1}, 'b', cell.FgColor(cell.ColorRed)); err != nil {
+					return err
+				}
+				return term.Flush()
+			},
+			want: cursorDown +
+				"\r\x1b[39;49m \x1b[38;5;1;49mb\x1b[39;49m " + resetSGR + clearToEOL +
+				"\x1b[1A\r",
+		},
+		{
+			desc: "flush without changes only repositions the cursor",
+			action: func() error {
+				return term.Flush()
+			},
+			want: cursorDown + "\x1b[1A\r",
+		},
+		{
+			desc: "close leaves the frame on the screen",
+			action: func() error {
+				term.Close()
+				return nil
+			},
+			want: "\x1b[1B\r\n" + resetSGR + showCursor,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			out.Reset()
+			if err := tc.action(); err != nil {
+				t.Fatalf("action => unexpected error: %v", err)
+			}
+			if got := out.String(); got != tc.want {
+				t.Errorf("output => %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if err := term.Flush(); err == nil {
+		t.Errorf("Flush after Close => got nil error, want an error")
+	}
+}
+
+func TestClear(t *testing.T) {
+	var out bytes.Buffer
+	term, err := New(1, Output(&out), Width(2), ColorMode(terminalapi.ColorMode256))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := term.SetCell(image.Point{0, 0}, 'a'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := term.Clear(cell.BgColor(cell.ColorBlue)); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+
+	want := hideCursor + "\r\x1b[39;48;5;4m  " + resetSGR + clearToEOL + "\r"
+	if got := out.String(); got != want {
+		t.Errorf("output => %q, want %q", got, want)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	term, err := New(1, Output(&bytes.Buffer{}))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer term.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	got := term.Event(ctx)
+	want := terminalapi.NewError("unable to pull the next event: context deadline exceeded")
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Event => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary inlinedemo simulates a deployment script that displays its progress
+// in a few lines below the prompt. The final frame remains on the screen
+// when the demo exits.
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/inline"
+	"github.com/mum4k/termdash/widgets/gauge"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// deploy simulates a deployment to the provided number of hosts, reporting
+// the progress on the gauge and the log. Calls cancel when done.
+func deploy(g *gauge.Gauge, log *text.Text, hosts int, cancel context.CancelFunc) {
+	defer cancel()
+	for i := 1; i <= hosts; i++ {
+		time.Sleep(300 * time.Millisecond)
+		if err := log.Write(fmt.Sprintf("deployed to host-%02d\n", i)); err != nil {
+			panic(err)
+		}
+		if err := g.Absolute(i, hosts); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func main() {
+	t, err := inline.New(8)
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	g := gauge.New(gauge.Height(1), gauge.TextLabel("hosts"))
+	log := text.New(text.RollContent())
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("Deploying"),
+		container.SplitHorizontal(
+			container.Top(container.PlaceWidget(g)),
+			container.Bottom(container.PlaceWidget(log)),
+			container.SplitPercent(20),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go deploy(g, log, 20, cancel)
+
+	ctrl, err := termdash.NewController(t, c)
+	if err != nil {
+		panic(err)
+	}
+	defer ctrl.Close()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ctrl.Redraw(); err != nil {
+				panic(err)
+			}
+		case <-ctx.Done():
+			// Draw the final frame before exiting.
+			if err := ctrl.Redraw(); err != nil {
+				panic(err)
+			}
+			return
+		}
+	}
+}
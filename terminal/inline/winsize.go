@@ -0,0 +1,42 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package inline
+
+import (
+	"image"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the window size reported by the TIOCGWINSZ ioctl.
+type winsize struct {
+	rows    uint16
+	cols    uint16
+	xpixels uint16
+	ypixels uint16
+}
+
+// fdSize returns the size of the terminal the file descriptor refers to.
+// Returns false if the file descriptor doesn't refer to a terminal.
+func fdSize(fd uintptr) (image.Point, bool) {
+	var ws winsize
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws))); errno != 0 {
+		return image.ZP, false
+	}
+	return image.Point{int(ws.cols), int(ws.rows)}, true
+}
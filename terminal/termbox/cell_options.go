@@ -18,20 +18,24 @@ package termbox
 
 import (
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/colormode"
+	"github.com/mum4k/termdash/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
 
 // cellColor converts termdash cell color to the termbox format.
-func cellColor(c cell.Color) tbx.Attribute {
-	return tbx.Attribute(c)
+// The color is first converted to the nearest color available in the color
+// mode.
+func cellColor(c cell.Color, cm terminalapi.ColorMode) tbx.Attribute {
+	return tbx.Attribute(colormode.Convert(c, cm))
 }
 
 // cellOptsToFg converts the cell options to the termbox foreground attribute.
-func cellOptsToFg(opts *cell.Options) tbx.Attribute {
-	return cellColor(opts.FgColor)
+func cellOptsToFg(opts *cell.Options, cm terminalapi.ColorMode) tbx.Attribute {
+	return cellColor(opts.FgColor, cm)
 }
 
 // cellOptsToBg converts the cell options to the termbox background attribute.
-func cellOptsToBg(opts *cell.Options) tbx.Attribute {
-	return cellColor(opts.BgColor)
+func cellOptsToBg(opts *cell.Options, cm terminalapi.ColorMode) tbx.Attribute {
+	return cellColor(opts.BgColor, cm)
 }
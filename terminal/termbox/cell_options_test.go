@@ -18,31 +18,37 @@ import (
 	"testing"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
 
 func TestCellColor(t *testing.T) {
 	tests := []struct {
+		desc  string
 		color cell.Color
+		cm    terminalapi.ColorMode
 		want  tbx.Attribute
 	}{
-		{cell.ColorDefault, tbx.ColorDefault},
-		{cell.ColorBlack, tbx.ColorBlack},
-		{cell.ColorRed, tbx.ColorRed},
-		{cell.ColorGreen, tbx.ColorGreen},
-		{cell.ColorYellow, tbx.ColorYellow},
-		{cell.ColorBlue, tbx.ColorBlue},
-		{cell.ColorMagenta, tbx.ColorMagenta},
-		{cell.ColorCyan, tbx.ColorCyan},
-		{cell.ColorWhite, tbx.ColorWhite},
-		{cell.Color(42), tbx.Attribute(42)},
+		{"default", cell.ColorDefault, terminalapi.ColorModeNormal, tbx.ColorDefault},
+		{"black", cell.ColorBlack, terminalapi.ColorModeNormal, tbx.ColorBlack},
+		{"red", cell.ColorRed, terminalapi.ColorModeNormal, tbx.ColorRed},
+		{"green", cell.ColorGreen, terminalapi.ColorModeNormal, tbx.ColorGreen},
+		{"yellow", cell.ColorYellow, terminalapi.ColorModeNormal, tbx.ColorYellow},
+		{"blue", cell.ColorBlue, terminalapi.ColorModeNormal, tbx.ColorBlue},
+		{"magenta", cell.ColorMagenta, terminalapi.ColorModeNormal, tbx.ColorMagenta},
+		{"cyan", cell.ColorCyan, terminalapi.ColorModeNormal, tbx.ColorCyan},
+		{"white", cell.ColorWhite, terminalapi.ColorModeNormal, tbx.ColorWhite},
+		{"numbered color in 256 mode", cell.Color(42), terminalapi.ColorMode256, tbx.Attribute(42)},
+		{"numbered color in normal mode", cell.ColorNumber(196), terminalapi.ColorModeNormal, tbx.ColorRed},
+		{"24 bit color in 256 mode", cell.ColorRGB24(95, 255, 135), terminalapi.ColorMode256, tbx.Attribute(85)},
+		{"monochrome mode", cell.ColorRed, terminalapi.ColorModeMonochrome, tbx.ColorDefault},
 	}
 
 	for _, tc := range tests {
-		t.Run(tc.color.String(), func(t *testing.T) {
-			got := cellColor(tc.color)
+		t.Run(tc.desc, func(t *testing.T) {
+			got := cellColor(tc.color, tc.cm)
 			if got != tc.want {
-				t.Errorf("cellColor(%v) => got %v, want %v", tc.color, got, tc.want)
+				t.Errorf("cellColor(%v, %v) => got %v, want %v", tc.color, tc.cm, got, tc.want)
 			}
 
 		})
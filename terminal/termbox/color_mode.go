@@ -22,17 +22,22 @@ import (
 )
 
 // colorMode converts termdash color modes to the termbox format.
-func colorMode(cm terminalapi.ColorMode) (tbx.OutputMode, error) {
+// Termbox doesn't support all the termdash color modes, so this also returns
+// the color mode the cell colors must be converted to before they are passed
+// to termbox.
+func colorMode(cm terminalapi.ColorMode) (tbx.OutputMode, terminalapi.ColorMode, error) {
 	switch cm {
-	case terminalapi.ColorModeNormal:
-		return tbx.OutputNormal, nil
-	case terminalapi.ColorMode256:
-		return tbx.Output256, nil
+	case terminalapi.ColorModeNormal, terminalapi.ColorMode16:
+		return tbx.OutputNormal, terminalapi.ColorModeNormal, nil
+	case terminalapi.ColorMode256, terminalapi.ColorModeRGB:
+		return tbx.Output256, terminalapi.ColorMode256, nil
 	case terminalapi.ColorMode216:
-		return tbx.Output216, nil
+		return tbx.Output216, cm, nil
 	case terminalapi.ColorModeGrayscale:
-		return tbx.OutputGrayscale, nil
+		return tbx.OutputGrayscale, cm, nil
+	case terminalapi.ColorModeMonochrome:
+		return tbx.OutputNormal, cm, nil
 	default:
-		return -1, fmt.Errorf("don't know how to convert color mode %v to the termbox format", cm)
+		return -1, cm, fmt.Errorf("don't know how to convert color mode %v to the termbox format", cm)
 	}
 }
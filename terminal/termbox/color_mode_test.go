@@ -0,0 +1,55 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package termbox
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/terminalapi"
+	tbx "github.com/nsf/termbox-go"
+)
+
+func TestColorMode(t *testing.T) {
+	tests := []struct {
+		cm       terminalapi.ColorMode
+		wantOM   tbx.OutputMode
+		wantCell terminalapi.ColorMode
+		wantErr  bool
+	}{
+		{terminalapi.ColorModeNormal, tbx.OutputNormal, terminalapi.ColorModeNormal, false},
+		{terminalapi.ColorMode256, tbx.Output256, terminalapi.ColorMode256, false},
+		{terminalapi.ColorMode216, tbx.Output216, terminalapi.ColorMode216, false},
+		{terminalapi.ColorModeGrayscale, tbx.OutputGrayscale, terminalapi.ColorModeGrayscale, false},
+		{terminalapi.ColorModeRGB, tbx.Output256, terminalapi.ColorMode256, false},
+		{terminalapi.ColorMode16, tbx.OutputNormal, terminalapi.ColorModeNormal, false},
+		{terminalapi.ColorModeMonochrome, tbx.OutputNormal, terminalapi.ColorModeMonochrome, false},
+		{terminalapi.ColorMode(-1), -1, terminalapi.ColorMode(-1), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cm.String(), func(t *testing.T) {
+			om, cm, err := colorMode(tc.cm)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("colorMode => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if om != tc.wantOM || cm != tc.wantCell {
+				t.Errorf("colorMode => (%v, %v), want (%v, %v)", om, cm, tc.wantOM, tc.wantCell)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/eventqueue"
+	"github.com/mum4k/termdash/terminal/colormode"
 	"github.com/mum4k/termdash/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
@@ -40,6 +41,7 @@ func (o option) set(t *Terminal) {
 }
 
 // ColorMode sets the terminal color mode.
+// Defaults to the color mode detected by colormode.Detect.
 func ColorMode(cm terminalapi.ColorMode) Option {
 	return option(func(t *Terminal) {
 		t.colorMode = cm
@@ -58,6 +60,9 @@ type Terminal struct {
 
 	// Options.
 	colorMode terminalapi.ColorMode
+
+	// cellColorMode is the color mode the cell colors are converted to.
+	cellColorMode terminalapi.ColorMode
 }
 
 // New returns a new termbox based Terminal.
@@ -69,18 +74,20 @@ func New(opts ...Option) (*Terminal, error) {
 	tbx.SetInputMode(tbx.InputEsc | tbx.InputMouse)
 
 	t := &Terminal{
-		events: eventqueue.New(),
-		done:   make(chan struct{}),
+		events:    eventqueue.New(),
+		done:      make(chan struct{}),
+		colorMode: colormode.Detect(),
 	}
 	for _, opt := range opts {
 		opt.set(t)
 	}
 
-	om, err := colorMode(t.colorMode)
+	om, cm, err := colorMode(t.colorMode)
 	if err != nil {
 		return nil, err
 	}
 	tbx.SetOutputMode(om)
+	t.cellColorMode = cm
 
 	go t.pollEvents() // Stops when Close() is called.
 	return t, nil
@@ -95,7 +102,7 @@ func (t *Terminal) Size() image.Point {
 // Clear implements terminalapi.Terminal.Clear.
 func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	return tbx.Clear(cellOptsToFg(o), cellOptsToBg(o))
+	return tbx.Clear(cellOptsToFg(o, t.cellColorMode), cellOptsToBg(o, t.cellColorMode))
 }
 
 // Flush implements terminalapi.Terminal.Flush.
@@ -116,7 +123,7 @@ func (t *Terminal) HideCursor() {
 // SetCell implements terminalapi.Terminal.SetCell.
 func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o), cellOptsToBg(o))
+	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o, t.cellColorMode), cellOptsToBg(o, t.cellColorMode))
 	return nil
 }
 
@@ -0,0 +1,78 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestCSSColor(t *testing.T) {
+	tests := []struct {
+		desc  string
+		color cell.Color
+		want  string
+	}{
+		{
+			desc:  "default color",
+			color: cell.ColorDefault,
+			want:  "",
+		},
+		{
+			desc:  "system color",
+			color: cell.ColorRed,
+			want:  "#cd0000",
+		},
+		{
+			desc:  "bright system color",
+			color: cell.ColorNumber(15),
+			want:  "#ffffff",
+		},
+		{
+			desc:  "color cube",
+			color: cell.ColorRGB6(5, 2, 0),
+			want:  "#ff8700",
+		},
+		{
+			desc:  "shade of grey",
+			color: cell.ColorNumber(232),
+			want:  "#080808",
+		},
+		{
+			desc:  "last shade of grey",
+			color: cell.ColorNumber(255),
+			want:  "#eeeeee",
+		},
+		{
+			desc:  "24 bit color",
+			color: cell.ColorRGB24(18, 52, 86),
+			want:  "#123456",
+		},
+		{
+			desc:  "out of range color",
+			color: cell.Color(300),
+			want:  "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := cssColor(tc.color); got != tc.want {
+				t.Errorf("cssColor(%v) => %q, want %q", tc.color, got, tc.want)
+			}
+		})
+	}
+}
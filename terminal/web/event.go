@@ -0,0 +1,141 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+// event.go converts messages received from the browser to the termdash format.
+
+import (
+	"image"
+	"unicode/utf8"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// namedKeys maps the values of the KeyboardEvent.key property of
+// non-printable keys to the termdash format.
+var namedKeys = map[string]keyboard.Key{
+	"F1":         keyboard.KeyF1,
+	"F2":         keyboard.KeyF2,
+	"F3":         keyboard.KeyF3,
+	"F4":         keyboard.KeyF4,
+	"F5":         keyboard.KeyF5,
+	"F6":         keyboard.KeyF6,
+	"F7":         keyboard.KeyF7,
+	"F8":         keyboard.KeyF8,
+	"F9":         keyboard.KeyF9,
+	"F10":        keyboard.KeyF10,
+	"F11":        keyboard.KeyF11,
+	"F12":        keyboard.KeyF12,
+	"Insert":     keyboard.KeyInsert,
+	"Delete":     keyboard.KeyDelete,
+	"Home":       keyboard.KeyHome,
+	"End":        keyboard.KeyEnd,
+	"PageUp":     keyboard.KeyPgUp,
+	"PageDown":   keyboard.KeyPgDn,
+	"ArrowUp":    keyboard.KeyArrowUp,
+	"ArrowDown":  keyboard.KeyArrowDown,
+	"ArrowLeft":  keyboard.KeyArrowLeft,
+	"ArrowRight": keyboard.KeyArrowRight,
+	"Backspace":  keyboard.KeyBackspace,
+	"Tab":        keyboard.KeyTab,
+	"Enter":      keyboard.KeyEnter,
+	"Escape":     keyboard.KeyEsc,
+}
+
+// maxSize is the largest size of the terminal accepted from the browser. It
+// bounds the memory the server allocates for the buffers of the terminal.
+var maxSize = image.Point{1000, 500}
+
+// newKeyboard creates a new termdash keyboard events with the provided keys.
+func newKeyboard(keys ...keyboard.Key) []terminalapi.Event {
+	var evs []terminalapi.Event
+	for _, k := range keys {
+		evs = append(evs, &terminalapi.Keyboard{Key: k})
+	}
+	return evs
+}
+
+// convKey converts a browser keyboard message to the termdash format.
+func convKey(m *clientMessage) []terminalapi.Event {
+	if k, ok := namedKeys[m.Key]; ok {
+		return newKeyboard(k)
+	}
+
+	if utf8.RuneCountInString(m.Key) != 1 {
+		return []terminalapi.Event{
+			terminalapi.NewErrorf("unknown keyboard key %q in a keyboard event", m.Key),
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(m.Key)
+	if m.Ctrl {
+		return newKeyboard(keyboard.KeyCtrl, keyboard.Key(r))
+	}
+	return newKeyboard(keyboard.Key(r))
+}
+
+// buttons maps the mouse buttons reported by the browser to the termdash
+// format.
+var buttons = map[string]mouse.Button{
+	"left":      mouse.ButtonLeft,
+	"middle":    mouse.ButtonMiddle,
+	"right":     mouse.ButtonRight,
+	"release":   mouse.ButtonRelease,
+	"wheelUp":   mouse.ButtonWheelUp,
+	"wheelDown": mouse.ButtonWheelDown,
+}
+
+// convMouse converts a browser mouse message to the termdash format.
+func convMouse(m *clientMessage) terminalapi.Event {
+	button, ok := buttons[m.Button]
+	if !ok {
+		return terminalapi.NewErrorf("unknown mouse button %q in a mouse event", m.Button)
+	}
+	return &terminalapi.Mouse{
+		Position: image.Point{m.X, m.Y},
+		Button:   button,
+	}
+}
+
+// convResize converts a browser resize message to the termdash format.
+func convResize(m *clientMessage) terminalapi.Event {
+	size := image.Point{m.Width, m.Height}
+	if size.X <= 0 || size.Y <= 0 {
+		return terminalapi.NewErrorf("terminal resized to a non-positive size: %v", size)
+	}
+	if size.X > maxSize.X || size.Y > maxSize.Y {
+		return terminalapi.NewErrorf("terminal resized to %v, which is larger than the maximum size %v", size, maxSize)
+	}
+	return &terminalapi.Resize{
+		Size: size,
+	}
+}
+
+// toTermdashEvents converts a browser message to the termdash event format.
+func toTermdashEvents(m *clientMessage) []terminalapi.Event {
+	switch t := m.Type; t {
+	case msgTypeResize:
+		return []terminalapi.Event{convResize(m)}
+	case msgTypeMouse:
+		return []terminalapi.Event{convMouse(m)}
+	case msgTypeKey:
+		return convKey(m)
+	default:
+		return []terminalapi.Event{
+			terminalapi.NewErrorf("unknown browser message type: %q", t),
+		}
+	}
+}
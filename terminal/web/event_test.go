@@ -0,0 +1,141 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+func TestToTermdashEvents(t *testing.T) {
+	tests := []struct {
+		desc string
+		msg  *clientMessage
+		want []terminalapi.Event
+	}{
+		{
+			desc: "unknown message type",
+			msg:  &clientMessage{Type: "unknown"},
+			want: []terminalapi.Event{
+				terminalapi.NewError(`unknown browser message type: "unknown"`),
+			},
+		},
+		{
+			desc: "resize event",
+			msg:  &clientMessage{Type: "resize", Width: 80, Height: 24},
+			want: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{80, 24}},
+			},
+		},
+		{
+			desc: "resize event to a zero size",
+			msg:  &clientMessage{Type: "resize", Width: 0, Height: 24},
+			want: []terminalapi.Event{
+				terminalapi.NewError("terminal resized to a non-positive size: (0,24)"),
+			},
+		},
+		{
+			desc: "resize event larger than the maximum size",
+			msg:  &clientMessage{Type: "resize", Width: 1000000, Height: 1000000},
+			want: []terminalapi.Event{
+				terminalapi.NewError("terminal resized to (1000000,1000000), which is larger than the maximum size (1000,500)"),
+			},
+		},
+		{
+			desc: "mouse event",
+			msg:  &clientMessage{Type: "mouse", X: 10, Y: 20, Button: "left"},
+			want: []terminalapi.Event{
+				&terminalapi.Mouse{
+					Position: image.Point{10, 20},
+					Button:   mouse.ButtonLeft,
+				},
+			},
+		},
+		{
+			desc: "mouse wheel event",
+			msg:  &clientMessage{Type: "mouse", X: 1, Y: 2, Button: "wheelDown"},
+			want: []terminalapi.Event{
+				&terminalapi.Mouse{
+					Position: image.Point{1, 2},
+					Button:   mouse.ButtonWheelDown,
+				},
+			},
+		},
+		{
+			desc: "unknown mouse button",
+			msg:  &clientMessage{Type: "mouse", Button: "back"},
+			want: []terminalapi.Event{
+				terminalapi.NewError(`unknown mouse button "back" in a mouse event`),
+			},
+		},
+		{
+			desc: "printable character",
+			msg:  &clientMessage{Type: "key", Key: "a"},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+		},
+		{
+			desc: "unicode character",
+			msg:  &clientMessage{Type: "key", Key: "ž"},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'ž'},
+			},
+		},
+		{
+			desc: "space",
+			msg:  &clientMessage{Type: "key", Key: " "},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeySpace},
+			},
+		},
+		{
+			desc: "named key",
+			msg:  &clientMessage{Type: "key", Key: "ArrowUp"},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+		},
+		{
+			desc: "control with a character",
+			msg:  &clientMessage{Type: "key", Key: "c", Ctrl: true},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrl},
+				&terminalapi.Keyboard{Key: 'c'},
+			},
+		},
+		{
+			desc: "unknown key",
+			msg:  &clientMessage{Type: "key", Key: "CapsLock"},
+			want: []terminalapi.Event{
+				terminalapi.NewError(`unknown keyboard key "CapsLock" in a keyboard event`),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := toTermdashEvents(tc.msg)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("toTermdashEvents => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+// handler.go serves the HTML page and the WebSocket connections.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"image"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/websocket"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// ServeFunc is called once for each browser that connects to the Handler.
+// It receives a terminal dedicated to the connection, typically creates a
+// container on it and runs termdash until the context expires. The context
+// expires when the browser disconnects.
+type ServeFunc func(ctx context.Context, t terminalapi.Terminal) error
+
+// Handler serves a page that displays a terminal in the web browser.
+// Requests for the path "ws" relative to the page are upgraded to a WebSocket
+// connection, all other requests receive the HTML page.
+// Implements http.Handler.
+type Handler struct {
+	// serve is called for each connection.
+	serve ServeFunc
+
+	// upgrader upgrades HTTP connections to the WebSocket protocol.
+	upgrader websocket.Upgrader
+
+	// opts are the provided options.
+	opts *options
+}
+
+// NewHandler returns a new Handler that calls the provided function for each
+// browser that connects.
+func NewHandler(serve ServeFunc, opts ...Option) *Handler {
+	return &Handler{
+		serve: serve,
+		opts:  newOptions(opts...),
+	}
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path.Base(r.URL.Path) == "ws" {
+		h.serveWS(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct{ Title string }{h.opts.title}
+	if err := pageTmpl.Execute(w, data); err != nil {
+		h.handleError(fmt.Errorf("unable to render the page: %v", err))
+	}
+}
+
+// handleError forwards the error to the error handler if one was provided.
+func (h *Handler) handleError(err error) {
+	if h.opts.errorHandler != nil {
+		h.opts.errorHandler(err)
+	}
+}
+
+// serveWS serves a single WebSocket connection.
+func (h *Handler) serveWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied to the client.
+		h.handleError(fmt.Errorf("unable to upgrade the connection: %v", err))
+		return
+	}
+	defer conn.Close()
+
+	if err := h.serveConn(conn); err != nil {
+		h.handleError(err)
+		msg := websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error())
+		conn.WriteMessage(websocket.CloseMessage, msg)
+	}
+}
+
+// maxMessageSize is the largest message in bytes accepted from the browser.
+// The connection is closed when a larger message arrives.
+const maxMessageSize = 4096
+
+// serveConn creates the terminal for the connection and runs the ServeFunc.
+func (h *Handler) serveConn(conn *websocket.Conn) error {
+	conn.SetReadLimit(maxMessageSize)
+	size, err := initialSize(conn)
+	if err != nil {
+		return err
+	}
+
+	t, err := newTerminal(conn, size, h.opts)
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go readMessages(conn, t, cancel) // Stops when the connection is closed.
+	return h.serve(ctx, t)
+}
+
+// initialSize waits for the first message from the browser which must report
+// the size of the terminal.
+func initialSize(conn *websocket.Conn) (image.Point, error) {
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return image.ZP, fmt.Errorf("unable to read the initial size: %v", err)
+	}
+
+	var m clientMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return image.ZP, fmt.Errorf("unable to decode the initial size %q: %v", data, err)
+	}
+	if m.Type != msgTypeResize {
+		return image.ZP, fmt.Errorf("the first message must be of type %q, got %q", msgTypeResize, m.Type)
+	}
+
+	switch ev := convResize(&m).(type) {
+	case *terminalapi.Resize:
+		return ev.Size, nil
+	case *terminalapi.Error:
+		return image.ZP, ev.Error()
+	default:
+		return image.ZP, errors.New("unexpected event when reading the initial size")
+	}
+}
+
+// readMessages forwards messages received from the browser to the terminal.
+// Calls cancel when the connection is closed.
+func readMessages(conn *websocket.Conn, t *Terminal, cancel context.CancelFunc) {
+	defer cancel()
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		t.handleMessage(data)
+	}
+}
+
+// pageTmpl is the template of the HTML page.
+var pageTmpl = template.Must(template.New("page").Parse(page))
@@ -0,0 +1,352 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// dial connects to the WebSocket endpoint of the test server.
+func dial(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial => unexpected error: %v", err)
+	}
+	return conn
+}
+
+// readFrame reads the next frame sent by the server.
+func readFrame(t *testing.T, conn *websocket.Conn) *frame {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var f frame
+	if err := conn.ReadJSON(&f); err != nil {
+		t.Fatalf("ReadJSON => unexpected error: %v", err)
+	}
+	return &f
+}
+
+// nextEvent returns the next event that isn't a resize.
+func nextEvent(ctx context.Context, t terminalapi.Terminal) terminalapi.Event {
+	for {
+		ev := t.Event(ctx)
+		if _, ok := ev.(*terminalapi.Resize); !ok {
+			return ev
+		}
+	}
+}
+
+func TestHandlerServesPage(t *testing.T) {
+	h := NewHandler(func(context.Context, terminalapi.Terminal) error {
+		return nil
+	}, Title("wallboard"))
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("Get => unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got, want := resp.StatusCode, http.StatusOK; got != want {
+		t.Errorf("StatusCode => %d, want %d", got, want)
+	}
+	if got, want := resp.Header.Get("Content-Type"), "text/html; charset=utf-8"; got != want {
+		t.Errorf("Content-Type => %q, want %q", got, want)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll => unexpected error: %v", err)
+	}
+	if want := "<title>wallboard</title>"; !strings.Contains(string(body), want) {
+		t.Errorf("page doesn't contain %q, got:\n%s", want, body)
+	}
+}
+
+func TestHandlerStreamsFrames(t *testing.T) {
+	draw := make(chan image.Point)
+	h := NewHandler(func(ctx context.Context, t terminalapi.Terminal) error {
+		for {
+			select {
+			case p := <-draw:
+				if err := t.SetCell(p, 'x', cell.FgColor(cell.ColorRed)); err != nil {
+					return err
+				}
+				if err := t.Flush(); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	conn := dial(t, srv)
+	defer conn.Close()
+	if err := conn.WriteJSON(&clientMessage{Type: "resize", Width: 3, Height: 2}); err != nil {
+		t.Fatalf("WriteJSON => unexpected error: %v", err)
+	}
+
+	draw <- image.Point{1, 0}
+	got := readFrame(t, conn)
+	want := &frame{
+		Width:  3,
+		Height: 2,
+		Full:   true,
+		Cells: []*frameCell{
+			{X: 0, Y: 0, Rune: " "},
+			{X: 1, Y: 0, Rune: "x", Fg: "#cd0000"},
+			{X: 2, Y: 0, Rune: " "},
+			{X: 0, Y: 1, Rune: " "},
+			{X: 1, Y: 1, Rune: " "},
+			{X: 2, Y: 1, Rune: " "},
+		},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("first frame => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	draw <- image.Point{2, 1}
+	got = readFrame(t, conn)
+	want = &frame{
+		Width:  3,
+		Height: 2,
+		Cells: []*frameCell{
+			{X: 2, Y: 1, Rune: "x", Fg: "#cd0000"},
+		},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("second frame => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	if err := conn.WriteJSON(&clientMessage{Type: "resize", Width: 2, Height: 1}); err != nil {
+		t.Fatalf("WriteJSON => unexpected error: %v", err)
+	}
+	// The resize is processed asynchronously, keep drawing until it arrives.
+	for i := 0; ; i++ {
+		draw <- image.Point{0, 0}
+		got = readFrame(t, conn)
+		if got.Width == 2 {
+			break
+		}
+		if i > 100 {
+			t.Fatalf("the terminal wasn't resized, last frame: %+v", got)
+		}
+	}
+	want = &frame{
+		Width:  2,
+		Height: 1,
+		Full:   true,
+		Cells: []*frameCell{
+			{X: 0, Y: 0, Rune: "x", Fg: "#cd0000"},
+			{X: 1, Y: 0, Rune: " "},
+		},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("frame after resize => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestHandlerForwardsEvents(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		msgs []*clientMessage
+		want []terminalapi.Event
+	}{
+		{
+			desc: "forwards keyboard and mouse events",
+			msgs: []*clientMessage{
+				{Type: "key", Key: "Enter"},
+				{Type: "mouse", X: 1, Y: 2, Button: "right"},
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{1, 2}, Button: mouse.ButtonRight},
+			},
+		},
+		{
+			desc: "forwards errors for invalid messages",
+			msgs: []*clientMessage{
+				{Type: "unknown"},
+			},
+			want: []terminalapi.Event{
+				terminalapi.NewError(`unknown browser message type: "unknown"`),
+			},
+		},
+		{
+			desc: "read only terminal ignores keyboard and mouse events",
+			opts: []Option{ReadOnly()},
+			msgs: []*clientMessage{
+				{Type: "key", Key: "Enter"},
+				{Type: "mouse", X: 1, Y: 2, Button: "right"},
+				{Type: "unknown"},
+			},
+			want: []terminalapi.Event{
+				terminalapi.NewError(`unknown browser message type: "unknown"`),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotCh := make(chan []terminalapi.Event, 1)
+			h := NewHandler(func(ctx context.Context, t terminalapi.Terminal) error {
+				var got []terminalapi.Event
+				for range tc.want {
+					got = append(got, nextEvent(ctx, t))
+				}
+				gotCh <- got
+				<-ctx.Done()
+				return nil
+			}, tc.opts...)
+			srv := httptest.NewServer(h)
+			defer srv.Close()
+
+			conn := dial(t, srv)
+			defer conn.Close()
+			msgs := append([]*clientMessage{{Type: "resize", Width: 3, Height: 2}}, tc.msgs...)
+			for _, m := range msgs {
+				if err := conn.WriteJSON(m); err != nil {
+					t.Fatalf("WriteJSON => unexpected error: %v", err)
+				}
+			}
+
+			select {
+			case got := <-gotCh:
+				if diff := pretty.Compare(tc.want, got); diff != "" {
+					t.Errorf("Event => unexpected diff (-want, +got):\n%s", diff)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the events")
+			}
+		})
+	}
+}
+
+func TestHandlerReportsErrors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		first *clientMessage
+		serve ServeFunc
+	}{
+		{
+			desc:  "fails when the first message isn't a resize",
+			first: &clientMessage{Type: "key", Key: "a"},
+			serve: func(context.Context, terminalapi.Terminal) error {
+				return nil
+			},
+		},
+		{
+			desc:  "fails when the initial size is invalid",
+			first: &clientMessage{Type: "resize", Width: 0, Height: 0},
+			serve: func(context.Context, terminalapi.Terminal) error {
+				return nil
+			},
+		},
+		{
+			desc:  "fails when the initial size is too large",
+			first: &clientMessage{Type: "resize", Width: 1000000, Height: 1000000},
+			serve: func(context.Context, terminalapi.Terminal) error {
+				return nil
+			},
+		},
+		{
+			desc:  "reports errors returned by the ServeFunc",
+			first: &clientMessage{Type: "resize", Width: 1, Height: 1},
+			serve: func(context.Context, terminalapi.Terminal) error {
+				return errors.New("fake error")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			errCh := make(chan error, 1)
+			h := NewHandler(tc.serve, ErrorHandler(func(err error) {
+				errCh <- err
+			}))
+			srv := httptest.NewServer(h)
+			defer srv.Close()
+
+			conn := dial(t, srv)
+			defer conn.Close()
+			if err := conn.WriteJSON(tc.first); err != nil {
+				t.Fatalf("WriteJSON => unexpected error: %v", err)
+			}
+
+			select {
+			case <-errCh:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the error handler")
+			}
+
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			_, _, err := conn.ReadMessage()
+			if !websocket.IsCloseError(err, websocket.CloseInternalServerErr) {
+				t.Errorf("ReadMessage => %v, want a close error with code %d", err, websocket.CloseInternalServerErr)
+			}
+		})
+	}
+}
+
+func TestHandlerLimitsMessageSize(t *testing.T) {
+	errCh := make(chan error, 1)
+	h := NewHandler(func(context.Context, terminalapi.Terminal) error {
+		return nil
+	}, ErrorHandler(func(err error) {
+		errCh <- err
+	}))
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	conn := dial(t, srv)
+	defer conn.Close()
+	if err := conn.WriteJSON(&clientMessage{Type: "key", Key: strings.Repeat("a", maxMessageSize)}); err != nil {
+		t.Fatalf("WriteJSON => unexpected error: %v", err)
+	}
+
+	select {
+	case <-errCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the error handler")
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+		t.Errorf("ReadMessage => %v, want a close error with code %d", err, websocket.CloseMessageTooBig)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+// options.go contains configurable options for the Handler and its terminals.
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	readOnly     bool
+	title        string
+	errorHandler func(error)
+}
+
+// newOptions returns a new options instance with the default values.
+func newOptions(opts ...Option) *options {
+	o := &options{
+		title: DefaultTitle,
+	}
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	return o
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// ReadOnly configures the terminals to ignore keyboard and mouse events
+// coming from the browser. Resize events are still processed so that the
+// dashboard fills the browser window. Useful for wallboards.
+func ReadOnly() Option {
+	return option(func(opts *options) {
+		opts.readOnly = true
+	})
+}
+
+// DefaultTitle is the default value for the Title option.
+const DefaultTitle = "termdash"
+
+// Title sets the title of the served HTML page.
+func Title(title string) Option {
+	return option(func(opts *options) {
+		opts.title = title
+	})
+}
+
+// ErrorHandler is used to provide a function that will be called with errors
+// that occur while serving a connection, including errors returned by the
+// ServeFunc. If not provided, these errors are only reported to the browser
+// when the connection is closed.
+func ErrorHandler(f func(error)) Option {
+	return option(func(opts *options) {
+		opts.errorHandler = f
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+// page.go contains the HTML page that displays the terminal in the browser.
+
+// page is the source of the HTML page template.
+//
+// The page measures the size of a single character cell, reports the number
+// of cells that fit into the browser window and applies the frames received
+// from the server. Keyboard and mouse events are reported back to the server
+// with coordinates of the cell under the mouse pointer.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+html, body {
+  margin: 0;
+  height: 100%;
+  overflow: hidden;
+  background: #000000;
+  color: #e5e5e5;
+}
+#term, #measure {
+  font-family: monospace;
+  font-size: 14px;
+  line-height: 1.2;
+  white-space: pre;
+}
+#term {
+  margin: 0;
+  cursor: default;
+  user-select: none;
+}
+#term div {
+  height: 1.2em;
+}
+#term .cursor {
+  outline: 1px solid #e5e5e5;
+}
+#measure {
+  position: absolute;
+  visibility: hidden;
+}
+</style>
+</head>
+<body>
+<pre id="term"></pre>
+<span id="measure">W</span>
+<script>
+(function() {
+  "use strict";
+
+  var term = document.getElementById("term");
+  var measure = document.getElementById("measure");
+
+  // rows[y][x] is the span displaying the cell at (x, y).
+  var rows = [];
+  var cursor = null;
+
+  var url = new URL("ws", window.location.href);
+  url.protocol = url.protocol === "https:" ? "wss:" : "ws:";
+  var ws = new WebSocket(url.href);
+
+  function send(msg) {
+    if (ws.readyState === WebSocket.OPEN) {
+      ws.send(JSON.stringify(msg));
+    }
+  }
+
+  function cellSize() {
+    var r = measure.getBoundingClientRect();
+    return {width: r.width, height: r.height};
+  }
+
+  function sendSize() {
+    var cs = cellSize();
+    send({
+      type: "resize",
+      width: Math.max(1, Math.floor(window.innerWidth / cs.width)),
+      height: Math.max(1, Math.floor(window.innerHeight / cs.height))
+    });
+  }
+
+  function layout(width, height) {
+    term.textContent = "";
+    rows = [];
+    cursor = null;
+    for (var y = 0; y < height; y++) {
+      var div = document.createElement("div");
+      var row = [];
+      for (var x = 0; x < width; x++) {
+        var span = document.createElement("span");
+        span.textContent = " ";
+        div.appendChild(span);
+        row.push(span);
+      }
+      term.appendChild(div);
+      rows.push(row);
+    }
+  }
+
+  function apply(f) {
+    if (f.full || rows.length !== f.height || rows[0].length !== f.width) {
+      layout(f.width, f.height);
+    }
+    f.cells.forEach(function(c) {
+      var span = rows[c.y][c.x];
+      span.textContent = c.rune;
+      span.style.color = c.fg || "";
+      span.style.backgroundColor = c.bg || "";
+    });
+
+    if (cursor !== null) {
+      cursor.classList.remove("cursor");
+      cursor = null;
+    }
+    if (f.cursor && rows[f.cursor.Y] && rows[f.cursor.Y][f.cursor.X]) {
+      cursor = rows[f.cursor.Y][f.cursor.X];
+      cursor.classList.add("cursor");
+    }
+  }
+
+  function cellAt(ev) {
+    var r = term.getBoundingClientRect();
+    var cs = cellSize();
+    return {
+      x: Math.floor((ev.clientX - r.left) / cs.width),
+      y: Math.floor((ev.clientY - r.top) / cs.height)
+    };
+  }
+
+  function sendMouse(ev, button) {
+    var p = cellAt(ev);
+    send({type: "mouse", x: p.x, y: p.y, button: button});
+  }
+
+  var mouseButtons = ["left", "middle", "right"];
+
+  ws.onopen = sendSize;
+  ws.onmessage = function(ev) {
+    apply(JSON.parse(ev.data));
+  };
+  ws.onclose = function(ev) {
+    document.title = "disconnected" + (ev.reason ? ": " + ev.reason : "");
+  };
+
+  window.addEventListener("resize", sendSize);
+  window.addEventListener("keydown", function(ev) {
+    if (ev.key === "Control" || ev.key === "Shift" || ev.key === "Alt" || ev.key === "Meta") {
+      return;
+    }
+    ev.preventDefault();
+    send({type: "key", key: ev.key, ctrl: ev.ctrlKey});
+  });
+  term.addEventListener("mousedown", function(ev) {
+    if (ev.button < mouseButtons.length) {
+      sendMouse(ev, mouseButtons[ev.button]);
+    }
+  });
+  term.addEventListener("mouseup", function(ev) {
+    sendMouse(ev, "release");
+  });
+  term.addEventListener("wheel", function(ev) {
+    ev.preventDefault();
+    sendMouse(ev, ev.deltaY < 0 ? "wheelUp" : "wheelDown");
+  });
+  term.addEventListener("contextmenu", function(ev) {
+    ev.preventDefault();
+  });
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,152 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+// protocol.go defines the messages exchanged with the browser.
+
+import (
+	"fmt"
+	"image"
+	"reflect"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Types of messages sent by the browser.
+const (
+	msgTypeResize = "resize"
+	msgTypeKey    = "key"
+	msgTypeMouse  = "mouse"
+)
+
+// clientMessage is a message received from the browser.
+type clientMessage struct {
+	// Type is one of the msgType constants.
+	Type string `json:"type"`
+
+	// Width and Height are the new size of the terminal in cells.
+	// Set for msgTypeResize.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// Key is the value of the KeyboardEvent.key property.
+	// Set for msgTypeKey.
+	Key string `json:"key"`
+	// Ctrl indicates if the control key was held down.
+	// Set for msgTypeKey.
+	Ctrl bool `json:"ctrl"`
+
+	// X and Y are the coordinates of the cell under the mouse pointer.
+	// Set for msgTypeMouse.
+	X int `json:"x"`
+	Y int `json:"y"`
+	// Button identifies the mouse button, one of the button constants.
+	// Set for msgTypeMouse.
+	Button string `json:"button"`
+}
+
+// frameCell is a single changed cell within a frame.
+type frameCell struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	// Rune is the content of the cell.
+	// Empty for cells occupied by the second half of a full-width rune.
+	Rune string `json:"rune"`
+	// Fg and Bg are CSS colors, empty for the default color.
+	Fg string `json:"fg,omitempty"`
+	Bg string `json:"bg,omitempty"`
+}
+
+// frame is a message sent to the browser on each flush.
+type frame struct {
+	// Width and Height are the size of the terminal in cells.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// Full indicates that the frame contains all the cells and the browser
+	// should discard its current content.
+	Full bool `json:"full"`
+
+	// Cells are the cells that changed since the previous frame.
+	Cells []*frameCell `json:"cells"`
+
+	// Cursor is the position of the cursor, nil if the cursor is hidden.
+	Cursor *image.Point `json:"cursor"`
+}
+
+// newFrame returns a frame that transforms the front buffer into the back
+// buffer. If the front buffer is nil or of a different size, the frame
+// contains all the cells of the back buffer.
+func newFrame(front, back cell.Buffer, cursor *image.Point) (*frame, error) {
+	size := back.Size()
+	full := front == nil || front.Size() != size
+	f := &frame{
+		Width:  size.X,
+		Height: size.Y,
+		Full:   full,
+		Cells:  []*frameCell{},
+		Cursor: cursor,
+	}
+
+	for row := 0; row < size.Y; row++ {
+		for col := 0; col < size.X; col++ {
+			p := image.Point{col, row}
+			bc := back[col][row]
+			text, err := cellText(back, p)
+			if err != nil {
+				return nil, err
+			}
+			if !full {
+				fc := front[col][row]
+				// Compare the text instead of the runes, the cell might
+				// become partial if a full-width rune was placed into the
+				// previous cell.
+				ft, err := cellText(front, p)
+				if err != nil {
+					return nil, err
+				}
+				if ft == text && reflect.DeepEqual(fc.Opts, bc.Opts) {
+					continue
+				}
+			}
+
+			f.Cells = append(f.Cells, &frameCell{
+				X:    col,
+				Y:    row,
+				Rune: text,
+				Fg:   cssColor(bc.Opts.FgColor),
+				Bg:   cssColor(bc.Opts.BgColor),
+			})
+		}
+	}
+	return f, nil
+}
+
+// cellText returns the text displayed in the cell at the specified point.
+func cellText(b cell.Buffer, p image.Point) (string, error) {
+	partial, err := b.IsPartial(p)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine if point %v is a partial rune: %v", p, err)
+	}
+
+	switch r := b[p.X][p.Y].Rune; {
+	case partial:
+		return "", nil
+	case r == 0:
+		return " ", nil
+	default:
+		return string(r), nil
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+)
+
+// mustBuffer returns a new buffer of the provided size with the runes set
+// at the specified points.
+func mustBuffer(size image.Point, runes map[image.Point]rune, opts ...cell.Option) cell.Buffer {
+	b, err := cell.NewBuffer(size)
+	if err != nil {
+		panic(err)
+	}
+	for p, r := range runes {
+		if _, err := b.SetCell(p, r, opts...); err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+func TestNewFrame(t *testing.T) {
+	tests := []struct {
+		desc   string
+		front  cell.Buffer
+		back   cell.Buffer
+		cursor *image.Point
+		want   *frame
+	}{
+		{
+			desc: "full frame without front buffer",
+			back: mustBuffer(image.Point{2, 1}, map[image.Point]rune{{0, 0}: 'a'}),
+			want: &frame{
+				Width:  2,
+				Height: 1,
+				Full:   true,
+				Cells: []*frameCell{
+					{X: 0, Y: 0, Rune: "a"},
+					{X: 1, Y: 0, Rune: " "},
+				},
+			},
+		},
+		{
+			desc:  "full frame when the size changes",
+			front: mustBuffer(image.Point{1, 1}, nil),
+			back:  mustBuffer(image.Point{1, 2}, nil),
+			want: &frame{
+				Width:  1,
+				Height: 2,
+				Full:   true,
+				Cells: []*frameCell{
+					{X: 0, Y: 0, Rune: " "},
+					{X: 0, Y: 1, Rune: " "},
+				},
+			},
+		},
+		{
+			desc:  "no cells when nothing changed",
+			front: mustBuffer(image.Point{2, 1}, map[image.Point]rune{{0, 0}: 'a'}),
+			back:  mustBuffer(image.Point{2, 1}, map[image.Point]rune{{0, 0}: 'a'}),
+			want: &frame{
+				Width:  2,
+				Height: 1,
+				Cells:  []*frameCell{},
+			},
+		},
+		{
+			desc:  "includes cells with changed options",
+			front: mustBuffer(image.Point{2, 1}, map[image.Point]rune{{0, 0}: 'a'}),
+			back: mustBuffer(image.Point{2, 1}, map[image.Point]rune{{0, 0}: 'a'},
+				cell.FgColor(cell.ColorBlue),
+				cell.BgColor(cell.ColorNumber(255)),
+			),
+			want: &frame{
+				Width:  2,
+				Height: 1,
+				Cells: []*frameCell{
+					{X: 0, Y: 0, Rune: "a", Fg: "#0000ee", Bg: "#eeeeee"},
+				},
+			},
+		},
+		{
+			desc:  "includes the partial cell after a full-width rune",
+			front: mustBuffer(image.Point{2, 1}, nil),
+			back:  mustBuffer(image.Point{2, 1}, map[image.Point]rune{{0, 0}: '世'}),
+			want: &frame{
+				Width:  2,
+				Height: 1,
+				Cells: []*frameCell{
+					{X: 0, Y: 0, Rune: "世"},
+					{X: 1, Y: 0, Rune: ""},
+				},
+			},
+		},
+		{
+			desc:   "includes the cursor",
+			front:  mustBuffer(image.Point{1, 1}, nil),
+			back:   mustBuffer(image.Point{1, 1}, nil),
+			cursor: &image.Point{0, 0},
+			want: &frame{
+				Width:  1,
+				Height: 1,
+				Cells:  []*frameCell{},
+				Cursor: &image.Point{0, 0},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := newFrame(tc.front, tc.back, tc.cursor)
+			if err != nil {
+				t.Fatalf("newFrame => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("newFrame => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
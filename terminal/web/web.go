@@ -0,0 +1,216 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package web implements a terminal that is displayed in a web browser.
+
+The Handler serves a small HTML page which opens a WebSocket connection back
+to the server. Each connection gets its own Terminal which streams the changed
+cells to the browser on every Flush() and receives keyboard, mouse and resize
+events from it.
+*/
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/eventqueue"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// sender sends a message to the browser.
+type sender interface {
+	// WriteJSON writes the JSON encoding of v as a message.
+	WriteJSON(v interface{}) error
+}
+
+// Terminal is a terminal displayed in a web browser over a WebSocket
+// connection. Instances are created by the Handler, one per connection.
+// This object is thread-safe.
+// Implements terminalapi.Terminal.
+type Terminal struct {
+	// conn is used to send frames to the browser.
+	conn sender
+
+	// events is a queue of input events.
+	events *eventqueue.Unbound
+
+	// back is the back buffer modified by calls to SetCell and Clear.
+	back cell.Buffer
+	// front is the content currently displayed in the browser.
+	// Nil if the browser needs to receive the full content on the next flush.
+	front cell.Buffer
+
+	// cursor is the position of the cursor, nil if the cursor is hidden.
+	cursor *image.Point
+
+	// mu protects the Terminal.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// newTerminal returns a new Terminal of the specified size that sends its
+// content over the connection.
+func newTerminal(conn sender, size image.Point, opts *options) (*Terminal, error) {
+	b, err := cell.NewBuffer(size)
+	if err != nil {
+		return nil, err
+	}
+	return &Terminal{
+		conn:   conn,
+		events: eventqueue.New(),
+		back:   b,
+		opts:   opts,
+	}, nil
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.back.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, err := cell.NewBuffer(t.back.Size())
+	if err != nil {
+		return err
+	}
+	for _, col := range b {
+		for _, c := range col {
+			c.Apply(opts...)
+		}
+	}
+	t.back = b
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+// Only the cells that changed since the last flush are sent to the browser.
+func (t *Terminal) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, err := newFrame(t.front, t.back, t.cursor)
+	if err != nil {
+		return err
+	}
+	if err := t.conn.WriteJSON(f); err != nil {
+		return err
+	}
+	t.front = copyBuffer(t.back)
+	return nil
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+func (t *Terminal) SetCursor(p image.Point) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cursor = &p
+}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+func (t *Terminal) HideCursor() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cursor = nil
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.back.SetCell(p, r, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	ev, err := t.events.Pull(ctx)
+	if err != nil {
+		return terminalapi.NewErrorf("unable to pull the next event: %v", err)
+	}
+	return ev
+}
+
+// Close closes the terminal, should be called when the terminal isn't
+// required anymore. Doesn't close the underlying connection, that is owned by
+// the Handler.
+func (t *Terminal) Close() {
+	t.events.Close()
+}
+
+// resize resizes the back buffer of the terminal to the provided size.
+// The next flush sends the full content to the browser.
+func (t *Terminal) resize(size image.Point) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, err := cell.NewBuffer(size)
+	if err != nil {
+		return err
+	}
+	t.back = b
+	t.front = nil
+	return nil
+}
+
+// handleMessage processes one message received from the browser.
+func (t *Terminal) handleMessage(data []byte) {
+	var m clientMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.events.Push(terminalapi.NewErrorf("unable to decode message %q from the browser: %v", data, err))
+		return
+	}
+
+	for _, ev := range toTermdashEvents(&m) {
+		switch e := ev.(type) {
+		case *terminalapi.Resize:
+			if err := t.resize(e.Size); err != nil {
+				t.events.Push(terminalapi.NewErrorf("unable to resize the terminal: %v", err))
+				continue
+			}
+		case *terminalapi.Keyboard, *terminalapi.Mouse:
+			if t.opts.readOnly {
+				continue
+			}
+		}
+		t.events.Push(ev)
+	}
+}
+
+// copyBuffer returns a deep copy of the buffer.
+func copyBuffer(b cell.Buffer) cell.Buffer {
+	cp := make(cell.Buffer, len(b))
+	for col := range b {
+		cp[col] = make([]*cell.Cell, len(b[col]))
+		for row, c := range b[col] {
+			cp[col][row] = c.Copy()
+		}
+	}
+	return cp
+}
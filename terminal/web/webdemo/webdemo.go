@@ -0,0 +1,123 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary webdemo serves a read-only dashboard to web browsers.
+// Open http://localhost:8080 once the demo runs.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/web"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/gauge"
+	"github.com/mum4k/termdash/widgets/linechart"
+)
+
+var addr = flag.String("addr", ":8080", "the address to serve on")
+
+// playGauge continuously fills the gauge. Exits when the context expires.
+func playGauge(ctx context.Context, g *gauge.Gauge, delay time.Duration) {
+	progress := 0
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := g.Percent(progress); err != nil {
+				panic(err)
+			}
+			progress = (progress + 1) % 101
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// playLineChart continuously shifts a sine wave on the line chart. Exits when
+// the context expires.
+func playLineChart(ctx context.Context, lc *linechart.LineChart, delay time.Duration) {
+	step := 0
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			var values []float64
+			for i := 0; i < 200; i++ {
+				values = append(values, math.Sin(float64(i+step)/10))
+			}
+			if err := lc.Series("sine", values, linechart.SeriesCellOpts(cell.FgColor(cell.ColorBlue))); err != nil {
+				panic(err)
+			}
+			step++
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	// The widgets are shared by all the connected browsers.
+	ctx := context.Background()
+	g := gauge.New(gauge.Height(1))
+	go playGauge(ctx, g, 100*time.Millisecond)
+	lc := linechart.New()
+	go playLineChart(ctx, lc, 100*time.Millisecond)
+
+	h := web.NewHandler(func(ctx context.Context, t terminalapi.Terminal) error {
+		c, err := container.New(
+			t,
+			container.Border(draw.LineStyleLight),
+			container.BorderTitle("termdash in the browser"),
+			container.SplitHorizontal(
+				container.Top(
+					container.Border(draw.LineStyleLight),
+					container.BorderTitle("Gauge"),
+					container.PlaceWidget(g),
+				),
+				container.Bottom(
+					container.Border(draw.LineStyleLight),
+					container.BorderTitle("LineChart"),
+					container.PlaceWidget(lc),
+				),
+				container.SplitPercent(20),
+			),
+		)
+		if err != nil {
+			return err
+		}
+		return termdash.Run(ctx, t, c, termdash.RedrawInterval(100*time.Millisecond))
+	},
+		web.ReadOnly(),
+		web.ErrorHandler(func(err error) {
+			log.Printf("connection failed: %v", err)
+		}),
+	)
+	log.Printf("serving on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, h))
+}
@@ -29,10 +29,13 @@ func (cm ColorMode) String() string {
 
 // colorModeNames maps ColorMode values to human readable names.
 var colorModeNames = map[ColorMode]string{
-	ColorModeNormal:    "ColorModeNormal",
-	ColorMode256:       "ColorMode256",
-	ColorMode216:       "ColorMode216",
-	ColorModeGrayscale: "ColorModeGrayscale",
+	ColorModeNormal:     "ColorModeNormal",
+	ColorMode256:        "ColorMode256",
+	ColorMode216:        "ColorMode216",
+	ColorModeGrayscale:  "ColorModeGrayscale",
+	ColorModeRGB:        "ColorModeRGB",
+	ColorMode16:         "ColorMode16",
+	ColorModeMonochrome: "ColorModeMonochrome",
 }
 
 // Supported color modes.
@@ -57,4 +60,16 @@ const (
 	// i.e the 24 different shades of grey. However in this mode the colors are
 	// zero based, so the caller doesn't need to provide an offset.
 	ColorModeGrayscale
+
+	// ColorModeRGB supports the 24 bit colors created by cell.ColorRGB24 in
+	// addition to all the colors of ColorMode256.
+	ColorModeRGB
+
+	// ColorMode16 supports the 8 "system" colors and the 8 "bright system"
+	// colors, i.e. the first range of ColorMode256.
+	ColorMode16
+
+	// ColorModeMonochrome doesn't support any colors, all cells are displayed
+	// in the default color of the terminal.
+	ColorModeMonochrome
 )
@@ -0,0 +1,354 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testtermdash provides a driver for deterministic interaction tests
+// of whole dashboards.
+//
+// The driver runs termdash with a container tree on a fake terminal. Input
+// events are processed synchronously, i.e. each of the input methods returns
+// only after termdash processed the event and redrew the dashboard. The
+// periodic redraws are driven by a fake clock advanced by the test, so the
+// tests don't depend on the timing of the RedrawInterval.
+package testtermdash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/eventqueue"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	redrawInterval time.Duration
+	termdashOpts   []termdash.Option
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		redrawInterval: termdash.DefaultRedrawInterval,
+	}
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// RedrawInterval sets the interval of the fake clock in which the dashboard
+// is periodically redrawn.
+// Defaults to termdash.DefaultRedrawInterval.
+func RedrawInterval(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.redrawInterval = d
+	})
+}
+
+// TermdashOptions sets options passed to termdash, e.g. the keyboard or
+// mouse subscribers. The RedrawInterval option is ignored, use the
+// RedrawInterval option of the driver instead. The ErrorHandler option is
+// overridden by the driver, which returns the errors from its methods.
+func TermdashOptions(opts ...termdash.Option) Option {
+	return option(func(o *options) {
+		o.termdashOpts = opts
+	})
+}
+
+// NewContainerFn creates the container tree of the dashboard on the provided
+// terminal.
+type NewContainerFn func(t terminalapi.Terminal) (*container.Container, error)
+
+// Driver runs a dashboard on a fake terminal and interacts with it.
+// Call Close() when the driver isn't needed anymore.
+// This object is not thread-safe.
+type Driver struct {
+	// term is the terminal the dashboard runs on.
+	term *syncTerm
+	// events are the input events for the terminal.
+	events *eventqueue.Unbound
+	// cont is the root container of the dashboard.
+	cont *container.Container
+	// ctrl controls the termdash instance.
+	ctrl *termdash.Controller
+
+	// elapsed is the time of the fake clock since the last periodic redraw.
+	elapsed time.Duration
+	// now is the current time of the fake clock.
+	now time.Time
+
+	// errMu protects errs.
+	errMu sync.Mutex
+	// errs are the errors reported by termdash.
+	errs []error
+
+	opts *options
+}
+
+// New returns a new driver running the dashboard created by the provided
+// function on a fake terminal of the specified size.
+// The dashboard is drawn once before New returns.
+func New(size image.Point, fn NewContainerFn, opts ...Option) (*Driver, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if o.redrawInterval <= 0 {
+		return nil, fmt.Errorf("invalid redraw interval %v, must be a positive duration", o.redrawInterval)
+	}
+
+	events := eventqueue.New()
+	ft, err := faketerm.New(size, faketerm.WithEventQueue(events))
+	if err != nil {
+		events.Close()
+		return nil, err
+	}
+	term := newSyncTerm(ft)
+	cont, err := fn(term)
+	if err != nil {
+		events.Close()
+		return nil, fmt.Errorf("NewContainerFn => %v", err)
+	}
+
+	d := &Driver{
+		term:   term,
+		events: events,
+		cont:   cont,
+		now:    time.Unix(0, 0),
+		opts:   o,
+	}
+	tdOpts := append(append([]termdash.Option{}, o.termdashOpts...), termdash.ErrorHandler(d.handleError))
+	ctrl, err := termdash.NewController(term, cont, tdOpts...)
+	if err != nil {
+		events.Close()
+		return nil, err
+	}
+	d.ctrl = ctrl
+
+	// Wait until termdash starts waiting for the first event, this is the
+	// starting point for the synchronization of events.
+	term.waitEventCalls(1)
+	return d, nil
+}
+
+// handleError collects the errors reported by termdash.
+func (d *Driver) handleError(err error) {
+	d.errMu.Lock()
+	defer d.errMu.Unlock()
+	d.errs = append(d.errs, err)
+}
+
+// takeError returns and forgets the errors reported by termdash since the
+// last call.
+func (d *Driver) takeError() error {
+	d.errMu.Lock()
+	defer d.errMu.Unlock()
+	if len(d.errs) == 0 {
+		return nil
+	}
+	err := d.errs[0]
+	if len(d.errs) > 1 {
+		err = fmt.Errorf("%v (and %d more errors)", err, len(d.errs)-1)
+	}
+	d.errs = nil
+	return err
+}
+
+// Event sends the input event to the dashboard and waits until termdash
+// processes it. Returns the error termdash reported while processing the
+// event, if any.
+func (d *Driver) Event(ev terminalapi.Event) error {
+	if d.ctrl == nil {
+		return errors.New("the driver is closed")
+	}
+	// Termdash is waiting in its n-th call to Event, it calls Event again
+	// once it processed the pushed event.
+	n := d.term.eventCalls()
+	d.events.Push(ev)
+	d.term.waitEventCalls(n + 1)
+	return d.takeError()
+}
+
+// PressKey sends the key to the dashboard. Termdash forwards it to the
+// widget in the focused container and redraws the dashboard.
+func (d *Driver) PressKey(k keyboard.Key) error {
+	return d.Event(&terminalapi.Keyboard{Key: k})
+}
+
+// Type sends each rune of the text as a separate key press.
+func (d *Driver) Type(text string) error {
+	for _, r := range text {
+		if err := d.PressKey(keyboard.Key(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Click clicks the left mouse button at the point on the terminal, i.e.
+// sends the press and the release of the button. The click focuses the
+// container at the point and the dashboard is redrawn.
+func (d *Driver) Click(p image.Point) error {
+	if err := d.Event(&terminalapi.Mouse{Position: p, Button: mouse.ButtonLeft}); err != nil {
+		return err
+	}
+	return d.Event(&terminalapi.Mouse{Position: p, Button: mouse.ButtonRelease})
+}
+
+// Resize resizes the terminal. The dashboard is redrawn on the next
+// periodic redraw, see WaitForFrame.
+func (d *Driver) Resize(size image.Point) error {
+	return d.Event(&terminalapi.Resize{Size: size})
+}
+
+// Advance advances the fake clock by the duration. The dashboard is redrawn
+// once for each redraw interval that elapsed.
+func (d *Driver) Advance(dur time.Duration) error {
+	if d.ctrl == nil {
+		return errors.New("the driver is closed")
+	}
+	if dur < 0 {
+		return fmt.Errorf("invalid duration %v, the clock cannot go back", dur)
+	}
+	d.now = d.now.Add(dur)
+	d.elapsed += dur
+	for d.elapsed >= d.opts.redrawInterval {
+		d.elapsed -= d.opts.redrawInterval
+		if err := d.ctrl.Redraw(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitForFrame advances the fake clock to the next periodic redraw of the
+// dashboard.
+func (d *Driver) WaitForFrame() error {
+	return d.Advance(d.opts.redrawInterval - d.elapsed)
+}
+
+// Now returns the current time of the fake clock. The clock starts at the
+// Unix epoch.
+func (d *Driver) Now() time.Time {
+	return d.now
+}
+
+// Terminal returns the fake terminal the dashboard runs on, e.g. for the use
+// with faketerm.Diff or faketerm.Golden.
+func (d *Driver) Terminal() *faketerm.Terminal {
+	return d.term.Terminal
+}
+
+// Buffer returns a copy of the content of the terminal.
+func (d *Driver) Buffer() cell.Buffer {
+	src := d.term.BackBuffer()
+	size := src.Size()
+	dst, err := cell.NewBuffer(size)
+	if err != nil {
+		return nil
+	}
+	for col := range src {
+		for row := range src[col] {
+			dst[col][row] = src[col][row].Copy()
+		}
+	}
+	return dst
+}
+
+// FocusedWidget returns the widget in the focused container or nil if the
+// focused container has no widget.
+func (d *Driver) FocusedWidget() widgetapi.Widget {
+	return d.cont.FocusedWidget()
+}
+
+// FocusedArea returns the area of the terminal occupied by the focused
+// container.
+func (d *Driver) FocusedArea() image.Rectangle {
+	return d.cont.FocusedArea()
+}
+
+// Close stops the dashboard.
+func (d *Driver) Close() {
+	if d.ctrl == nil {
+		return
+	}
+	d.ctrl.Close()
+	d.ctrl = nil
+	d.events.Close()
+}
+
+// syncTerm wraps the fake terminal and counts the calls to Event, which
+// allows the driver to determine when termdash finished processing an event.
+type syncTerm struct {
+	*faketerm.Terminal
+
+	// mu protects calls.
+	mu   sync.Mutex
+	cond *sync.Cond
+	// calls is the number of calls to Event.
+	calls int
+}
+
+// newSyncTerm returns a new syncTerm wrapping the fake terminal.
+func newSyncTerm(ft *faketerm.Terminal) *syncTerm {
+	st := &syncTerm{Terminal: ft}
+	st.cond = sync.NewCond(&st.mu)
+	return st
+}
+
+// Event implements terminalapi.Terminal.Event.
+func (st *syncTerm) Event(ctx context.Context) terminalapi.Event {
+	st.mu.Lock()
+	st.calls++
+	st.cond.Broadcast()
+	st.mu.Unlock()
+	return st.Terminal.Event(ctx)
+}
+
+// eventCalls returns the number of calls to Event.
+func (st *syncTerm) eventCalls() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.calls
+}
+
+// waitEventCalls blocks until Event was called at least n times.
+func (st *syncTerm) waitEventCalls(n int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for st.calls < n {
+		st.cond.Wait()
+	}
+}
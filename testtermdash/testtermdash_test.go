@@ -0,0 +1,391 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtermdash
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/testcanvas"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/fakewidget"
+)
+
+// mirrorOpts are the options of the fake widget used in the tests.
+var mirrorOpts = widgetapi.Options{
+	WantKeyboard: true,
+	WantMouse:    true,
+}
+
+// withMirror returns a NewContainerFn that places the fake widget into the
+// root container.
+func withMirror(t terminalapi.Terminal) (*container.Container, error) {
+	return container.New(t, container.PlaceWidget(fakewidget.New(mirrorOpts)))
+}
+
+// mirrorTerm returns a fake terminal with the content of the fake widget
+// occupying the whole terminal after receiving the events.
+func mirrorTerm(size image.Point, events ...terminalapi.Event) *faketerm.Terminal {
+	ft := faketerm.MustNew(size)
+	cvs := testcanvas.MustNew(ft.Area())
+	fakewidget.MustDraw(ft, cvs, mirrorOpts, events...)
+	return ft
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		fn      NewContainerFn
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "fails on invalid redraw interval",
+			fn:   withMirror,
+			opts: []Option{
+				RedrawInterval(0),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when the container cannot be created",
+			fn: func(terminalapi.Terminal) (*container.Container, error) {
+				return nil, errors.New("container error")
+			},
+			wantErr: true,
+		},
+		{
+			desc: "succeeds with default options",
+			fn:   withMirror,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := New(image.Point{30, 5}, tc.fn, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			d.Close()
+		})
+	}
+}
+
+func TestInteraction(t *testing.T) {
+	size := image.Point{30, 5}
+	tests := []struct {
+		desc string
+		// actions interact with the dashboard.
+		actions func(*Driver) error
+		want    func() *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:    "draws the dashboard when created",
+			actions: func(*Driver) error { return nil },
+			want: func() *faketerm.Terminal {
+				return mirrorTerm(size)
+			},
+		},
+		{
+			desc: "forwards key presses",
+			actions: func(d *Driver) error {
+				return d.PressKey(keyboard.KeyEnter)
+			},
+			want: func() *faketerm.Terminal {
+				return mirrorTerm(size, &terminalapi.Keyboard{Key: keyboard.KeyEnter})
+			},
+		},
+		{
+			desc: "types text one key at a time",
+			actions: func(d *Driver) error {
+				return d.Type("abc")
+			},
+			want: func() *faketerm.Terminal {
+				return mirrorTerm(size, &terminalapi.Keyboard{Key: 'c'})
+			},
+		},
+		{
+			desc: "forwards clicks",
+			actions: func(d *Driver) error {
+				return d.Click(image.Point{2, 3})
+			},
+			want: func() *faketerm.Terminal {
+				return mirrorTerm(size, &terminalapi.Mouse{Position: image.Point{2, 3}, Button: mouse.ButtonRelease})
+			},
+		},
+		{
+			desc: "returns errors reported while processing the event",
+			actions: func(d *Driver) error {
+				return d.PressKey(keyboard.KeyEsc)
+			},
+			want: func() *faketerm.Terminal {
+				return mirrorTerm(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "the terminal is cleared on resize",
+			actions: func(d *Driver) error {
+				return d.Resize(image.Point{40, 6})
+			},
+			want: func() *faketerm.Terminal {
+				return faketerm.MustNew(image.Point{40, 6})
+			},
+		},
+		{
+			desc: "redraws the dashboard on the next frame after resize",
+			actions: func(d *Driver) error {
+				if err := d.Resize(image.Point{40, 6}); err != nil {
+					return err
+				}
+				return d.WaitForFrame()
+			},
+			want: func() *faketerm.Terminal {
+				return mirrorTerm(image.Point{40, 6})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := New(size, withMirror)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			defer d.Close()
+
+			err = tc.actions(d)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("actions => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+
+			if diff := faketerm.Diff(tc.want(), d.Terminal()); diff != "" {
+				t.Errorf("Terminal => %v", diff)
+			}
+		})
+	}
+}
+
+func TestFocus(t *testing.T) {
+	left := fakewidget.New(mirrorOpts)
+	right := fakewidget.New(mirrorOpts)
+	d, err := New(
+		image.Point{60, 10},
+		func(t terminalapi.Terminal) (*container.Container, error) {
+			return container.New(
+				t,
+				container.SplitVertical(
+					container.Left(
+						container.Border(draw.LineStyleLight),
+						container.PlaceWidget(left),
+					),
+					container.Right(
+						container.Border(draw.LineStyleLight),
+						container.PlaceWidget(right),
+					),
+				),
+			)
+		},
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer d.Close()
+
+	if got := d.FocusedWidget(); got != nil {
+		t.Errorf("FocusedWidget => %v, want nil before any click", got)
+	}
+	if got, want := d.FocusedArea(), image.Rect(0, 0, 60, 10); got != want {
+		t.Errorf("FocusedArea => %v, want %v", got, want)
+	}
+
+	if err := d.Click(image.Point{40, 5}); err != nil {
+		t.Fatalf("Click => unexpected error: %v", err)
+	}
+	if got := d.FocusedWidget(); got != right {
+		t.Errorf("FocusedWidget => %v, want the right widget %v", got, right)
+	}
+	if got, want := d.FocusedArea(), image.Rect(30, 0, 60, 10); got != want {
+		t.Errorf("FocusedArea => %v, want %v", got, want)
+	}
+
+	if err := d.Click(image.Point{5, 5}); err != nil {
+		t.Fatalf("Click => unexpected error: %v", err)
+	}
+	if got := d.FocusedWidget(); got != left {
+		t.Errorf("FocusedWidget => %v, want the left widget %v", got, left)
+	}
+}
+
+// counter is a widget that counts how many times it was drawn.
+type counter struct {
+	mu    sync.Mutex
+	draws int
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (c *counter) Draw(cvs *canvas.Canvas) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draws++
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (c *counter) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("unimplemented")
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (c *counter) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("unimplemented")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (c *counter) Options() widgetapi.Options {
+	return widgetapi.Options{}
+}
+
+// get returns the number of draws.
+func (c *counter) get() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.draws
+}
+
+func TestClock(t *testing.T) {
+	interval := 100 * time.Millisecond
+	tests := []struct {
+		desc      string
+		actions   func(*Driver) error
+		wantDraws int
+		wantNow   time.Time
+		wantErr   bool
+	}{
+		{
+			desc:      "draws once when created",
+			actions:   func(*Driver) error { return nil },
+			wantDraws: 1,
+			wantNow:   time.Unix(0, 0),
+		},
+		{
+			desc: "doesn't redraw before the interval elapses",
+			actions: func(d *Driver) error {
+				return d.Advance(interval - time.Millisecond)
+			},
+			wantDraws: 1,
+			wantNow:   time.Unix(0, 0).Add(interval - time.Millisecond),
+		},
+		{
+			desc: "redraws once per elapsed interval",
+			actions: func(d *Driver) error {
+				return d.Advance(3*interval + time.Millisecond)
+			},
+			wantDraws: 4,
+			wantNow:   time.Unix(0, 0).Add(3*interval + time.Millisecond),
+		},
+		{
+			desc: "accumulates partial intervals",
+			actions: func(d *Driver) error {
+				for i := 0; i < 4; i++ {
+					if err := d.Advance(interval / 2); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantDraws: 3,
+			wantNow:   time.Unix(0, 0).Add(2 * interval),
+		},
+		{
+			desc: "WaitForFrame advances to the next redraw",
+			actions: func(d *Driver) error {
+				if err := d.Advance(interval / 4); err != nil {
+					return err
+				}
+				return d.WaitForFrame()
+			},
+			wantDraws: 2,
+			wantNow:   time.Unix(0, 0).Add(interval),
+		},
+		{
+			desc: "fails when the clock goes back",
+			actions: func(d *Driver) error {
+				return d.Advance(-time.Millisecond)
+			},
+			wantDraws: 1,
+			wantNow:   time.Unix(0, 0),
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &counter{}
+			d, err := New(
+				image.Point{10, 5},
+				func(t terminalapi.Terminal) (*container.Container, error) {
+					return container.New(t, container.PlaceWidget(c))
+				},
+				RedrawInterval(interval),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			defer d.Close()
+
+			err = tc.actions(d)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("actions => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if got := c.get(); got != tc.wantDraws {
+				t.Errorf("draws => %d, want %d", got, tc.wantDraws)
+			}
+			if got := d.Now(); !got.Equal(tc.wantNow) {
+				t.Errorf("Now => %v, want %v", got, tc.wantNow)
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	d, err := New(image.Point{30, 5}, withMirror)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	d.Close()
+	d.Close() // Closing twice is a no-op.
+
+	if err := d.PressKey(keyboard.KeyEnter); err == nil {
+		t.Errorf("PressKey => got nil error, want an error on a closed driver")
+	}
+	if err := d.WaitForFrame(); err == nil {
+		t.Errorf("WaitForFrame => got nil error, want an error on a closed driver")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package theme defines color themes shared by the containers and widgets.
+//
+// A theme assigns colors to semantic roles, e.g. the color of borders or of
+// the axes of charts. Set a theme on a container using the container.Theme
+// option. The theme is inherited by sub containers and widgets placed in the
+// containers use its colors for everything that wasn't explicitly colored
+// using their own options.
+package theme
+
+import "github.com/mum4k/termdash/cell"
+
+// Theme contains the colors of the semantic roles.
+// The zero value uses the default color of the terminal for all the roles.
+type Theme struct {
+	// Border is the color of borders around containers and widgets.
+	Border cell.Color
+	// FocusedBorder is the color of the border around the container that has
+	// the keyboard focus.
+	FocusedBorder cell.Color
+	// Title is the color of the titles in the borders.
+	Title cell.Color
+	// Axis is the color of the axes of charts.
+	Axis cell.Color
+	// Label is the color of labels, e.g. the values on chart axes or the text
+	// under bars.
+	Label cell.Color
+	// Series are the colors used for the data displayed by widgets, e.g. the
+	// lines in a line chart or the bars of a bar chart. Widgets that display
+	// multiple series use the colors in order and start over when they run
+	// out of colors.
+	Series []cell.Color
+
+	// OK is the color of values in the normal range.
+	OK cell.Color
+	// Warn is the color of values that need attention.
+	Warn cell.Color
+	// Critical is the color of values that need immediate action.
+	Critical cell.Color
+}
+
+// SeriesColor returns the color of the i-th series. Returns the default
+// color if the theme doesn't have any series colors.
+func (t *Theme) SeriesColor(i int) cell.Color {
+	if len(t.Series) == 0 || i < 0 {
+		return cell.ColorDefault
+	}
+	return t.Series[i%len(t.Series)]
+}
+
+// Dark returns a theme for terminals with a dark background.
+// Each call returns a new instance that can be modified by the caller.
+func Dark() *Theme {
+	return &Theme{
+		Border:        cell.ColorNumber(244),
+		FocusedBorder: cell.ColorNumber(214),
+		Title:         cell.ColorNumber(252),
+		Axis:          cell.ColorNumber(244),
+		Label:         cell.ColorNumber(250),
+		Series: []cell.Color{
+			cell.ColorNumber(39),
+			cell.ColorNumber(214),
+			cell.ColorNumber(78),
+			cell.ColorNumber(170),
+			cell.ColorNumber(203),
+			cell.ColorNumber(44),
+		},
+		OK:       cell.ColorNumber(78),
+		Warn:     cell.ColorNumber(220),
+		Critical: cell.ColorNumber(196),
+	}
+}
+
+// Light returns a theme for terminals with a light background.
+// Each call returns a new instance that can be modified by the caller.
+func Light() *Theme {
+	return &Theme{
+		Border:        cell.ColorNumber(245),
+		FocusedBorder: cell.ColorNumber(25),
+		Title:         cell.ColorNumber(235),
+		Axis:          cell.ColorNumber(240),
+		Label:         cell.ColorNumber(238),
+		Series: []cell.Color{
+			cell.ColorNumber(25),
+			cell.ColorNumber(166),
+			cell.ColorNumber(28),
+			cell.ColorNumber(90),
+			cell.ColorNumber(160),
+			cell.ColorNumber(30),
+		},
+		OK:       cell.ColorNumber(28),
+		Warn:     cell.ColorNumber(136),
+		Critical: cell.ColorNumber(160),
+	}
+}
+
+// HighContrast returns a theme that only uses the bright variants of the
+// system colors, for terminals with a dark background and users who need
+// strong contrast.
+// Each call returns a new instance that can be modified by the caller.
+func HighContrast() *Theme {
+	return &Theme{
+		Border:        cell.ColorNumber(15),
+		FocusedBorder: cell.ColorNumber(11),
+		Title:         cell.ColorNumber(15),
+		Axis:          cell.ColorNumber(15),
+		Label:         cell.ColorNumber(15),
+		Series: []cell.Color{
+			cell.ColorNumber(11),
+			cell.ColorNumber(14),
+			cell.ColorNumber(13),
+			cell.ColorNumber(10),
+			cell.ColorNumber(9),
+			cell.ColorNumber(15),
+		},
+		OK:       cell.ColorNumber(10),
+		Warn:     cell.ColorNumber(11),
+		Critical: cell.ColorNumber(9),
+	}
+}
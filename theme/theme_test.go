@@ -0,0 +1,90 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package theme
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestSeriesColor(t *testing.T) {
+	tests := []struct {
+		desc  string
+		theme *Theme
+		i     int
+		want  cell.Color
+	}{
+		{
+			desc:  "no series colors",
+			theme: &Theme{},
+			i:     0,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "negative index",
+			theme: &Theme{Series: []cell.Color{cell.ColorRed}},
+			i:     -1,
+			want:  cell.ColorDefault,
+		},
+		{
+			desc:  "first color",
+			theme: &Theme{Series: []cell.Color{cell.ColorRed, cell.ColorBlue}},
+			i:     0,
+			want:  cell.ColorRed,
+		},
+		{
+			desc:  "second color",
+			theme: &Theme{Series: []cell.Color{cell.ColorRed, cell.ColorBlue}},
+			i:     1,
+			want:  cell.ColorBlue,
+		},
+		{
+			desc:  "starts over when out of colors",
+			theme: &Theme{Series: []cell.Color{cell.ColorRed, cell.ColorBlue}},
+			i:     2,
+			want:  cell.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.theme.SeriesColor(tc.i); got != tc.want {
+				t.Errorf("SeriesColor(%d) => %v, want %v", tc.i, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinThemes(t *testing.T) {
+	themes := map[string]func() *Theme{
+		"Dark":         Dark,
+		"Light":        Light,
+		"HighContrast": HighContrast,
+	}
+
+	for name, fn := range themes {
+		t.Run(name, func(t *testing.T) {
+			th := fn()
+			if len(th.Series) == 0 {
+				t.Errorf("%s() has no series colors", name)
+			}
+			th.Series[0] = cell.ColorDefault
+			if got := fn().Series[0]; got == cell.ColorDefault {
+				t.Errorf("%s() returned a shared instance, modification of one instance changed another", name)
+			}
+		})
+	}
+}
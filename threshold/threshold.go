@@ -0,0 +1,60 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package threshold assigns colors to values based on thresholds the values
+// reach, e.g. yellow for values from 70 and red for values from 90.
+package threshold
+
+import "github.com/mum4k/termdash/cell"
+
+// Colors are colors assigned to thresholds. A value has the color of the
+// largest threshold it reaches, i.e. the largest threshold that is less than
+// or equal to the value.
+// The zero value is ready to use and has no thresholds.
+type Colors struct {
+	thresholds []threshold
+}
+
+// threshold is a value from which values have the specified color.
+type threshold struct {
+	value float64
+	color cell.Color
+}
+
+// Set assigns the color to values that reach the threshold. Setting the same
+// threshold again replaces its color.
+func (c *Colors) Set(value float64, color cell.Color) {
+	for i, t := range c.thresholds {
+		if t.value == value {
+			c.thresholds[i].color = color
+			return
+		}
+	}
+	c.thresholds = append(c.thresholds, threshold{value: value, color: color})
+}
+
+// Color returns the color of the largest threshold the value reaches. Returns
+// false if the value doesn't reach any threshold.
+func (c *Colors) Color(value float64) (cell.Color, bool) {
+	var reached *threshold
+	for i, t := range c.thresholds {
+		if value >= t.value && (reached == nil || t.value > reached.value) {
+			reached = &c.thresholds[i]
+		}
+	}
+	if reached == nil {
+		return cell.ColorDefault, false
+	}
+	return reached.color, true
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package threshold
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestColors(t *testing.T) {
+	// set is a threshold set on the Colors.
+	type set struct {
+		value float64
+		color cell.Color
+	}
+
+	tests := []struct {
+		desc      string
+		sets      []set
+		value     float64
+		wantColor cell.Color
+		wantOK    bool
+	}{
+		{
+			desc:      "no thresholds",
+			value:     10,
+			wantColor: cell.ColorDefault,
+		},
+		{
+			desc:      "value below all the thresholds",
+			sets:      []set{{70, cell.ColorYellow}, {90, cell.ColorRed}},
+			value:     69,
+			wantColor: cell.ColorDefault,
+		},
+		{
+			desc:      "value equal to a threshold reaches it",
+			sets:      []set{{70, cell.ColorYellow}, {90, cell.ColorRed}},
+			value:     70,
+			wantColor: cell.ColorYellow,
+			wantOK:    true,
+		},
+		{
+			desc:      "largest reached threshold wins regardless of the order",
+			sets:      []set{{90, cell.ColorRed}, {70, cell.ColorYellow}},
+			value:     95,
+			wantColor: cell.ColorRed,
+			wantOK:    true,
+		},
+		{
+			desc:      "setting the same threshold again replaces the color",
+			sets:      []set{{70, cell.ColorYellow}, {70, cell.ColorBlue}},
+			value:     80,
+			wantColor: cell.ColorBlue,
+			wantOK:    true,
+		},
+		{
+			desc:      "negative thresholds",
+			sets:      []set{{-10, cell.ColorBlue}, {0, cell.ColorGreen}},
+			value:     -5,
+			wantColor: cell.ColorBlue,
+			wantOK:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var c Colors
+			for _, s := range tc.sets {
+				c.Set(s.value, s.color)
+			}
+
+			gotColor, gotOK := c.Color(tc.value)
+			if gotColor != tc.wantColor || gotOK != tc.wantOK {
+				t.Errorf("Color(%v) => %v, %v, want %v, %v", tc.value, gotColor, gotOK, tc.wantColor, tc.wantOK)
+			}
+		})
+	}
+}
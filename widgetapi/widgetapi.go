@@ -20,6 +20,7 @@ import (
 
 	"github.com/mum4k/termdash/canvas"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 )
 
 // Options contains registration options for a widget.
@@ -53,7 +54,9 @@ type Options struct {
 	// WantMouse allows a widget to request mouse events.
 	// If false, mouse events won't be forwarded to the widget.
 	// If true, the widget receives all mouse events whose coordinates fall
-	// within its canvas.
+	// within its canvas. The release of the left mouse button is also
+	// forwarded to the widget that received the preceding press of the
+	// button, even if the release falls outside of its canvas.
 	WantMouse bool
 }
 
@@ -76,7 +79,13 @@ type Widget interface {
 
 	// Mouse is called when the widget is focused on the dashboard and a mouse
 	// event happens on its canvas. Only called if the widget registered for mouse
-	// events.
+	// events. The coordinates are relative to the canvas of the widget.
+	//
+	// The widget also receives the release of the left mouse button that
+	// follows a press of the button on its canvas, even if the release happens
+	// outside of the canvas. The coordinates of such release fall outside of
+	// the canvas and can be negative, widgets must not assume that the
+	// coordinates of a mouse event are within the canvas.
 	Mouse(m *terminalapi.Mouse) error
 
 	// Options returns registration options for the widget.
@@ -85,3 +94,21 @@ type Widget interface {
 	// size, etc.
 	Options() Options
 }
+
+// Themed is an optional interface implemented by widgets that use the colors
+// of the theme set on the container the widget is placed in.
+// Colors explicitly provided to the widget via its options take precedence
+// over the theme.
+type Themed interface {
+	// SetTheme is called by the infrastructure before each call to Draw()
+	// with the theme inherited by the container or nil if no theme was set.
+	SetTheme(t *theme.Theme)
+}
+
+// Focusable is an optional interface implemented by widgets that display
+// whether their container has the keyboard focus, e.g. buttons.
+type Focusable interface {
+	// SetFocused is called by the infrastructure before each call to Draw()
+	// with true if the container of the widget has the keyboard focus.
+	SetFocused(focused bool)
+}
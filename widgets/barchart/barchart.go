@@ -20,35 +20,46 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
+	"strconv"
 	"sync"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/canvas"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/numbers"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
 // BarChart displays multiple bars showing relative ratios of values.
 //
 // Each bar can have a text label under it explaining the meaning of the value
-// and can display the value itself inside the bar.
+// and can display the value itself inside the bar. Negative values are
+// displayed as bars that extend down from the zero baseline.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type BarChart struct {
-	// values are the values provided on a call to Values(). These are the
-	// individual bars that will be drawn.
-	values []int
+	// values are the values provided on a call to Values() or FloatValues().
+	// These are the individual bars that will be drawn.
+	values []float64
+	// min is the minimum value of a bar. A bar having this value takes all
+	// the vertical space below the zero baseline.
+	min float64
 	// max is the maximum value of a bar. A bar having this value takes all the
-	// vertical space.
-	max int
+	// vertical space above the zero baseline.
+	max float64
 
 	// mu protects the BarChart.
 	mu sync.Mutex
 
 	// opts are the provided options.
 	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
 }
 
 // New returns a new BarChart.
@@ -69,14 +80,10 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas) error {
 	defer bc.mu.Unlock()
 
 	for i, v := range bc.values {
-		r, err := bc.barRect(cvs, i, v)
-		if err != nil {
-			return err
-		}
-
+		r := bc.barRect(cvs, i, v)
 		if r.Dy() > 0 { // Value might be so small so that the rectangle is zero.
 			if err := draw.Rectangle(cvs, r,
-				draw.RectCellOpts(cell.BgColor(bc.barColor(i))),
+				draw.RectCellOpts(cell.BgColor(bc.barColor(i, v))),
 				draw.RectChar(bc.opts.barChar),
 			); err != nil {
 				return err
@@ -84,14 +91,19 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas) error {
 		}
 
 		if bc.opts.showValues {
-			if err := bc.drawText(cvs, i, fmt.Sprint(bc.values[i]), bc.valColor(i), insideBar); err != nil {
+			ar, vAlign := bc.valueArea(cvs, i, v)
+			if err := bc.drawText(cvs, ar, bc.valueText(v), bc.valColor(i), vAlign); err != nil {
 				return err
 			}
 		}
 
 		l, c := bc.label(i)
 		if l != "" {
-			if err := bc.drawText(cvs, i, l, c, underBar); err != nil {
+			// Align the text within the entire column where the bar is, this
+			// includes the space for any label under the bar.
+			minX, maxX := bc.barX(cvs, i)
+			ar := image.Rect(minX, cvs.Area().Min.Y, maxX, cvs.Area().Max.Y)
+			if err := bc.drawText(cvs, ar, l, c, align.VerticalBottom); err != nil {
 				return err
 			}
 		}
@@ -99,42 +111,28 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas) error {
 	return nil
 }
 
-// textLoc represents the location of the drawn text.
-type textLoc int
-
-const (
-	insideBar textLoc = iota
-	underBar
-)
-
-// drawText draws the provided text inside or under the i-th bar.
-func (bc *BarChart) drawText(cvs *canvas.Canvas, i int, text string, color cell.Color, loc textLoc) error {
-	// Rectangle representing area in which the text will be aligned.
-	var barCol image.Rectangle
-
-	r, err := bc.barRect(cvs, i, bc.max)
-	if err != nil {
-		return err
+// valueText formats the value displayed inside of a bar.
+func (bc *BarChart) valueText(v float64) string {
+	if bc.opts.valueFormat != "" {
+		return fmt.Sprintf(bc.opts.valueFormat, v)
 	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
 
-	switch loc {
-	case insideBar:
-		// Align the text within the bar itself.
-		barCol = r
-	case underBar:
-		// Align the text within the entire column where the bar is, this
-		// includes the space for any label under the bar.
-		barCol = image.Rect(r.Min.X, cvs.Area().Min.Y, r.Max.X, cvs.Area().Max.Y)
+// drawText draws the provided text aligned within the area.
+func (bc *BarChart) drawText(cvs *canvas.Canvas, ar image.Rectangle, text string, color cell.Color, vAlign align.Vertical) error {
+	if ar.Dy() <= 0 {
+		return nil // No space for the text.
 	}
 
-	start, err := align.Text(barCol, text, align.HorizontalCenter, align.VerticalBottom)
+	start, err := align.Text(ar, text, align.HorizontalCenter, vAlign)
 	if err != nil {
 		return err
 	}
 
 	return draw.Text(cvs, text, start,
 		draw.TextCellOpts(cell.FgColor(color)),
-		draw.TextMaxX(barCol.Max.X),
+		draw.TextMaxX(ar.Max.X),
 		draw.TextOverrunMode(draw.OverrunModeThreeDot),
 	)
 }
@@ -156,44 +154,89 @@ func (bc *BarChart) barWidth(cvs *canvas.Canvas) int {
 	return rem / len(bc.values)
 }
 
-// barHeight determines the height of the i-th bar based on the value it is displaying.
-func (bc *BarChart) barHeight(cvs *canvas.Canvas, i, value int) int {
-	available := cvs.Area().Dy()
+// barX returns the horizontal range of the i-th bar on the canvas.
+func (bc *BarChart) barX(cvs *canvas.Canvas, i int) (minX, maxX int) {
+	bw := bc.barWidth(cvs)
+	minX = bw * i
+	if i > 0 {
+		minX += bc.opts.barGap * i
+	}
+	return minX, minX + bw
+}
+
+// graphRows returns the range of rows on the canvas available to the bars
+// and the row of the zero baseline. Bars of positive values end right above
+// the baseline, bars of negative values start at the baseline.
+func (bc *BarChart) graphRows(cvs *canvas.Canvas) (minY, baseY, maxY int) {
+	minY, maxY = cvs.Area().Min.Y, cvs.Area().Max.Y
 	if len(bc.opts.labels) > 0 {
 		// One line for the bar labels.
-		available--
+		maxY--
 	}
 
-	ratio := float32(value) / float32(bc.max)
-	return int(float32(available) * ratio)
+	available := maxY - minY
+	above := available
+	if bc.min < 0 {
+		above = int(numbers.Round(float64(available) * bc.max / (bc.max - bc.min)))
+	}
+	return minY, minY + above, maxY
+}
+
+// barHeight determines the height of a bar that displays the value, given
+// the number of rows available above or below the zero baseline.
+func (bc *BarChart) barHeight(value float64, above, below int) int {
+	switch {
+	case value > 0 && bc.max > 0:
+		return int(float64(above) * value / bc.max)
+	case value < 0 && bc.min < 0:
+		return int(float64(below) * value / bc.min)
+	default:
+		return 0
+	}
 }
 
 // barRect returns a rectangle that represents the i-th bar on the canvas that
 // displays the specified value.
-func (bc *BarChart) barRect(cvs *canvas.Canvas, i, value int) (image.Rectangle, error) {
-	bw := bc.barWidth(cvs)
-	minX := bw * i
-	if i > 0 {
-		minX += bc.opts.barGap * i
+func (bc *BarChart) barRect(cvs *canvas.Canvas, i int, value float64) image.Rectangle {
+	minX, maxX := bc.barX(cvs, i)
+	minY, baseY, maxY := bc.graphRows(cvs)
+	bh := bc.barHeight(value, baseY-minY, maxY-baseY)
+	if value < 0 {
+		return image.Rect(minX, baseY, maxX, baseY+bh)
 	}
-	maxX := minX + bw
+	return image.Rect(minX, baseY-bh, maxX, baseY)
+}
 
-	bh := bc.barHeight(cvs, i, value)
-	maxY := cvs.Area().Max.Y
-	if len(bc.opts.labels) > 0 {
-		// One line for the bar labels.
-		maxY--
+// valueArea returns the area where the value of the i-th bar is displayed
+// and the vertical alignment of the value within it. The value of a positive
+// bar is displayed at the bottom of the area above the zero baseline, the
+// value of a negative bar at the top of the area below it.
+func (bc *BarChart) valueArea(cvs *canvas.Canvas, i int, value float64) (image.Rectangle, align.Vertical) {
+	minX, maxX := bc.barX(cvs, i)
+	minY, baseY, maxY := bc.graphRows(cvs)
+	if value < 0 || baseY == minY {
+		return image.Rect(minX, baseY, maxX, maxY), align.VerticalTop
 	}
-	minY := maxY - bh
-	return image.Rect(minX, minY, maxX, maxY), nil
+	return image.Rect(minX, minY, maxX, baseY), align.VerticalBottom
 }
 
-// barColor safely determines the color for the i-th bar.
-// Colors are optional and don't have to be specified for all the bars.
-func (bc *BarChart) barColor(i int) cell.Color {
+// barColor safely determines the color for the i-th bar that displays the
+// value. Colors are optional and don't have to be specified for all the bars.
+// Colors of thresholds and of negative values take precedence.
+func (bc *BarChart) barColor(i int, value float64) cell.Color {
+	if c, ok := bc.opts.thresholds.Color(value); ok {
+		return c
+	}
+	if value < 0 && bc.opts.negativeColorSet {
+		return bc.opts.negativeColor
+	}
+
 	if len(bc.opts.barColors) > i {
 		return bc.opts.barColors[i]
 	}
+	if bc.theme != nil {
+		return bc.theme.SeriesColor(i)
+	}
 	return DefaultBarColor
 }
 
@@ -217,19 +260,48 @@ func (bc *BarChart) label(i int) (string, cell.Color) {
 	if len(bc.opts.labelColors) > i {
 		return label, bc.opts.labelColors[i]
 	}
+	if bc.theme != nil {
+		return label, bc.theme.Label
+	}
 	return label, DefaultLabelColor
 }
 
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (bc *BarChart) SetTheme(t *theme.Theme) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.theme = t
+}
+
 // Values sets the values to be displayed by the BarChart.
 // Each value ends up in its own bar. The values must not be negative and must
 // be less or equal the maximum value. A bar displaying the maximum value is a
 // full bar, taking all available vertical space.
 // Provided options override values set when New() was called.
 func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
+	if err := validateValues(values, max); err != nil {
+		return err
+	}
+
+	var fv []float64
+	for _, v := range values {
+		fv = append(fv, float64(v))
+	}
+	return bc.FloatValues(fv, 0, float64(max), opts...)
+}
+
+// FloatValues is like Values, but accepts values with fractional parts and
+// negative values. The values must be in range min <= value <= max.
+// The min must be zero or negative and the max zero or positive, the
+// vertical space is divided between them by the zero baseline. Bars of
+// negative values extend down from the baseline, a bar displaying the min
+// takes all the vertical space below it.
+// Provided options override values set when New() was called.
+func (bc *BarChart) FloatValues(values []float64, min, max float64, opts ...Option) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
-	if err := validateValues(values, max); err != nil {
+	if err := validateFloatValues(values, min, max); err != nil {
 		return err
 	}
 
@@ -237,6 +309,7 @@ func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 		opt.set(bc.opts)
 	}
 	bc.values = values
+	bc.min = min
 	bc.max = max
 	return nil
 }
@@ -297,3 +370,22 @@ func validateValues(values []int, max int) error {
 	}
 	return nil
 }
+
+// validateFloatValues validates the provided values, minimum and maximum.
+func validateFloatValues(values []float64, min, max float64) error {
+	if min > 0 || max < 0 || min == max || !finite(min) || !finite(max) {
+		return fmt.Errorf("invalid minimum %v and maximum %v, must be min <= 0 <= max and min < max", min, max)
+	}
+
+	for i, v := range values {
+		if !finite(v) || v < min || v > max {
+			return fmt.Errorf("invalid values[%d]: %v, each value must be min <= value <= max", i, v)
+		}
+	}
+	return nil
+}
+
+// finite asserts whether the value is neither NaN nor infinite.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -16,6 +16,7 @@ package barchart
 
 import (
 	"image"
+	"math"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -25,6 +26,7 @@ import (
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/draw/testdraw"
 	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -185,6 +187,61 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "uses colors from the theme",
+			bc: New(
+				Char('o'),
+				BarColors([]cell.Color{cell.ColorCyan}),
+				Labels([]string{
+					"1",
+					"2",
+					"3",
+				}),
+				LabelColors([]cell.Color{cell.ColorRed}),
+			),
+			update: func(bc *BarChart) error {
+				bc.SetTheme(&theme.Theme{
+					Label:  cell.ColorWhite,
+					Series: []cell.Color{cell.ColorBlue, cell.ColorMagenta},
+				})
+				return bc.Values([]int{1, 2, 5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 11),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorCyan)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 5, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(6, 0, 7, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+
+				// Labels.
+				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorWhite),
+				))
+				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorWhite),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "trims too long labels",
 			bc: New(
@@ -519,6 +576,203 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "fails for positive min",
+			bc: New(
+				Char('o'),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{1, 2}, 1, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails for negative max",
+			bc: New(
+				Char('o'),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{-1, -2}, -10, -1)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails for equal min and max",
+			bc: New(
+				Char('o'),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues(nil, 0, 0)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails for NaN value",
+			bc: New(
+				Char('o'),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{math.NaN()}, 0, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails for value smaller than min",
+			bc: New(
+				Char('o'),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{-11}, -10, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "displays negative bars below the zero baseline",
+			bc: New(
+				Char('o'),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{-5, 5, 10, -10}, -10, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, r := range []image.Rectangle{
+					image.Rect(0, 5, 1, 7),
+					image.Rect(2, 3, 3, 5),
+					image.Rect(4, 0, 5, 5),
+					image.Rect(6, 5, 7, 10),
+				} {
+					testdraw.MustRectangle(c, r,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays fractional values in the specified format",
+			bc: New(
+				Char('o'),
+				BarWidth(3),
+				ShowValues(),
+				ValueFormat("%.1f"),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{1.5, 2.5}, 0, 3)
+			},
+			canvas: image.Rect(0, 0, 7, 6),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 3, 3, 6),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "1.5", image.Point{0, 5},
+					draw.TextCellOpts(cell.FgColor(DefaultValueColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 1, 7, 6),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "2.5", image.Point{4, 5},
+					draw.TextCellOpts(cell.FgColor(DefaultValueColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays values of negative bars at the top of the bar",
+			bc: New(
+				Char('o'),
+				BarWidth(2),
+				ShowValues(),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{-2, 2}, -4, 4)
+			},
+			canvas: image.Rect(0, 0, 5, 8),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 4, 2, 6),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "-2", image.Point{0, 4},
+					draw.TextCellOpts(cell.FgColor(DefaultValueColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 2, 5, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "2", image.Point{3, 3},
+					draw.TextCellOpts(cell.FgColor(DefaultValueColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "colors bars by sign and thresholds",
+			bc: New(
+				Char('o'),
+				BarColors([]cell.Color{cell.ColorGreen, cell.ColorGreen, cell.ColorGreen}),
+				NegativeBarColor(cell.ColorBlue),
+				Threshold(5, cell.ColorYellow),
+			),
+			update: func(bc *BarChart) error {
+				return bc.FloatValues([]float64{-1, 1, 5}, -5, 5)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 5, 1, 6),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 4, 3, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
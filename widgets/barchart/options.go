@@ -19,6 +19,7 @@ package barchart
 import (
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/threshold"
 )
 
 // Option is used to provide options.
@@ -45,6 +46,11 @@ type options struct {
 	labelColors []cell.Color
 	valueColors []cell.Color
 	labels      []string
+	valueFormat string
+
+	thresholds       threshold.Colors
+	negativeColor    cell.Color
+	negativeColorSet bool
 }
 
 // newOptions returns options with the default values set.
@@ -99,7 +105,8 @@ const DefaultBarColor = cell.ColorRed
 // BarColors sets the colors of each of the bars.
 // Bars are created on a call to Values(), each value ends up in its own Bar.
 // The first supplied color applies to the bar displaying the first value.
-// Any bars that don't have a color specified use the DefaultBarColor.
+// Any bars that don't have a color specified use the series colors of the
+// theme set on the container or the DefaultBarColor if there is no theme.
 func BarColors(colors []cell.Color) Option {
 	return option(func(opts *options) {
 		opts.barColors = colors
@@ -113,8 +120,9 @@ const DefaultLabelColor = cell.ColorGreen
 // LabelColors sets the colors of each of the labels under the bars.
 // Bars are created on a call to Values(), each value ends up in its own Bar.
 // The first supplied color applies to the label of the bar displaying the
-// first value. Any labels that don't have a color specified use the
-// DefaultLabelColor.
+// first value. Any labels that don't have a color specified use the label
+// color of the theme set on the container or the DefaultLabelColor if there
+// is no theme.
 func LabelColors(colors []cell.Color) Option {
 	return option(func(opts *options) {
 		opts.labelColors = colors
@@ -145,3 +153,32 @@ func ValueColors(colors []cell.Color) Option {
 		opts.valueColors = colors
 	})
 }
+
+// ValueFormat sets the format of the values displayed in the bars when the
+// ShowValues option is provided. The format is a fmt verb for a float64
+// value, e.g. "%.1f". If not set, values are displayed with the smallest
+// number of decimal places necessary.
+func ValueFormat(format string) Option {
+	return option(func(opts *options) {
+		opts.valueFormat = format
+	})
+}
+
+// NegativeBarColor sets the color of bars that display negative values.
+// Takes precedence over the BarColors option.
+func NegativeBarColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.negativeColor = c
+		opts.negativeColorSet = true
+	})
+}
+
+// Threshold colors the bars of values that reach the specified value with the
+// provided color. Can be specified multiple times, see threshold.Colors for
+// how the color of a bar is chosen.
+// Takes precedence over the BarColors and the NegativeBarColor options.
+func Threshold(v float64, c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.thresholds.Set(v, c)
+	})
+}
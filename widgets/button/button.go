@@ -0,0 +1,266 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package button is a widget that displays a button the user can press with
+// the mouse or the keyboard.
+package button
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// CallbackFn is the function called when the button is pressed.
+//
+// The callback function must be light-weight, ideally just storing a value and
+// returning, since more button presses might occur. The callback function
+// must be thread-safe as the mouse or keyboard events that press the button
+// are processed in a separate goroutine. The function is called after the
+// widget released its lock, so it can call the methods of the widget.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type CallbackFn func() error
+
+// Button displays a button with a label, e.g. to pause the ingestion of data.
+//
+// The button is pressed by a click of the left mouse button or by the enter
+// or the space key while its container has the keyboard focus. The button is
+// displayed in a different color while it is focused and while it is
+// pressed.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Button struct {
+	// label is the text displayed on the button.
+	label string
+	// onPress is called when the button is pressed.
+	onPress CallbackFn
+
+	// mousePressed is true while the left mouse button is held on the button.
+	mousePressed bool
+	// keyUpAt is the time when the button pressed with the keyboard is
+	// released.
+	keyUpAt time.Time
+	// focused indicates whether the container of the button has the keyboard
+	// focus.
+	focused bool
+	// area is the area of the canvas from the last call to Draw.
+	area image.Rectangle
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+
+	// pending are the callbacks to call once mu is released, so that the
+	// callbacks can access the Button.
+	pending []func() error
+
+	// mu protects the Button.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new Button with the label that calls the callback function
+// when pressed.
+func New(label string, onPress CallbackFn, opts ...Option) (*Button, error) {
+	if err := validLabel(label); err != nil {
+		return nil, err
+	}
+	if onPress == nil {
+		return nil, errors.New("the callback function cannot be nil")
+	}
+
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Button{
+		label:   label,
+		onPress: onPress,
+		now:     time.Now,
+		opts:    o,
+	}, nil
+}
+
+// pressed asserts whether the button is displayed as pressed.
+// b.mu must be held when calling this method.
+func (b *Button) pressed() bool {
+	return b.mousePressed || b.now().Before(b.keyUpAt)
+}
+
+// unlockAndCall releases b.mu and calls the pending callbacks in order.
+// Returns the error of the first callback that fails, the remaining callbacks
+// aren't called.
+// b.mu must be held when calling this method.
+func (b *Button) unlockAndCall() error {
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for _, call := range pending {
+		if err := call(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// press schedules the callback of the button.
+// b.mu must be held when calling this method.
+func (b *Button) press() {
+	b.pending = append(b.pending, b.onPress)
+}
+
+// fillColor returns the color of the button in its current state.
+// b.mu must be held when calling this method.
+func (b *Button) fillColor() cell.Color {
+	switch {
+	case b.pressed():
+		if b.theme != nil && !b.opts.pressedFillColorSet {
+			return b.theme.Axis
+		}
+		return b.opts.pressedFillColor
+
+	case b.focused:
+		if b.theme != nil && !b.opts.focusedFillColorSet {
+			return b.theme.FocusedBorder
+		}
+		return b.opts.focusedFillColor
+
+	default:
+		if b.theme != nil && !b.opts.fillColorSet {
+			return b.theme.SeriesColor(0)
+		}
+		return b.opts.fillColor
+	}
+}
+
+// Draw draws the Button widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (b *Button) Draw(cvs *canvas.Canvas) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ar := cvs.Area()
+	b.area = ar
+	cOpts := []cell.Option{
+		cell.FgColor(b.opts.textColor),
+		cell.BgColor(b.fillColor()),
+	}
+	if err := draw.Rectangle(cvs, ar, draw.RectCellOpts(cOpts...)); err != nil {
+		return err
+	}
+
+	start, err := align.Text(ar, b.label, align.HorizontalCenter, align.VerticalMiddle)
+	if err != nil {
+		return err
+	}
+	return draw.Text(cvs, b.label, start,
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cOpts...),
+	)
+}
+
+// Keyboard presses the button when the enter or the space key is pressed.
+// Implements widgetapi.Widget.Keyboard.
+func (b *Button) Keyboard(k *terminalapi.Keyboard) error {
+	b.mu.Lock()
+	if k.Key == keyboard.KeyEnter || k.Key == keyboard.KeySpace {
+		b.keyUpAt = b.now().Add(b.opts.keyUpDelay)
+		b.press()
+	}
+	return b.unlockAndCall()
+}
+
+// Mouse presses the button when the left mouse button is released over it.
+// The button is displayed as pressed while the mouse button is held down.
+// A release outside of the button, which the container forwards to the
+// widget that received the press, releases the button without pressing it.
+// Implements widgetapi.Widget.Mouse.
+func (b *Button) Mouse(m *terminalapi.Mouse) error {
+	b.mu.Lock()
+	switch m.Button {
+	case mouse.ButtonLeft:
+		b.mousePressed = true
+	case mouse.ButtonRelease:
+		if b.mousePressed && m.Position.In(b.area) {
+			b.press()
+		}
+		b.mousePressed = false
+	default:
+		b.mousePressed = false
+	}
+	return b.unlockAndCall()
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (b *Button) SetTheme(t *theme.Theme) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.theme = t
+}
+
+// SetFocused implements widgetapi.Focusable.SetFocused.
+func (b *Button) SetFocused(focused bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.focused = focused
+	if !focused {
+		b.mousePressed = false
+	}
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*Button) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+}
+
+// validLabel validates the label of the button.
+func validLabel(label string) error {
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	for _, r := range label {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("the label %q cannot contain control characters, found: %q", label, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+import (
+	"errors"
+	"image"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		onPress CallbackFn
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc:    "succeeds with default options",
+			label:   "ok",
+			onPress: func() error { return nil },
+		},
+		{
+			desc:    "fails on an empty label",
+			onPress: func() error { return nil },
+			wantErr: true,
+		},
+		{
+			desc:    "fails on control characters in the label",
+			label:   "o\tk",
+			onPress: func() error { return nil },
+			wantErr: true,
+		},
+		{
+			desc:    "fails on nil callback",
+			label:   "ok",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero key up delay",
+			label:   "ok",
+			onPress: func() error { return nil },
+			opts:    []Option{KeyUpDelay(0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.label, tc.onPress, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// event is a keyboard or a mouse event processed by the widget.
+type event struct {
+	k *terminalapi.Keyboard
+	m *terminalapi.Mouse
+}
+
+// key returns a keyboard event.
+func key(k keyboard.Key) *event {
+	return &event{k: &terminalapi.Keyboard{Key: k}}
+}
+
+// button returns a mouse event with the button.
+func button(b mouse.Button) *event {
+	return &event{m: &terminalapi.Mouse{Position: image.Point{1, 1}, Button: b}}
+}
+
+// buttonAt returns a mouse event with the button at the position.
+func buttonAt(b mouse.Button, p image.Point) *event {
+	return &event{m: &terminalapi.Mouse{Position: p, Button: b}}
+}
+
+func TestButton(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		opts    []Option
+		theme   *theme.Theme
+		focused bool
+		events  []*event
+		// wait advances the time after the events are processed.
+		wait        time.Duration
+		canvas      image.Rectangle
+		wantPresses int
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws the label in the middle",
+			label:  "ok",
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "Button_default.golden",
+		},
+		{
+			desc:   "trims a long label",
+			label:  "pause ingestion",
+			canvas: image.Rect(0, 0, 8, 1),
+			golden: "Button_trimmed.golden",
+		},
+		{
+			desc:    "focused button",
+			label:   "ok",
+			focused: true,
+			canvas:  image.Rect(0, 0, 8, 3),
+			golden:  "Button_focused.golden",
+		},
+		{
+			desc:    "held mouse button displays the button as pressed",
+			label:   "ok",
+			focused: true,
+			events:  []*event{button(mouse.ButtonLeft)},
+			canvas:  image.Rect(0, 0, 8, 3),
+			golden:  "Button_pressed.golden",
+		},
+		{
+			desc:        "release of the mouse button presses the button",
+			label:       "ok",
+			events:      []*event{button(mouse.ButtonLeft), button(mouse.ButtonLeft), button(mouse.ButtonRelease)},
+			canvas:      image.Rect(0, 0, 8, 3),
+			wantPresses: 1,
+			golden:      "Button_default.golden",
+		},
+		{
+			desc:   "release without a press is ignored",
+			label:  "ok",
+			events: []*event{button(mouse.ButtonRelease)},
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "Button_default.golden",
+		},
+		{
+			desc:   "release outside of the button doesn't press it",
+			label:  "ok",
+			events: []*event{button(mouse.ButtonLeft), buttonAt(mouse.ButtonRelease, image.Point{10, 1})},
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "Button_default.golden",
+		},
+		{
+			desc:  "release inside after a release outside doesn't press the button",
+			label: "ok",
+			events: []*event{
+				button(mouse.ButtonLeft),
+				buttonAt(mouse.ButtonRelease, image.Point{-1, 1}),
+				button(mouse.ButtonRelease),
+			},
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "Button_default.golden",
+		},
+		{
+			desc:   "other mouse buttons cancel the press",
+			label:  "ok",
+			events: []*event{button(mouse.ButtonLeft), button(mouse.ButtonRight), button(mouse.ButtonRelease)},
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "Button_default.golden",
+		},
+		{
+			desc:        "enter and space press the button",
+			label:       "ok",
+			focused:     true,
+			events:      []*event{key(keyboard.KeyEnter), key(keyboard.KeySpace), key('a')},
+			canvas:      image.Rect(0, 0, 8, 3),
+			wantPresses: 2,
+			golden:      "Button_pressed.golden",
+		},
+		{
+			desc:        "button pressed with the keyboard is released after the delay",
+			label:       "ok",
+			focused:     true,
+			events:      []*event{key(keyboard.KeyEnter)},
+			wait:        DefaultKeyUpDelay,
+			canvas:      image.Rect(0, 0, 8, 3),
+			wantPresses: 1,
+			golden:      "Button_focused.golden",
+		},
+		{
+			desc:  "custom colors",
+			label: "ok",
+			opts: []Option{
+				TextColor(cell.ColorWhite),
+				FillColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			golden: "Button_custom_colors.golden",
+		},
+		{
+			desc:    "theme colors",
+			label:   "ok",
+			theme:   theme.Dark(),
+			focused: true,
+			canvas:  image.Rect(0, 0, 4, 1),
+			golden:  "Button_theme.golden",
+		},
+		{
+			desc:    "colors in options take precedence over the theme",
+			label:   "ok",
+			opts:    []Option{FocusedFillColor(cell.ColorRed)},
+			theme:   theme.Dark(),
+			focused: true,
+			canvas:  image.Rect(0, 0, 4, 1),
+			golden:  "Button_theme_overridden.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotPresses int
+			b, err := New(tc.label, func() error {
+				gotPresses++
+				return nil
+			}, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+			b.now = func() time.Time { return now }
+			if tc.theme != nil {
+				b.SetTheme(tc.theme)
+			}
+			b.SetFocused(tc.focused)
+
+			// The widget learns the area of its canvas when drawn.
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := b.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				var err error
+				if ev.k != nil {
+					err = b.Keyboard(ev.k)
+				} else {
+					err = b.Mouse(ev.m)
+				}
+				if err != nil {
+					t.Fatalf("processing event %+v => unexpected error: %v", ev, err)
+				}
+			}
+			now = now.Add(tc.wait)
+			if gotPresses != tc.wantPresses {
+				t.Errorf("button pressed %d times, want %d", gotPresses, tc.wantPresses)
+			}
+
+			cvs, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := b.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCallbackError(t *testing.T) {
+	b, err := New("ok", func() error { return errors.New("pressed") })
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := b.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestCallbackCanAccessButton(t *testing.T) {
+	var b *Button
+	var presses int
+	b, err := New("ok", func() error {
+		b.SetFocused(true)
+		presses++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- b.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Keyboard => timed out, the callback deadlocked")
+	}
+
+	if want := 1; presses != want {
+		t.Errorf("button pressed %d times, want %d", presses, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	b, err := New("ok", func() error { return nil })
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := b.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary buttondemo displays a small control panel built from the Button,
+// CheckBox and RadioGroup widgets that controls a SparkLine.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/button"
+	"github.com/mum4k/termdash/widgets/checkbox"
+	"github.com/mum4k/termdash/widgets/radio"
+	"github.com/mum4k/termdash/widgets/sparkline"
+)
+
+// colors are the colors of the SparkLine the user can select.
+var colors = map[string]cell.Color{
+	"blue":  cell.ColorBlue,
+	"green": cell.ColorGreen,
+	"red":   cell.ColorRed,
+}
+
+// controls is the state of the control panel.
+type controls struct {
+	mu     sync.Mutex
+	paused bool
+	spikes bool
+	color  cell.Color
+}
+
+// play periodically adds random values to the SparkLine unless paused.
+// Exits when the context expires.
+func play(ctx context.Context, sl *sparkline.SparkLine, c *controls, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.mu.Lock()
+			paused, spikes, color := c.paused, c.spikes, c.color
+			c.mu.Unlock()
+			if paused {
+				continue
+			}
+
+			v := 10 + rand.Intn(10)
+			if spikes && rand.Intn(5) == 0 {
+				v += 30
+			}
+			if err := sl.Add([]int{v}, sparkline.Color(color)); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctrl := &controls{color: cell.ColorBlue}
+	sl := sparkline.New()
+
+	pause, err := button.New("Pause / Resume", func() error {
+		ctrl.mu.Lock()
+		defer ctrl.mu.Unlock()
+		ctrl.paused = !ctrl.paused
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	spikes, err := checkbox.New("Spikes", checkbox.OnToggle(func(checked bool) error {
+		ctrl.mu.Lock()
+		defer ctrl.mu.Unlock()
+		ctrl.spikes = checked
+		return nil
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	color, err := radio.New([]string{"blue", "green", "red"}, radio.OnChange(func(_ int, label string) error {
+		ctrl.mu.Lock()
+		defer ctrl.mu.Unlock()
+		ctrl.color = colors[label]
+		return nil
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go play(ctx, sl, ctrl, 250*time.Millisecond)
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS Q TO QUIT, CLICK A WIDGET TO FOCUS IT"),
+		container.SplitVertical(
+			container.Left(
+				container.SplitHorizontal(
+					container.Top(
+						container.Border(draw.LineStyleLight),
+						container.PlaceWidget(pause),
+					),
+					container.Bottom(
+						container.SplitHorizontal(
+							container.Top(
+								container.Border(draw.LineStyleLight),
+								container.BorderTitle("Options"),
+								container.PlaceWidget(spikes),
+							),
+							container.Bottom(
+								container.Border(draw.LineStyleLight),
+								container.BorderTitle("Color"),
+								container.PlaceWidget(color),
+							),
+						),
+					),
+				),
+			),
+			container.Right(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Requests"),
+				container.PlaceWidget(sl),
+			),
+			container.SplitPercent(30),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(100*time.Millisecond)); err != nil {
+		panic(err)
+	}
+}
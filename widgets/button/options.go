@@ -0,0 +1,136 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+// options.go contains configurable options for Button.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	textColor        cell.Color
+	fillColor        cell.Color
+	focusedFillColor cell.Color
+	pressedFillColor cell.Color
+	keyUpDelay       time.Duration
+
+	// Indicate that the colors were provided and take precedence over the
+	// theme.
+	fillColorSet        bool
+	focusedFillColorSet bool
+	pressedFillColorSet bool
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.keyUpDelay <= 0 {
+		return fmt.Errorf("invalid key up delay %v, must be a positive duration", o.keyUpDelay)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		textColor:        DefaultTextColor,
+		fillColor:        DefaultFillColor,
+		focusedFillColor: DefaultFocusedFillColor,
+		pressedFillColor: DefaultPressedFillColor,
+		keyUpDelay:       DefaultKeyUpDelay,
+	}
+}
+
+// DefaultTextColor is the default value for the TextColor option.
+const DefaultTextColor = cell.ColorBlack
+
+// TextColor sets the color of the label.
+func TextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.textColor = c
+	})
+}
+
+// DefaultFillColor is the default value for the FillColor option.
+var DefaultFillColor = cell.ColorNumber(117)
+
+// FillColor sets the color of the button. If not provided, the first series
+// color of the theme is used or DefaultFillColor if the container doesn't
+// have a theme.
+func FillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.fillColor = c
+		opts.fillColorSet = true
+	})
+}
+
+// DefaultFocusedFillColor is the default value for the FocusedFillColor
+// option.
+var DefaultFocusedFillColor = cell.ColorNumber(220)
+
+// FocusedFillColor sets the color of the button while its container has the
+// keyboard focus. If not provided, the color of the focused border of the
+// theme is used or DefaultFocusedFillColor if the container doesn't have a
+// theme.
+func FocusedFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.focusedFillColor = c
+		opts.focusedFillColorSet = true
+	})
+}
+
+// DefaultPressedFillColor is the default value for the PressedFillColor
+// option.
+var DefaultPressedFillColor = cell.ColorNumber(240)
+
+// PressedFillColor sets the color of the button while it is pressed. If not
+// provided, the color of the axes of the theme is used or
+// DefaultPressedFillColor if the container doesn't have a theme.
+func PressedFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.pressedFillColor = c
+		opts.pressedFillColorSet = true
+	})
+}
+
+// DefaultKeyUpDelay is the default value for the KeyUpDelay option.
+const DefaultKeyUpDelay = 250 * time.Millisecond
+
+// KeyUpDelay sets how long the button is displayed as pressed after it was
+// pressed using the keyboard. Terminals don't report the release of keys, so
+// the button is released when the delay expires.
+func KeyUpDelay(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.keyUpDelay = d
+	})
+}
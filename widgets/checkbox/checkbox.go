@@ -0,0 +1,252 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkbox is a widget that displays a check box the user can toggle
+// with the mouse or the keyboard.
+package checkbox
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"unicode"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The boxes displayed in front of the label.
+const (
+	boxChecked   = "[✓] "
+	boxUnchecked = "[ ] "
+)
+
+// CheckBox displays a check box with a label, e.g. to toggle a series on a
+// chart.
+//
+// The check box is toggled by a click of the left mouse button or by the
+// enter or the space key while its container has the keyboard focus. The
+// check box is highlighted while it is focused and while the mouse button is
+// held on it.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type CheckBox struct {
+	// label is the text displayed next to the box.
+	label string
+	// checked is the state of the check box.
+	checked bool
+
+	// pressed is true while the left mouse button is held on the check box.
+	pressed bool
+	// focused indicates whether the container of the check box has the
+	// keyboard focus.
+	focused bool
+	// area is the area of the canvas from the last call to Draw.
+	area image.Rectangle
+
+	// pending are the callbacks to call once mu is released, so that the
+	// callbacks can access the CheckBox.
+	pending []func() error
+
+	// mu protects the CheckBox.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new CheckBox with the label.
+func New(label string, opts ...Option) (*CheckBox, error) {
+	if err := validLabel(label); err != nil {
+		return nil, err
+	}
+
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	return &CheckBox{
+		label:   label,
+		checked: o.checked,
+		opts:    o,
+	}, nil
+}
+
+// IsChecked returns true if the check box is checked.
+func (cb *CheckBox) IsChecked() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.checked
+}
+
+// SetChecked sets the state of the check box.
+func (cb *CheckBox) SetChecked(checked bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.checked = checked
+}
+
+// unlockAndCall releases cb.mu and calls the pending callbacks in order.
+// Returns the error of the first callback that fails, the remaining callbacks
+// aren't called.
+// cb.mu must be held when calling this method.
+func (cb *CheckBox) unlockAndCall() error {
+	pending := cb.pending
+	cb.pending = nil
+	cb.mu.Unlock()
+
+	for _, call := range pending {
+		if err := call(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toggle inverts the state of the check box and schedules the OnToggle
+// callback.
+// cb.mu must be held when calling this method.
+func (cb *CheckBox) toggle() {
+	cb.checked = !cb.checked
+	if fn := cb.opts.onToggle; fn != nil {
+		checked := cb.checked
+		cb.pending = append(cb.pending, func() error { return fn(checked) })
+	}
+}
+
+// cellOpts returns the cell options of the check box in its current state.
+// The options are prefixed with the colors of the theme, so that the colors
+// in the options take precedence.
+// cb.mu must be held when calling this method.
+func (cb *CheckBox) cellOpts() []cell.Option {
+	var cOpts []cell.Option
+	if cb.theme != nil {
+		cOpts = append(cOpts, cell.FgColor(cb.theme.Label))
+	}
+	cOpts = append(cOpts, cb.opts.labelCellOpts...)
+
+	switch {
+	case cb.pressed:
+		bg := cb.opts.pressedColor
+		if cb.theme != nil && !cb.opts.pressedColorSet {
+			bg = cb.theme.Axis
+		}
+		cOpts = append(cOpts, cell.BgColor(bg))
+
+	case cb.focused:
+		bg := cb.opts.focusedColor
+		if cb.theme != nil && !cb.opts.focusedColorSet {
+			bg = cb.theme.FocusedBorder
+		}
+		cOpts = append(cOpts, cell.BgColor(bg))
+	}
+	return cOpts
+}
+
+// Draw draws the CheckBox widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (cb *CheckBox) Draw(cvs *canvas.Canvas) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.area = cvs.Area()
+	box := boxUnchecked
+	if cb.checked {
+		box = boxChecked
+	}
+	return draw.Text(cvs, box+cb.label, image.Point{0, 0},
+		draw.TextMaxX(cvs.Area().Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cb.cellOpts()...),
+	)
+}
+
+// Keyboard toggles the check box when the enter or the space key is pressed.
+// Implements widgetapi.Widget.Keyboard.
+func (cb *CheckBox) Keyboard(k *terminalapi.Keyboard) error {
+	cb.mu.Lock()
+	if k.Key == keyboard.KeyEnter || k.Key == keyboard.KeySpace {
+		cb.toggle()
+	}
+	return cb.unlockAndCall()
+}
+
+// Mouse toggles the check box when the left mouse button is released over it.
+// A release outside of the check box, which the container forwards to the
+// widget that received the press, doesn't toggle it.
+// Implements widgetapi.Widget.Mouse.
+func (cb *CheckBox) Mouse(m *terminalapi.Mouse) error {
+	cb.mu.Lock()
+	switch m.Button {
+	case mouse.ButtonLeft:
+		cb.pressed = true
+	case mouse.ButtonRelease:
+		if cb.pressed && m.Position.In(cb.area) {
+			cb.toggle()
+		}
+		cb.pressed = false
+	default:
+		cb.pressed = false
+	}
+	return cb.unlockAndCall()
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (cb *CheckBox) SetTheme(t *theme.Theme) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.theme = t
+}
+
+// SetFocused implements widgetapi.Focusable.SetFocused.
+func (cb *CheckBox) SetFocused(focused bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.focused = focused
+	if !focused {
+		cb.pressed = false
+	}
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*CheckBox) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+}
+
+// validLabel validates the label of the check box.
+func validLabel(label string) error {
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	for _, r := range label {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("the label %q cannot contain control characters, found: %q", label, r)
+		}
+	}
+	return nil
+}
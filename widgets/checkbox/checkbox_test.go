@@ -0,0 +1,314 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkbox
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		opts    []Option
+		want    bool
+		wantErr bool
+	}{
+		{
+			desc:  "unchecked by default",
+			label: "errors",
+		},
+		{
+			desc:  "initially checked",
+			label: "errors",
+			opts:  []Option{Checked()},
+			want:  true,
+		},
+		{
+			desc:    "fails on an empty label",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on control characters in the label",
+			label:   "a\nb",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cb, err := New(tc.label, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := cb.IsChecked(); got != tc.want {
+				t.Errorf("IsChecked => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// event is a keyboard or a mouse event processed by the widget.
+type event struct {
+	k *terminalapi.Keyboard
+	m *terminalapi.Mouse
+}
+
+// key returns a keyboard event.
+func key(k keyboard.Key) *event {
+	return &event{k: &terminalapi.Keyboard{Key: k}}
+}
+
+// button returns a mouse event with the button.
+func button(b mouse.Button) *event {
+	return &event{m: &terminalapi.Mouse{Position: image.Point{1, 0}, Button: b}}
+}
+
+// buttonAt returns a mouse event with the button at the position.
+func buttonAt(b mouse.Button, p image.Point) *event {
+	return &event{m: &terminalapi.Mouse{Position: p, Button: b}}
+}
+
+func TestCheckBox(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		opts    []Option
+		theme   *theme.Theme
+		focused bool
+		// update gets called before the events are processed.
+		update      func(*CheckBox)
+		events      []*event
+		canvas      image.Rectangle
+		wantChecked bool
+		// wantToggles are the states passed to the OnToggle callback.
+		wantToggles []bool
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "unchecked",
+			label:  "errors",
+			canvas: image.Rect(0, 0, 12, 1),
+			golden: "CheckBox_unchecked.golden",
+		},
+		{
+			desc:        "checked by calling SetChecked",
+			label:       "errors",
+			update:      func(cb *CheckBox) { cb.SetChecked(true) },
+			canvas:      image.Rect(0, 0, 12, 1),
+			wantChecked: true,
+			golden:      "CheckBox_checked.golden",
+		},
+		{
+			desc:   "trims a long label",
+			label:  "show errors",
+			canvas: image.Rect(0, 0, 8, 1),
+			golden: "CheckBox_trimmed.golden",
+		},
+		{
+			desc:        "enter and space toggle the check box",
+			label:       "errors",
+			focused:     true,
+			events:      []*event{key(keyboard.KeyEnter), key(keyboard.KeySpace), key(keyboard.KeySpace), key('a')},
+			canvas:      image.Rect(0, 0, 12, 1),
+			wantChecked: true,
+			wantToggles: []bool{true, false, true},
+			golden:      "CheckBox_focused.golden",
+		},
+		{
+			desc:   "held mouse button highlights the check box",
+			label:  "errors",
+			events: []*event{button(mouse.ButtonLeft)},
+			canvas: image.Rect(0, 0, 12, 1),
+			golden: "CheckBox_pressed.golden",
+		},
+		{
+			desc:        "release of the mouse button toggles the check box",
+			label:       "errors",
+			events:      []*event{button(mouse.ButtonLeft), button(mouse.ButtonRelease), button(mouse.ButtonRelease)},
+			canvas:      image.Rect(0, 0, 12, 1),
+			wantChecked: true,
+			wantToggles: []bool{true},
+			golden:      "CheckBox_checked.golden",
+		},
+		{
+			desc:  "release inside after a release outside doesn't toggle the check box",
+			label: "errors",
+			events: []*event{
+				button(mouse.ButtonLeft),
+				buttonAt(mouse.ButtonRelease, image.Point{1, 1}),
+				button(mouse.ButtonRelease),
+			},
+			canvas: image.Rect(0, 0, 12, 1),
+			golden: "CheckBox_unchecked.golden",
+		},
+		{
+			desc:   "other mouse buttons cancel the press",
+			label:  "errors",
+			events: []*event{button(mouse.ButtonLeft), button(mouse.ButtonWheelUp), button(mouse.ButtonRelease)},
+			canvas: image.Rect(0, 0, 12, 1),
+			golden: "CheckBox_unchecked.golden",
+		},
+		{
+			desc:  "custom colors",
+			label: "errors",
+			opts: []Option{
+				LabelCellOpts(cell.FgColor(cell.ColorRed)),
+				FocusedColor(cell.ColorYellow),
+			},
+			focused: true,
+			canvas:  image.Rect(0, 0, 12, 1),
+			golden:  "CheckBox_custom_colors.golden",
+		},
+		{
+			desc:    "theme colors",
+			label:   "errors",
+			theme:   theme.Dark(),
+			focused: true,
+			canvas:  image.Rect(0, 0, 12, 1),
+			golden:  "CheckBox_theme.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotToggles []bool
+			opts := append([]Option{
+				OnToggle(func(checked bool) error {
+					gotToggles = append(gotToggles, checked)
+					return nil
+				}),
+			}, tc.opts...)
+			cb, err := New(tc.label, opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				cb.SetTheme(tc.theme)
+			}
+			cb.SetFocused(tc.focused)
+			if tc.update != nil {
+				tc.update(cb)
+			}
+
+			// The widget learns the area of its canvas when drawn.
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := cb.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				var err error
+				if ev.k != nil {
+					err = cb.Keyboard(ev.k)
+				} else {
+					err = cb.Mouse(ev.m)
+				}
+				if err != nil {
+					t.Fatalf("processing event %+v => unexpected error: %v", ev, err)
+				}
+			}
+			if got := cb.IsChecked(); got != tc.wantChecked {
+				t.Errorf("IsChecked => %v, want %v", got, tc.wantChecked)
+			}
+			if diff := pretty.Compare(tc.wantToggles, gotToggles); diff != "" {
+				t.Errorf("OnToggle => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			cvs, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := cb.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCallbackCanAccessCheckBox(t *testing.T) {
+	var cb *CheckBox
+	var got []bool
+	cb, err := New("errors", OnToggle(func(bool) error {
+		got = append(got, cb.IsChecked())
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- cb.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Keyboard => timed out, the callback deadlocked")
+	}
+
+	if diff := pretty.Compare([]bool{true}, got); diff != "" {
+		t.Errorf("OnToggle => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	cb, err := New("errors")
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := cb.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkbox
+
+// options.go contains configurable options for CheckBox.
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	checked       bool
+	onToggle      ToggleFn
+	labelCellOpts []cell.Option
+	focusedColor  cell.Color
+	pressedColor  cell.Color
+
+	// Indicate that the colors were provided and take precedence over the
+	// theme.
+	focusedColorSet bool
+	pressedColorSet bool
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		focusedColor: DefaultFocusedColor,
+		pressedColor: DefaultPressedColor,
+	}
+}
+
+// Checked makes the check box initially checked.
+func Checked() Option {
+	return option(func(opts *options) {
+		opts.checked = true
+	})
+}
+
+// ToggleFn is the function called when the user checks or unchecks the check
+// box with its new state.
+//
+// The callback function must be light-weight, ideally just storing a value and
+// returning, since more events might occur. The callback function must be
+// thread-safe as the mouse or keyboard events are processed in a separate
+// goroutine. The function is called after the widget released its lock, so it
+// can call the methods of the widget.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type ToggleFn func(checked bool) error
+
+// OnToggle sets a function that is called when the user checks or unchecks
+// the check box. Isn't called when the state is changed by calling
+// SetChecked.
+func OnToggle(fn ToggleFn) Option {
+	return option(func(opts *options) {
+		opts.onToggle = fn
+	})
+}
+
+// LabelCellOpts sets the cell options of the check box and its label.
+func LabelCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.labelCellOpts = cOpts
+	})
+}
+
+// DefaultFocusedColor is the default value for the FocusedColor option.
+const DefaultFocusedColor = cell.ColorBlue
+
+// FocusedColor sets the background color of the check box while its
+// container has the keyboard focus. If not provided, the color of the focused
+// border of the theme is used or DefaultFocusedColor if the container doesn't
+// have a theme.
+func FocusedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.focusedColor = c
+		opts.focusedColorSet = true
+	})
+}
+
+// DefaultPressedColor is the default value for the PressedColor option.
+var DefaultPressedColor = cell.ColorNumber(240)
+
+// PressedColor sets the background color of the check box while the mouse
+// button is held on it. If not provided, the color of the axes of the theme
+// is used or DefaultPressedColor if the container doesn't have a theme.
+func PressedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.pressedColor = c
+		opts.pressedColorSet = true
+	})
+}
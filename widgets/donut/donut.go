@@ -26,9 +26,11 @@ import (
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/canvas"
 	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/numbers"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -73,6 +75,9 @@ type Donut struct {
 
 	// opts are the provided options.
 	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
 }
 
 // New returns a new Donut.
@@ -165,7 +170,7 @@ func (d *Donut) holeRadius(donutRadius int) int {
 // The text is only drawn if the radius of the donut "hole" is large enough to
 // accommodate it.
 func (d *Donut) drawText(cvs *canvas.Canvas, mid image.Point, holeR int) error {
-	cells, first := availableCells(mid, holeR)
+	cells, first := draw.BrailleCircleTextCells(mid, holeR)
 	t := d.progressText()
 	needCells := runewidth.StringWidth(t)
 	if cells < needCells {
@@ -177,12 +182,34 @@ func (d *Donut) drawText(cvs *canvas.Canvas, mid image.Point, holeR int) error {
 	if err != nil {
 		return fmt.Errorf("align.Text => %v", err)
 	}
-	if err := draw.Text(cvs, t, start, draw.TextMaxX(start.X+needCells), draw.TextCellOpts(d.opts.textCellOpts...)); err != nil {
+	if err := draw.Text(cvs, t, start, draw.TextMaxX(start.X+needCells), draw.TextCellOpts(d.themedCellOpts(d.opts.textCellOpts, true)...)); err != nil {
 		return fmt.Errorf("draw.Text => %v", err)
 	}
 	return nil
 }
 
+// themedCellOpts returns the cell options prefixed with the color from the
+// theme, so that colors in the options take precedence. Uses the label color
+// if label is true, otherwise the first series color.
+// d.mu must be held when calling this method.
+func (d *Donut) themedCellOpts(cOpts []cell.Option, label bool) []cell.Option {
+	if d.theme == nil {
+		return cOpts
+	}
+	c := d.theme.SeriesColor(0)
+	if label {
+		c = d.theme.Label
+	}
+	return append([]cell.Option{cell.FgColor(c)}, cOpts...)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (d *Donut) SetTheme(t *theme.Theme) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.theme = t
+}
+
 // Draw draws the Donut widget onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (d *Donut) Draw(cvs *canvas.Canvas) error {
@@ -200,11 +227,11 @@ func (d *Donut) Draw(cvs *canvas.Canvas) error {
 		return nil
 	}
 
-	mid, r := midAndRadius(bc.Area())
+	mid, r := draw.BrailleCircleMidAndRadius(bc.Area())
 	if err := draw.BrailleCircle(bc, mid, r,
 		draw.BrailleCircleFilled(),
 		draw.BrailleCircleArcOnly(startA, endA),
-		draw.BrailleCircleCellOpts(d.opts.cellOpts...),
+		draw.BrailleCircleCellOpts(d.themedCellOpts(d.opts.cellOpts, false)...),
 	); err != nil {
 		return fmt.Errorf("failed to draw the outer circle: %v", err)
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/mum4k/termdash/draw/testdraw"
 	"github.com/mum4k/termdash/terminal/faketerm"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -343,6 +344,39 @@ func TestDonut(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "uses colors from the theme",
+			canvas: image.Rect(0, 0, 7, 7),
+			update: func(d *Donut) error {
+				d.SetTheme(&theme.Theme{
+					Label:  cell.ColorBlue,
+					Series: []cell.Color{cell.ColorMagenta},
+				})
+				return d.Percent(100, HolePercent(80))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(c.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 6,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 5,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testdraw.MustText(c, "100%", image.Point{2, 3}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "sets text cell options",
 			canvas: image.Rect(0, 0, 7, 7),
@@ -70,10 +70,6 @@ func newOptions() *options {
 		donutHolePercent: DefaultHolePercent,
 		startAngle:       DefaultStartAngle,
 		direction:        -1,
-		textCellOpts: []cell.Option{
-			cell.FgColor(cell.ColorDefault),
-			cell.BgColor(cell.ColorDefault),
-		},
 	}
 }
 
@@ -116,6 +112,8 @@ func HideTextProgress() Option {
 
 // TextCellOpts sets cell options on cells that contain the displayed text
 // progress.
+// The text uses the label color of the theme set on the container unless the
+// cell options specify another color.
 func TextCellOpts(cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.textCellOpts = cOpts
@@ -123,6 +121,8 @@ func TextCellOpts(cOpts ...cell.Option) Option {
 }
 
 // CellOpts sets cell options on cells that contain the donut.
+// The donut uses the first series color of the theme set on the container
+// unless the cell options specify another color.
 func CellOpts(cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.cellOpts = cOpts
@@ -29,6 +29,7 @@ import (
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -57,7 +58,8 @@ const (
 // Gauge displays the progress of an operation.
 //
 // Draws a rectangle, a progress bar with optional display of percentage and /
-// or text label.
+// or text label. The progress bar fills horizontally or vertically, either
+// continuously or in discrete segments.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type Gauge struct {
@@ -74,6 +76,9 @@ type Gauge struct {
 
 	// opts are the provided options.
 	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
 }
 
 // New returns a new Gauge.
@@ -131,12 +136,66 @@ func (g *Gauge) Percent(p int, opts ...Option) error {
 	return nil
 }
 
-// width determines the required width of the gauge drawn on the provided area
-// in order to represent the current progress.
-func (g *Gauge) width(ar image.Rectangle) int {
+// filledLength determines the number of cells out of the provided length that
+// need to be filled in order to represent the current progress.
+func (g *Gauge) filledLength(length int) int {
 	mult := float32(g.current) / float32(g.total)
-	width := float32(ar.Dx()) * mult
-	return int(width)
+	filled := float32(length) * mult
+	return int(filled)
+}
+
+// segment returns the area of the part of the gauge that starts after the
+// specified number of cells in the direction the gauge fills and has the
+// specified length.
+func (g *Gauge) segment(usable image.Rectangle, start, length int) image.Rectangle {
+	if g.opts.vertical {
+		return image.Rect(usable.Min.X, usable.Max.Y-start-length, usable.Max.X, usable.Max.Y-start)
+	}
+	return image.Rect(usable.Min.X+start, usable.Min.Y, usable.Min.X+start+length, usable.Max.Y)
+}
+
+// filledAreas returns the areas of the usable area that need to be filled in
+// order to represent the current progress.
+func (g *Gauge) filledAreas(usable image.Rectangle) []image.Rectangle {
+	length := usable.Dx()
+	if g.opts.vertical {
+		length = usable.Dy()
+	}
+	if g.total == 0 || length <= 0 {
+		return nil
+	}
+
+	if !g.opts.segmented {
+		filled := g.filledLength(length)
+		if filled <= 0 {
+			return nil
+		}
+		return []image.Rectangle{g.segment(usable, 0, filled)}
+	}
+
+	step := g.opts.segmentLength + g.opts.segmentGap
+	// The last segment can be shorter if it doesn't fit.
+	count := (length + step - 1) / step
+	var areas []image.Rectangle
+	for i := 0; i < g.filledLength(count); i++ {
+		start := i * step
+		segLen := g.opts.segmentLength
+		if rem := length - start; segLen > rem {
+			segLen = rem
+		}
+		areas = append(areas, g.segment(usable, start, segLen))
+	}
+	return areas
+}
+
+// inAreas asserts whether the point falls into any of the areas.
+func inAreas(p image.Point, areas []image.Rectangle) bool {
+	for _, ar := range areas {
+		if p.In(ar) {
+			return true
+		}
+	}
+	return false
 }
 
 // hasBorder determines of the gauge has a border.
@@ -183,7 +242,8 @@ func (g *Gauge) gaugeText() string {
 }
 
 // drawText draws the text enumerating the progress and the text label.
-func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
+// The filled are the areas of the gauge that represent the progress.
+func (g *Gauge) drawText(cvs *canvas.Canvas, filled []image.Rectangle) error {
 	text := g.gaugeText()
 	if text == "" {
 		return nil
@@ -210,7 +270,7 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 		// If the current rune is full-width and only one of its cells falls
 		// within the filled area of the gauge, extend the gauge by one cell to
 		// fully cover the full-width rune.
-		if rw == 2 && next.In(ar) && cur.In(progress) && !next.In(progress) {
+		if rw == 2 && next.In(ar) && inAreas(cur, filled) && !inAreas(next, filled) {
 			fixup := image.Rect(
 				next.X,
 				ar.Min.Y,
@@ -219,15 +279,14 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 			)
 			if err := draw.Rectangle(cvs, fixup,
 				draw.RectChar(g.opts.gaugeChar),
-				draw.RectCellOpts(cell.BgColor(g.opts.color)),
+				draw.RectCellOpts(cell.BgColor(g.fillColor())),
 			); err != nil {
 				return err
 			}
-
 		}
 
 		var cellOpts []cell.Option
-		if cur.In(progress) {
+		if inAreas(cur, filled) {
 			cellOpts = append(cellOpts, cell.FgColor(g.opts.filledTextColor))
 		} else {
 			cellOpts = append(cellOpts, cell.FgColor(g.opts.emptyTextColor))
@@ -252,30 +311,69 @@ func (g *Gauge) Draw(cvs *canvas.Canvas) error {
 	if g.hasBorder() {
 		if err := draw.Border(cvs, cvs.Area(),
 			draw.BorderLineStyle(g.opts.border),
-			draw.BorderTitle(g.opts.borderTitle, draw.OverrunModeThreeDot, g.opts.borderCellOpts...),
+			draw.BorderTitle(g.opts.borderTitle, draw.OverrunModeThreeDot, g.borderCellOpts(true)...),
 			draw.BorderTitleAlign(g.opts.borderTitleHAlign),
-			draw.BorderCellOpts(g.opts.borderCellOpts...),
+			draw.BorderCellOpts(g.borderCellOpts(false)...),
 		); err != nil {
 			return err
 		}
 	}
 
-	usable := g.usable(cvs)
-	progress := image.Rect(
-		usable.Min.X,
-		usable.Min.Y,
-		usable.Min.X+g.width(usable),
-		usable.Max.Y,
-	)
-	if progress.Dx() > 0 {
-		if err := draw.Rectangle(cvs, progress,
+	filled := g.filledAreas(g.usable(cvs))
+	for _, ar := range filled {
+		if err := draw.Rectangle(cvs, ar,
 			draw.RectChar(g.opts.gaugeChar),
-			draw.RectCellOpts(cell.BgColor(g.opts.color)),
+			draw.RectCellOpts(cell.BgColor(g.fillColor())),
 		); err != nil {
 			return err
 		}
 	}
-	return g.drawText(cvs, progress)
+	return g.drawText(cvs, filled)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (g *Gauge) SetTheme(t *theme.Theme) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.theme = t
+}
+
+// color returns the color of the gauge.
+// g.mu must be held when calling this method.
+func (g *Gauge) color() cell.Color {
+	if !g.opts.colorSet && g.theme != nil {
+		return g.theme.SeriesColor(0)
+	}
+	return g.opts.color
+}
+
+// fillColor returns the color of the filled part of the gauge, which depends
+// on the color stops the current progress reaches.
+// g.mu must be held when calling this method.
+func (g *Gauge) fillColor() cell.Color {
+	if g.total == 0 {
+		return g.color()
+	}
+	percent := float64(g.current) / float64(g.total) * 100
+	if c, ok := g.opts.colorStops.Color(percent); ok {
+		return c
+	}
+	return g.color()
+}
+
+// borderCellOpts returns the cell options of the border or of the border
+// title if title is true. The provided options are prefixed with the color
+// from the theme, so that colors in the options take precedence.
+// g.mu must be held when calling this method.
+func (g *Gauge) borderCellOpts(title bool) []cell.Option {
+	if g.theme == nil {
+		return g.opts.borderCellOpts
+	}
+	c := g.theme.Border
+	if title {
+		c = g.theme.Title
+	}
+	return append([]cell.Option{cell.FgColor(c)}, g.opts.borderCellOpts...)
 }
 
 // Keyboard input isn't supported on the Gauge widget.
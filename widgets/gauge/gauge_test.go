@@ -26,6 +26,7 @@ import (
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/draw/testdraw"
 	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -50,6 +51,7 @@ func TestGauge(t *testing.T) {
 		absolute      *absoluteCall // if set the test case calls Gauge.Absolute().
 		canvas        image.Rectangle
 		opts          []Option
+		theme         *theme.Theme // if set, the test case calls Gauge.SetTheme().
 		want          func(size image.Point) *faketerm.Terminal
 		wantUpdateErr bool // whether to expect an error on a call to Gauge.Percent() or Gauge.Absolute().
 		wantDrawErr   bool
@@ -74,6 +76,74 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "uses colors from the theme",
+			gauge: New(
+				Char('o'),
+				Border(draw.LineStyleLight),
+				BorderTitle("t"),
+			),
+			theme: &theme.Theme{
+				Border: cell.ColorRed,
+				Title:  cell.ColorBlue,
+				Series: []cell.Color{cell.ColorMagenta},
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, c.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+					draw.BorderTitle("t", draw.OverrunModeThreeDot, cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 1, 5, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustText(c, "50", image.Point{3, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlack),
+					cell.BgColor(cell.ColorMagenta),
+				))
+				testdraw.MustText(c, "%", image.Point{5, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "explicit colors take precedence over the theme",
+			gauge: New(
+				Char('o'),
+				Color(cell.ColorCyan),
+				Border(draw.LineStyleLight, cell.FgColor(cell.ColorGreen)),
+			),
+			theme: &theme.Theme{
+				Border: cell.ColorRed,
+				Series: []cell.Color{cell.ColorMagenta},
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, c.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 1, 5, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorCyan)),
+				)
+				testdraw.MustText(c, "50", image.Point{3, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlack),
+					cell.BgColor(cell.ColorCyan),
+				))
+				testdraw.MustText(c, "%", image.Point{5, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "aligns the progress text top and left",
 			gauge: New(
@@ -712,6 +782,228 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "uses the color of the largest reached color stop",
+			gauge: New(
+				Char('o'),
+				ColorStop(90, cell.ColorRed),
+				ColorStop(70, cell.ColorYellow),
+			),
+			percent: &percentCall{p: 75},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 7, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testdraw.MustText(c, "75%", image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "color stop applies from its percentage",
+			gauge: New(
+				Char('o'),
+				ColorStop(70, cell.ColorYellow),
+				ColorStop(90, cell.ColorRed),
+				HideTextProgress(),
+			),
+			absolute: &absoluteCall{done: 9, total: 10},
+			canvas:   image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 9, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "uses the color below all color stops",
+			gauge: New(
+				Char('o'),
+				Color(cell.ColorBlue),
+				ColorStop(70, cell.ColorYellow),
+				HideTextProgress(),
+			),
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 5, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "vertical gauge fills from the bottom",
+			gauge: New(
+				Char('o'),
+				Vertical(),
+			),
+			percent: &percentCall{p: 70},
+			canvas:  image.Rect(0, 0, 4, 6),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 2, 4, 6),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "70%", image.Point{0, 2},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "vertical gauge with border",
+			gauge: New(
+				Char('o'),
+				Vertical(),
+				Border(draw.LineStyleLight),
+			),
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 5, 6),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, image.Rect(0, 0, 5, 6))
+				testdraw.MustRectangle(c, image.Rect(1, 3, 4, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "50%", image.Point{1, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented gauge fills whole segments",
+			gauge: New(
+				Char('o'),
+				Segmented(2, 1),
+				HideTextProgress(),
+			),
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, ar := range []image.Rectangle{
+					image.Rect(0, 0, 2, 1),
+					image.Rect(3, 0, 5, 1),
+				} {
+					testdraw.MustRectangle(c, ar,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented gauge shortens the last segment",
+			gauge: New(
+				Char('o'),
+				Segmented(2, 1),
+				HideTextProgress(),
+			),
+			percent: &percentCall{p: 100},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, ar := range []image.Rectangle{
+					image.Rect(0, 0, 2, 1),
+					image.Rect(3, 0, 5, 1),
+					image.Rect(6, 0, 8, 1),
+					image.Rect(9, 0, 10, 1),
+				} {
+					testdraw.MustRectangle(c, ar,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented vertical gauge",
+			gauge: New(
+				Char('o'),
+				Vertical(),
+				Segmented(1, 1),
+				HideTextProgress(),
+			),
+			percent: &percentCall{p: 100},
+			canvas:  image.Rect(0, 0, 2, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, y := range []int{0, 2, 4} {
+					testdraw.MustRectangle(c, image.Rect(0, y, 2, y+1),
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "text in the gaps of a segmented gauge uses EmptyTextColor",
+			gauge: New(
+				Char('o'),
+				Segmented(1, 1),
+			),
+			percent: &percentCall{p: 100},
+			canvas:  image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, x := range []int{0, 2, 4} {
+					testdraw.MustRectangle(c, image.Rect(x, 0, x+1, 1),
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testdraw.MustText(c, "1", image.Point{1, 0})
+				testdraw.MustText(c, "0", image.Point{2, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "0", image.Point{3, 0})
+				testdraw.MustText(c, "%", image.Point{4, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -742,6 +1034,9 @@ func TestGauge(t *testing.T) {
 
 			}
 
+			if tc.theme != nil {
+				tc.gauge.SetTheme(tc.theme)
+			}
 			err = tc.gauge.Draw(c)
 			if (err != nil) != tc.wantDrawErr {
 				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
@@ -20,6 +20,7 @@ import (
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/threshold"
 )
 
 // Option is used to provide options.
@@ -37,6 +38,7 @@ type options struct {
 	hTextAlign       align.Horizontal
 	vTextAlign       align.Vertical
 	color            cell.Color
+	colorSet         bool
 	filledTextColor  cell.Color
 	emptyTextColor   cell.Color
 	// If set, draws a border around the gauge.
@@ -44,6 +46,12 @@ type options struct {
 	borderCellOpts    []cell.Option
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
+
+	colorStops    threshold.Colors
+	vertical      bool
+	segmented     bool
+	segmentLength int
+	segmentGap    int
 }
 
 // newOptions returns options with the default values set.
@@ -116,9 +124,12 @@ func TextLabel(text string) Option {
 const DefaultColor = cell.ColorGreen
 
 // Color sets the color of the gauge.
+// If not set, the gauge uses the first series color of the theme set on its
+// container or DefaultColor if there is no theme.
 func Color(c cell.Color) Option {
 	return option(func(opts *options) {
 		opts.color = c
+		opts.colorSet = true
 	})
 }
 
@@ -169,6 +180,8 @@ func VerticalTextAlign(v align.Vertical) Option {
 }
 
 // Border configures the gauge to have a border of the specified style.
+// The border and its title use the colors of the theme set on the container
+// unless the cell options specify other colors.
 func Border(ls draw.LineStyle, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.border = ls
@@ -190,3 +203,50 @@ func BorderTitleAlign(h align.Horizontal) Option {
 		opts.borderTitleHAlign = h
 	})
 }
+
+// ColorStop sets the color of the gauge when the progress reaches the
+// specified percentage, e.g. yellow from 70% and red from 90%. Can be
+// specified multiple times, see threshold.Colors for how the color of the
+// gauge is chosen. Below all the stops, the gauge has the color set by the
+// Color option.
+func ColorStop(percent int, c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.colorStops.Set(float64(percent), c)
+	})
+}
+
+// Horizontal configures the gauge to fill from the left to the right. This is
+// the default orientation.
+func Horizontal() Option {
+	return option(func(opts *options) {
+		opts.vertical = false
+	})
+}
+
+// Vertical configures the gauge to fill from the bottom to the top, e.g. to
+// draw thermometer style columns.
+func Vertical() Option {
+	return option(func(opts *options) {
+		opts.vertical = true
+	})
+}
+
+// Segmented configures the gauge to draw the progress as discrete segments
+// separated by gaps, like a LED bar. The length is the size of each segment
+// in cells in the direction the gauge fills and the gap is the number of
+// empty cells between the segments. Only whole segments are filled.
+// The length must be at least one and the gap zero or positive, other values
+// are adjusted to the nearest valid value.
+func Segmented(length, gap int) Option {
+	return option(func(opts *options) {
+		if length < 1 {
+			length = 1
+		}
+		if gap < 0 {
+			gap = 0
+		}
+		opts.segmented = true
+		opts.segmentLength = length
+		opts.segmentGap = gap
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+// gradient.go maps values onto the colors of a gradient.
+
+import (
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/numbers"
+)
+
+// fraction returns the position of the value within the range as a number in
+// the range 0 <= f <= 1. Values outside of the range are clamped.
+func fraction(v, min, max float64) float64 {
+	if max <= min {
+		return 0
+	}
+	f := (v - min) / (max - min)
+	return math.Max(0, math.Min(1, f))
+}
+
+// gradientColor returns the color at the position f within the gradient,
+// where f is in range 0 <= f <= 1.
+func gradientColor(gradient []cell.Color, f float64) cell.Color {
+	last := len(gradient) - 1
+	if last == 0 {
+		return gradient[0]
+	}
+
+	pos := f * float64(last)
+	if !allRGB24(gradient) {
+		return gradient[int(numbers.Round(pos))]
+	}
+
+	i := int(pos)
+	if i >= last {
+		return gradient[last]
+	}
+	return blend(gradient[i], gradient[i+1], pos-float64(i))
+}
+
+// allRGB24 asserts whether all the colors were created by cell.ColorRGB24.
+func allRGB24(colors []cell.Color) bool {
+	for _, c := range colors {
+		if _, _, _, ok := c.RGB24(); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// blend returns the color between the two RGB24 colors, t is the distance
+// from the first color in range 0 <= t <= 1.
+func blend(from, to cell.Color, t float64) cell.Color {
+	fr, fg, fb, _ := from.RGB24()
+	tr, tg, tb, _ := to.RGB24()
+	mix := func(a, b int) int {
+		return int(numbers.Round(float64(a) + t*float64(b-a)))
+	}
+	return cell.ColorRGB24(mix(fr, tr), mix(fg, tg), mix(fb, tb))
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestFraction(t *testing.T) {
+	tests := []struct {
+		desc     string
+		v        float64
+		min, max float64
+		want     float64
+	}{
+		{desc: "empty range", v: 5, min: 5, max: 5, want: 0},
+		{desc: "minimum", v: 0, min: 0, max: 10, want: 0},
+		{desc: "maximum", v: 10, min: 0, max: 10, want: 1},
+		{desc: "middle", v: 0, min: -5, max: 5, want: 0.5},
+		{desc: "clamps values below the range", v: -1, min: 0, max: 10, want: 0},
+		{desc: "clamps values above the range", v: 11, min: 0, max: 10, want: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fraction(tc.v, tc.min, tc.max); got != tc.want {
+				t.Errorf("fraction(%v, %v, %v) => %v, want %v", tc.v, tc.min, tc.max, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGradientColor(t *testing.T) {
+	tests := []struct {
+		desc     string
+		gradient []cell.Color
+		f        float64
+		want     cell.Color
+	}{
+		{
+			desc:     "single color",
+			gradient: []cell.Color{cell.ColorRed},
+			f:        0.7,
+			want:     cell.ColorRed,
+		},
+		{
+			desc:     "first color",
+			gradient: []cell.Color{cell.ColorBlue, cell.ColorGreen, cell.ColorRed},
+			f:        0,
+			want:     cell.ColorBlue,
+		},
+		{
+			desc:     "last color",
+			gradient: []cell.Color{cell.ColorBlue, cell.ColorGreen, cell.ColorRed},
+			f:        1,
+			want:     cell.ColorRed,
+		},
+		{
+			desc:     "nearest color",
+			gradient: []cell.Color{cell.ColorBlue, cell.ColorGreen, cell.ColorRed},
+			f:        0.3,
+			want:     cell.ColorGreen,
+		},
+		{
+			desc:     "interpolates RGB24 colors",
+			gradient: []cell.Color{cell.ColorRGB24(0, 0, 0), cell.ColorRGB24(200, 100, 0)},
+			f:        0.5,
+			want:     cell.ColorRGB24(100, 50, 0),
+		},
+		{
+			desc:     "interpolates between the surrounding RGB24 colors",
+			gradient: []cell.Color{cell.ColorRGB24(0, 0, 0), cell.ColorRGB24(0, 0, 100), cell.ColorRGB24(100, 0, 100)},
+			f:        0.75,
+			want:     cell.ColorRGB24(50, 0, 100),
+		},
+		{
+			desc:     "last RGB24 color",
+			gradient: []cell.Color{cell.ColorRGB24(0, 0, 0), cell.ColorRGB24(0, 0, 100)},
+			f:        1,
+			want:     cell.ColorRGB24(0, 0, 100),
+		},
+		{
+			desc:     "doesn't interpolate mixed colors",
+			gradient: []cell.Color{cell.ColorRGB24(0, 0, 0), cell.ColorRed},
+			f:        0.4,
+			want:     cell.ColorRGB24(0, 0, 0),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := gradientColor(tc.gradient, tc.f); got != tc.want {
+				t.Errorf("gradientColor => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,482 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package heatmap is a widget that displays a matrix of values as colored
+// cells.
+package heatmap
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"sync"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// HeatMap displays a matrix of values as cells whose background color
+// represents the value, e.g. latency histograms over time or error counts per
+// host and minute. Labels of the columns are displayed under the cells and
+// labels of the rows on their left. A legend under the heat map shows the
+// color scale.
+//
+// Terminals only report the position of the mouse while a button is pressed,
+// so values are inspected by clicking or dragging over the cells. The
+// inspected cell is marked and its labels and value replace the color scale
+// in the legend. A click outside of the cells ends the inspection.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type HeatMap struct {
+	// values are the displayed values, values[y][x] is displayed in the row y
+	// and the column x. NaN values represent missing data.
+	values [][]float64
+	// xLabels and yLabels are the labels of the columns and the rows.
+	xLabels []string
+	yLabels []string
+
+	// inspected is the inspected cell, valid only if isInspected is true.
+	inspected   image.Point
+	isInspected bool
+
+	// The layout of the last drawn heat map, used to process mouse events.
+	// gridAr is the area on the canvas where the cells are drawn.
+	gridAr image.Rectangle
+	// cellSize is the size of the area that displays a single value.
+	cellSize image.Point
+
+	// mu protects the HeatMap.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new HeatMap.
+func New(opts ...Option) (*HeatMap, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &HeatMap{
+		opts: opt,
+	}, nil
+}
+
+// Values sets the values to display, replacing any previously provided
+// values. The values are organized in rows, values[0] is the top row and all
+// the rows must have the same length. NaN values represent missing data and
+// are displayed as empty cells.
+// The labels are optional, if provided there must be one label per column in
+// xLabels and one label per row in yLabels.
+func (hm *HeatMap) Values(xLabels, yLabels []string, values [][]float64) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	var cols int
+	if len(values) > 0 {
+		cols = len(values[0])
+	}
+	for y, row := range values {
+		if len(row) != cols {
+			return fmt.Errorf("row %d has %d values, all the rows must have the same length as the first row (%d)", y, len(row), cols)
+		}
+		for x, v := range row {
+			if math.IsInf(v, 0) {
+				return fmt.Errorf("invalid value %v in row %d column %d, must be a finite number or NaN", v, y, x)
+			}
+		}
+	}
+	if len(xLabels) > 0 && len(xLabels) != cols {
+		return fmt.Errorf("got %d labels for %d columns, the X labels must be empty or have one label per column", len(xLabels), cols)
+	}
+	if len(yLabels) > 0 && len(yLabels) != len(values) {
+		return fmt.Errorf("got %d labels for %d rows, the Y labels must be empty or have one label per row", len(yLabels), len(values))
+	}
+
+	hm.values = values
+	hm.xLabels = xLabels
+	hm.yLabels = yLabels
+	if hm.isInspected && !hm.inBounds(hm.inspected) {
+		hm.isInspected = false
+	}
+	return nil
+}
+
+// ClearValues removes all the values and labels.
+func (hm *HeatMap) ClearValues() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.values = nil
+	hm.xLabels = nil
+	hm.yLabels = nil
+	hm.isInspected = false
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (hm *HeatMap) SetTheme(t *theme.Theme) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.theme = t
+}
+
+// inBounds asserts whether the point identifies one of the values.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) inBounds(p image.Point) bool {
+	return p.Y >= 0 && p.Y < len(hm.values) && p.X >= 0 && p.X < len(hm.values[p.Y])
+}
+
+// columns returns the number of columns.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) columns() int {
+	if len(hm.values) == 0 {
+		return 0
+	}
+	return len(hm.values[0])
+}
+
+// valueRange returns the values represented by the first and the last color
+// of the gradient.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) valueRange() (min, max float64) {
+	if hm.opts.rangeSet {
+		return hm.opts.min, hm.opts.max
+	}
+
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, row := range hm.values {
+		for _, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	if min > max { // No values.
+		return 0, 0
+	}
+	return min, max
+}
+
+// labelCellOpts returns the cell options of the labels prefixed with the
+// label color of the theme, so that colors in the options take precedence.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) labelCellOpts() []cell.Option {
+	if hm.theme == nil {
+		return hm.opts.labelCellOpts
+	}
+	return append([]cell.Option{cell.FgColor(hm.theme.Label)}, hm.opts.labelCellOpts...)
+}
+
+// valueText formats the value.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) valueText(v float64) string {
+	if math.IsNaN(v) {
+		return "no data"
+	}
+	if hm.opts.valueFormat != "" {
+		return fmt.Sprintf(hm.opts.valueFormat, v)
+	}
+	rounded, _ := numbers.RoundToNonZeroPlaces(v, 2)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// label returns the label at the index or the index itself if there are no
+// labels.
+func label(labels []string, i int) string {
+	if len(labels) == 0 {
+		return strconv.Itoa(i)
+	}
+	return labels[i]
+}
+
+// yLabelsWidth returns the width of the column with the Y labels including
+// the space between the labels and the cells.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) yLabelsWidth() int {
+	var widest int
+	for _, l := range hm.yLabels {
+		if w := runewidth.StringWidth(l); w > widest {
+			widest = w
+		}
+	}
+	if widest == 0 {
+		return 0
+	}
+	return widest + 1
+}
+
+// layout calculates the area where the cells are drawn and the size of each
+// cell. Rows and columns are stretched to fill the available space, each of
+// them is at least one cell wide and high.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) layout(cvsAr image.Rectangle) (gridAr image.Rectangle, cellSize image.Point) {
+	bottom := 0
+	if len(hm.xLabels) > 0 {
+		bottom++
+	}
+	if !hm.opts.hideLegend {
+		bottom++
+	}
+	gridAr = image.Rect(
+		cvsAr.Min.X+hm.yLabelsWidth(), cvsAr.Min.Y,
+		cvsAr.Max.X, cvsAr.Max.Y-bottom,
+	)
+	if gridAr.Dx() < 1 || gridAr.Dy() < 1 || len(hm.values) == 0 || hm.columns() == 0 {
+		return image.ZR, image.ZP
+	}
+
+	cellSize = image.Point{gridAr.Dx() / hm.columns(), gridAr.Dy() / len(hm.values)}
+	if cellSize.X < 1 {
+		cellSize.X = 1
+	}
+	if cellSize.Y < 1 {
+		cellSize.Y = 1
+	}
+	return gridAr, cellSize
+}
+
+// cellArea returns the area on the canvas where the value at the point is
+// drawn. The area is empty if the value doesn't fit onto the canvas.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) cellArea(p image.Point) image.Rectangle {
+	min := hm.gridAr.Min.Add(image.Point{p.X * hm.cellSize.X, p.Y * hm.cellSize.Y})
+	return image.Rectangle{min, min.Add(hm.cellSize)}.Intersect(hm.gridAr)
+}
+
+// Draw draws the HeatMap widget onto the canvas.
+// Rows and columns that don't fit onto the canvas are omitted.
+// Implements widgetapi.Widget.Draw.
+func (hm *HeatMap) Draw(cvs *canvas.Canvas) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.gridAr, hm.cellSize = hm.layout(cvs.Area())
+	if hm.gridAr.Empty() {
+		return nil
+	}
+
+	min, max := hm.valueRange()
+	for y, row := range hm.values {
+		for x, v := range row {
+			ar := hm.cellArea(image.Point{x, y})
+			if ar.Empty() || math.IsNaN(v) {
+				continue
+			}
+			c := gradientColor(hm.opts.gradient, fraction(v, min, max))
+			if err := draw.Rectangle(cvs, ar, draw.RectCellOpts(cell.BgColor(c))); err != nil {
+				return fmt.Errorf("failed to draw the value in row %d column %d: %v", y, x, err)
+			}
+		}
+	}
+
+	if err := hm.drawMark(cvs); err != nil {
+		return err
+	}
+	if err := hm.drawYLabels(cvs); err != nil {
+		return err
+	}
+	if err := hm.drawXLabels(cvs); err != nil {
+		return err
+	}
+	return hm.drawLegend(cvs, min, max)
+}
+
+// markChar marks the inspected cell.
+const markChar = '•'
+
+// drawMark marks the inspected cell.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) drawMark(cvs *canvas.Canvas) error {
+	if !hm.isInspected {
+		return nil
+	}
+	ar := hm.cellArea(hm.inspected)
+	if ar.Empty() {
+		return nil
+	}
+	mid := image.Point{ar.Min.X + (ar.Dx()-1)/2, ar.Min.Y + (ar.Dy()-1)/2}
+	if _, err := cvs.SetCell(mid, markChar, hm.opts.markCellOpts...); err != nil {
+		return fmt.Errorf("failed to mark the inspected cell: %v", err)
+	}
+	return nil
+}
+
+// drawYLabels draws the labels of the rows on the left of the cells, each
+// label on the first line of its row.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) drawYLabels(cvs *canvas.Canvas) error {
+	for y, l := range hm.yLabels {
+		ar := hm.cellArea(image.Point{0, y})
+		if ar.Empty() {
+			break
+		}
+		pos := image.Point{cvs.Area().Min.X, ar.Min.Y}
+		if err := draw.Text(cvs, l, pos, draw.TextCellOpts(hm.labelCellOpts()...)); err != nil {
+			return fmt.Errorf("failed to draw the label of row %d: %v", y, err)
+		}
+	}
+	return nil
+}
+
+// drawXLabels draws the labels of the columns under the cells, each label
+// starts under the first cell of its column. Labels that would overlap the
+// previous label or don't fit are omitted.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) drawXLabels(cvs *canvas.Canvas) error {
+	if len(hm.xLabels) == 0 {
+		return nil
+	}
+
+	y := hm.gridAr.Min.Y + len(hm.values)*hm.cellSize.Y
+	if y > hm.gridAr.Max.Y {
+		y = hm.gridAr.Max.Y
+	}
+	nextFree := hm.gridAr.Min.X
+	for x, l := range hm.xLabels {
+		ar := hm.cellArea(image.Point{x, 0})
+		if ar.Empty() {
+			break
+		}
+		end := ar.Min.X + runewidth.StringWidth(l)
+		if ar.Min.X < nextFree || end > hm.gridAr.Max.X {
+			continue
+		}
+		if err := draw.Text(cvs, l, image.Point{ar.Min.X, y}, draw.TextCellOpts(hm.labelCellOpts()...)); err != nil {
+			return fmt.Errorf("failed to draw the label of column %d: %v", x, err)
+		}
+		nextFree = end + 1
+	}
+	return nil
+}
+
+// drawLegend draws the color scale between the smallest and the largest value
+// on the last line of the canvas. If a cell is inspected, its labels and value
+// are drawn instead.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) drawLegend(cvs *canvas.Canvas, min, max float64) error {
+	if hm.opts.hideLegend {
+		return nil
+	}
+
+	pos := image.Point{hm.gridAr.Min.X, cvs.Area().Max.Y - 1}
+	textOpts := []draw.TextOption{
+		draw.TextMaxX(cvs.Area().Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(hm.labelCellOpts()...),
+	}
+	if hm.isInspected {
+		p := hm.inspected
+		t := fmt.Sprintf("%s, %s: %s", label(hm.xLabels, p.X), label(hm.yLabels, p.Y), hm.valueText(hm.values[p.Y][p.X]))
+		if err := draw.Text(cvs, t, pos, textOpts...); err != nil {
+			return fmt.Errorf("failed to draw the inspected value: %v", err)
+		}
+		return nil
+	}
+
+	minText, maxText := hm.valueText(min), hm.valueText(max)
+	scaleW := hm.gridAr.Dx() - runewidth.StringWidth(minText) - runewidth.StringWidth(maxText) - 2
+	if scaleW < 1 {
+		return nil
+	}
+
+	if err := draw.Text(cvs, minText, pos, textOpts...); err != nil {
+		return fmt.Errorf("failed to draw the legend: %v", err)
+	}
+	pos.X += runewidth.StringWidth(minText) + 1
+	for i := 0; i < scaleW; i++ {
+		f := 0.5
+		if scaleW > 1 {
+			f = float64(i) / float64(scaleW-1)
+		}
+		c := gradientColor(hm.opts.gradient, f)
+		if _, err := cvs.SetCell(image.Point{pos.X + i, pos.Y}, ' ', cell.BgColor(c)); err != nil {
+			return fmt.Errorf("failed to draw the legend: %v", err)
+		}
+	}
+	pos.X += scaleW + 1
+	if err := draw.Text(cvs, maxText, pos, textOpts...); err != nil {
+		return fmt.Errorf("failed to draw the legend: %v", err)
+	}
+	return nil
+}
+
+// valueAt returns the position of the value drawn at the point on the canvas.
+// Returns false if there isn't any value at the point.
+// hm.mu must be held when calling this method.
+func (hm *HeatMap) valueAt(point image.Point) (image.Point, bool) {
+	if hm.gridAr.Empty() || !point.In(hm.gridAr) {
+		return image.ZP, false
+	}
+	rel := point.Sub(hm.gridAr.Min)
+	p := image.Point{rel.X / hm.cellSize.X, rel.Y / hm.cellSize.Y}
+	if !hm.inBounds(p) {
+		return image.ZP, false
+	}
+	return p, true
+}
+
+// Keyboard input isn't supported on the HeatMap widget.
+func (*HeatMap) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the HeatMap widget doesn't support keyboard events")
+}
+
+// Mouse inspects the value under the mouse while the left button is pressed
+// or released over a cell.
+// Implements widgetapi.Widget.Mouse.
+func (hm *HeatMap) Mouse(m *terminalapi.Mouse) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	if m.Button != mouse.ButtonLeft && m.Button != mouse.ButtonRelease {
+		return nil
+	}
+
+	p, ok := hm.valueAt(m.Position)
+	if !ok {
+		if m.Button == mouse.ButtonLeft {
+			hm.isInspected = false
+		}
+		return nil
+	}
+	hm.inspected = p
+	hm.isInspected = true
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*HeatMap) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: false,
+		WantMouse:    true,
+	}
+}
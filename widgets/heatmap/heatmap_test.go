@@ -0,0 +1,417 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc: "succeeds with a value range",
+			opts: []Option{ValueRange(-1, 1)},
+		},
+		{
+			desc:    "fails on an empty gradient",
+			opts:    []Option{Gradient()},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an empty value range",
+			opts:    []Option{ValueRange(1, 1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an inverted value range",
+			opts:    []Option{ValueRange(2, 1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on a NaN in the value range",
+			opts:    []Option{ValueRange(math.NaN(), 1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		desc    string
+		xLabels []string
+		yLabels []string
+		values  [][]float64
+		wantErr bool
+	}{
+		{
+			desc: "succeeds without values",
+		},
+		{
+			desc:    "succeeds with labels",
+			xLabels: []string{"a", "b"},
+			yLabels: []string{"1"},
+			values:  [][]float64{{1, 2}},
+		},
+		{
+			desc:   "succeeds with missing values",
+			values: [][]float64{{1, math.NaN()}},
+		},
+		{
+			desc:    "fails on rows with different lengths",
+			values:  [][]float64{{1, 2}, {1}},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an infinite value",
+			values:  [][]float64{{1, math.Inf(1)}},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on too few X labels",
+			xLabels: []string{"a"},
+			values:  [][]float64{{1, 2}},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on too many Y labels",
+			yLabels: []string{"1", "2"},
+			values:  [][]float64{{1, 2}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hm, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			err = hm.Values(tc.xLabels, tc.yLabels, tc.values)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Values => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// testGradient is a gradient of distinguishable colors used in the tests.
+var testGradient = []cell.Color{cell.ColorBlue, cell.ColorGreen, cell.ColorYellow, cell.ColorRed}
+
+func TestHeatMap(t *testing.T) {
+	values := [][]float64{
+		{0, 1, 2, 3},
+		{1, 2, 3, 4},
+		{2, math.NaN(), 4, 6},
+	}
+	xLabels := []string{"10:00", "10:01", "10:02", "10:03"}
+	yLabels := []string{"host1", "host2", "host3"}
+
+	tests := []struct {
+		desc    string
+		opts    []Option
+		canvas  image.Rectangle
+		xLabels []string
+		yLabels []string
+		values  [][]float64
+		events  []*terminalapi.Mouse
+		theme   *theme.Theme // if set, the test case calls HeatMap.SetTheme().
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws nothing without values",
+			opts:   []Option{Gradient(testGradient...)},
+			canvas: image.Rect(0, 0, 20, 6),
+			golden: "HeatMap_empty.golden",
+		},
+		{
+			desc:    "draws values with labels and a legend",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			golden:  "HeatMap_labels.golden",
+		},
+		{
+			desc:   "draws values without labels",
+			opts:   []Option{Gradient(testGradient...)},
+			canvas: image.Rect(0, 0, 20, 6),
+			values: values,
+			golden: "HeatMap_no_labels.golden",
+		},
+		{
+			desc:    "omits X labels that would overlap",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 16, 6),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			golden:  "HeatMap_overlapping_labels.golden",
+		},
+		{
+			desc:    "omits columns that don't fit",
+			opts:    []Option{Gradient(testGradient...), HideLegend()},
+			canvas:  image.Rect(0, 0, 8, 4),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			golden:  "HeatMap_clipped.golden",
+		},
+		{
+			desc:   "fixed value range and value format",
+			opts:   []Option{Gradient(testGradient...), ValueRange(0, 12), ValueFormat("%.1f")},
+			canvas: image.Rect(0, 0, 20, 6),
+			values: values,
+			golden: "HeatMap_value_range.golden",
+		},
+		{
+			desc:   "interpolates RGB24 gradient",
+			opts:   []Option{Gradient(cell.ColorRGB24(0, 0, 255), cell.ColorRGB24(255, 0, 0))},
+			canvas: image.Rect(0, 0, 20, 6),
+			values: values,
+			golden: "HeatMap_rgb24.golden",
+		},
+		{
+			desc:    "hidden legend",
+			opts:    []Option{Gradient(testGradient...), HideLegend()},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			golden:  "HeatMap_hidden_legend.golden",
+		},
+		{
+			desc:    "label cell options",
+			opts:    []Option{Gradient(testGradient...), LabelCellOpts(cell.FgColor(cell.ColorMagenta))},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			golden:  "HeatMap_label_cellopts.golden",
+		},
+		{
+			desc:    "uses the label color of the theme",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			theme:   &theme.Theme{Label: cell.ColorRed},
+			golden:  "HeatMap_theme.golden",
+		},
+		{
+			desc:    "label cell options take precedence over the theme",
+			opts:    []Option{Gradient(testGradient...), LabelCellOpts(cell.FgColor(cell.ColorMagenta))},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			theme:   &theme.Theme{Label: cell.ColorRed},
+			golden:  "HeatMap_label_cellopts.golden",
+		},
+		{
+			desc:    "inspects the clicked value",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{26, 4}, Button: mouse.ButtonLeft},
+			},
+			golden: "HeatMap_inspect.golden",
+		},
+		{
+			desc:    "inspects the value where the button is released",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{26, 4}, Button: mouse.ButtonLeft},
+				{Position: image.Point{13, 2}, Button: mouse.ButtonRelease},
+			},
+			golden: "HeatMap_inspect_release.golden",
+		},
+		{
+			desc:    "inspects a missing value",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{13, 4}, Button: mouse.ButtonLeft},
+			},
+			golden: "HeatMap_inspect_missing.golden",
+		},
+		{
+			desc:    "click outside of the cells ends the inspection",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{26, 4}, Button: mouse.ButtonLeft},
+				{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			golden: "HeatMap_labels.golden",
+		},
+		{
+			desc:    "ignores other buttons",
+			opts:    []Option{Gradient(testGradient...)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			xLabels: xLabels,
+			yLabels: yLabels,
+			values:  values,
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{26, 4}, Button: mouse.ButtonWheelUp},
+			},
+			golden: "HeatMap_labels.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hm, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				hm.SetTheme(tc.theme)
+			}
+			if err := hm.Values(tc.xLabels, tc.yLabels, tc.values); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Mouse events are processed based on the layout of the last
+			// drawn heat map.
+			if err := hm.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := hm.Mouse(ev); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+			cvs, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := hm.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestValuesEndsInspectionOutOfBounds(t *testing.T) {
+	hm, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := hm.Values(nil, nil, [][]float64{{1, 2}, {3, 4}}); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+	cvs, err := canvas.New(image.Rect(0, 0, 10, 5))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := hm.Draw(cvs); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if err := hm.Mouse(&terminalapi.Mouse{Position: image.Point{9, 3}, Button: mouse.ButtonLeft}); err != nil {
+		t.Fatalf("Mouse => unexpected error: %v", err)
+	}
+	if !hm.isInspected {
+		t.Fatalf("Mouse => no inspected cell, want one")
+	}
+
+	if err := hm.Values(nil, nil, [][]float64{{1}}); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+	if hm.isInspected {
+		t.Errorf("Values => inspected cell %v remains, want none", hm.inspected)
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	hm, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := hm.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	hm, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := hm.Options()
+	want := widgetapi.Options{
+		MinimumSize: image.Point{1, 1},
+		WantMouse:   true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
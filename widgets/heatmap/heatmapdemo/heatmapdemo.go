@@ -0,0 +1,150 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary heatmapdemo displays a couple of HeatMap widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/heatmap"
+)
+
+// minutes is the number of displayed minutes.
+const minutes = 30
+
+// playLatency periodically shifts a new column of a latency histogram into the
+// heat map. Exits when the context expires.
+func playLatency(ctx context.Context, hm *heatmap.HeatMap, delay time.Duration) {
+	buckets := []string{"1s", "500ms", "250ms", "100ms", "50ms", "10ms"}
+	values := make([][]float64, len(buckets))
+	for i := range values {
+		values[i] = make([]float64, minutes)
+	}
+
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	start := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+			peak := 3 + 2*math.Sin(float64(time.Since(start))/float64(10*time.Second))
+			for i, row := range values {
+				d := float64(len(buckets)-1-i) - peak
+				row = append(row[1:], math.Floor(100*math.Exp(-d*d/2)+rand.Float64()*10))
+				values[i] = row
+			}
+
+			var xLabels []string
+			now := time.Now()
+			for i := 0; i < minutes; i++ {
+				xLabels = append(xLabels, now.Add(time.Duration(i-minutes+1)*time.Minute).Format("15:04"))
+			}
+			if err := hm.Values(xLabels, buckets, values); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// errorCounts returns random error counts per host and minute, some of the
+// data is missing.
+func errorCounts() (xLabels, yLabels []string, values [][]float64) {
+	for i := 0; i < minutes; i++ {
+		xLabels = append(xLabels, fmt.Sprintf("-%dm", minutes-i))
+	}
+	for h := 0; h < 8; h++ {
+		yLabels = append(yLabels, fmt.Sprintf("host%d", h))
+		var row []float64
+		for i := 0; i < minutes; i++ {
+			v := float64(rand.Intn(h*3 + 1))
+			if rand.Intn(20) == 0 {
+				v = math.NaN()
+			}
+			row = append(row, v)
+		}
+		values = append(values, row)
+	}
+	return xLabels, yLabels, values
+}
+
+func main() {
+	t, err := termbox.New(termbox.ColorMode(terminalapi.ColorMode256))
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	latency, err := heatmap.New()
+	if err != nil {
+		panic(err)
+	}
+	go playLatency(ctx, latency, 1*time.Second)
+
+	errs, err := heatmap.New(
+		heatmap.Gradient(cell.ColorRGB24(255, 255, 255), cell.ColorRGB24(255, 160, 0), cell.ColorRGB24(200, 0, 0)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := errs.Values(errorCounts()); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS Q TO QUIT, CLICK A CELL TO INSPECT IT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Latency histogram"),
+				container.PlaceWidget(latency),
+			),
+			container.Bottom(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Errors per host"),
+				container.PlaceWidget(errs),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(1*time.Second)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+// options.go contains configurable options for HeatMap.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	gradient      []cell.Color
+	valueFormat   string
+	hideLegend    bool
+	labelCellOpts []cell.Option
+	markCellOpts  []cell.Option
+
+	// rangeSet indicates that the range of the values was set by the
+	// ValueRange option.
+	rangeSet bool
+	min, max float64
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if len(o.gradient) == 0 {
+		return errors.New("the gradient must have at least one color")
+	}
+	if !o.rangeSet {
+		return nil
+	}
+	for _, v := range []float64{o.min, o.max} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("invalid value range %v..%v, the values must be finite numbers", o.min, o.max)
+		}
+	}
+	if o.min >= o.max {
+		return fmt.Errorf("invalid value range %v..%v, the minimum must be smaller than the maximum", o.min, o.max)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		gradient:     DefaultGradient,
+		markCellOpts: []cell.Option{cell.FgColor(cell.ColorBlack)},
+	}
+}
+
+// DefaultGradient is the default value for the Gradient option, a ramp from
+// blue over green and yellow to red in the 256 color palette.
+var DefaultGradient = []cell.Color{
+	cell.ColorNumber(17),
+	cell.ColorNumber(19),
+	cell.ColorNumber(21),
+	cell.ColorNumber(27),
+	cell.ColorNumber(33),
+	cell.ColorNumber(39),
+	cell.ColorNumber(45),
+	cell.ColorNumber(51),
+	cell.ColorNumber(49),
+	cell.ColorNumber(47),
+	cell.ColorNumber(46),
+	cell.ColorNumber(82),
+	cell.ColorNumber(118),
+	cell.ColorNumber(154),
+	cell.ColorNumber(190),
+	cell.ColorNumber(226),
+	cell.ColorNumber(220),
+	cell.ColorNumber(214),
+	cell.ColorNumber(208),
+	cell.ColorNumber(202),
+	cell.ColorNumber(196),
+}
+
+// Gradient sets the colors used as the background of the cells. The first
+// color represents the smallest value, the last color the largest value and
+// the colors in between are spread evenly over the range of the values.
+// If all the colors were created by cell.ColorRGB24, the values between two
+// colors are displayed in colors interpolated between them. Otherwise each
+// value is displayed in the nearest of the provided colors.
+// Defaults to DefaultGradient.
+func Gradient(colors ...cell.Color) Option {
+	return option(func(opts *options) {
+		opts.gradient = colors
+	})
+}
+
+// ValueRange sets the values represented by the first and the last color of
+// the gradient. Values outside of the range are displayed in the first or the
+// last color respectively.
+// If not set, the range spans from the smallest to the largest of the values.
+func ValueRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.min = min
+		opts.max = max
+		opts.rangeSet = true
+	})
+}
+
+// ValueFormat sets the format of the values displayed in the legend and when
+// inspecting a cell. The format is a fmt verb for a float64 value, e.g.
+// "%.1f". If not set, values are displayed with the smallest number of decimal
+// places necessary.
+func ValueFormat(format string) Option {
+	return option(func(opts *options) {
+		opts.valueFormat = format
+	})
+}
+
+// HideLegend disables the display of the color scale under the heat map.
+// Inspected values are still marked in the cells, but their description isn't
+// displayed.
+func HideLegend() Option {
+	return option(func(opts *options) {
+		opts.hideLegend = true
+	})
+}
+
+// LabelCellOpts sets cell options on cells that contain the labels of the
+// axes and the legend.
+// The labels use the label color of the theme set on the container unless the
+// cell options specify another color.
+func LabelCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.labelCellOpts = cOpts
+	})
+}
+
+// MarkCellOpts sets cell options on the marker displayed in the inspected
+// cell. Defaults to a black foreground color.
+func MarkCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.markCellOpts = cOpts
+	})
+}
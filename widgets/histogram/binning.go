@@ -0,0 +1,216 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+// binning.go divides samples into bins and calculates percentiles.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// bin is a range of values lo <= v < hi and the number of values in it.
+type bin struct {
+	lo, hi float64
+	count  float64
+}
+
+// quantile returns the q-quantile (0 <= q <= 1) of the sorted samples,
+// linearly interpolating between the two closest samples.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	rank := q * float64(len(sorted)-1)
+	i := int(rank)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (rank-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// sturgesCount returns the number of bins for n samples according to the
+// Sturges' formula.
+func sturgesCount(n int) int {
+	return int(math.Ceil(math.Log2(float64(n)))) + 1
+}
+
+// sampleBins divides the sorted samples into bins according to the options.
+func sampleBins(sorted []float64, opts *options) ([]*bin, error) {
+	if len(sorted) == 0 {
+		return nil, nil
+	}
+
+	min, max := sorted[0], sorted[len(sorted)-1]
+	var lo, width float64
+	var count int
+	switch opts.binning {
+	case binningFixedWidth:
+		width = opts.binWidth
+		lo = math.Floor(min/width) * width
+		count = int((max-lo)/width) + 1
+		if count > opts.maxBins {
+			return nil, fmt.Errorf("bins of width %v would divide the samples into %d bins, more than the maximum of %d", width, count, opts.maxBins)
+		}
+
+	case binningFixedCount:
+		lo, count = min, opts.binCount
+		if count > opts.maxBins {
+			return nil, fmt.Errorf("bin count %d is more than the maximum of %d", count, opts.maxBins)
+		}
+		width = (max - min) / float64(count)
+
+	default:
+		lo = min
+		iqr := quantile(sorted, 0.75) - quantile(sorted, 0.25)
+		width = 2 * iqr / math.Cbrt(float64(len(sorted)))
+		if width > 0 {
+			count = int(math.Ceil((max - min) / width))
+		} else {
+			count = sturgesCount(len(sorted))
+		}
+		if count > opts.maxBins {
+			count = opts.maxBins
+		}
+		if count < 1 {
+			count = 1
+		}
+		width = (max - min) / float64(count)
+	}
+	if width == 0 {
+		// All the samples are equal.
+		count = 1
+	}
+
+	var bins []*bin
+	for i := 0; i < count; i++ {
+		bins = append(bins, &bin{
+			lo: lo + float64(i)*width,
+			hi: lo + float64(i+1)*width,
+		})
+	}
+	for _, v := range sorted {
+		i := count - 1
+		if width > 0 {
+			i = int((v - lo) / width)
+		}
+		if i >= count {
+			// The largest sample is the upper bound of the last bin.
+			i = count - 1
+		}
+		bins[i].count++
+	}
+	return bins, nil
+}
+
+// Bucket is a pre-bucketed range of observations in the style of Prometheus
+// histograms.
+type Bucket struct {
+	// UpperBound is the inclusive upper bound of the bucket. Can be +Inf for
+	// the last bucket.
+	UpperBound float64
+	// Count is the cumulative number of observations that are less than or
+	// equal to the upper bound, i.e. including the observations in all the
+	// previous buckets.
+	Count float64
+}
+
+// bucketBins converts the cumulative buckets to bins.
+// The lower bound of the first bin is zero if its upper bound is positive, as
+// in Prometheus, otherwise the first bin has zero width.
+func bucketBins(buckets []Bucket) ([]*bin, error) {
+	var bins []*bin
+	for i, b := range buckets {
+		if math.IsNaN(b.UpperBound) || math.IsInf(b.UpperBound, -1) {
+			return nil, fmt.Errorf("invalid upper bound %v of bucket %d", b.UpperBound, i)
+		}
+		if math.IsInf(b.UpperBound, 1) && i != len(buckets)-1 {
+			return nil, fmt.Errorf("only the last bucket can have an infinite upper bound, found one in bucket %d", i)
+		}
+		if b.Count < 0 || math.IsNaN(b.Count) || math.IsInf(b.Count, 0) {
+			return nil, fmt.Errorf("invalid count %v of bucket %d, must be zero or a positive number", b.Count, i)
+		}
+
+		if i == 0 {
+			lo := math.Min(0, b.UpperBound)
+			bins = append(bins, &bin{lo: lo, hi: b.UpperBound, count: b.Count})
+			continue
+		}
+		prev := buckets[i-1]
+		if b.UpperBound <= prev.UpperBound {
+			return nil, fmt.Errorf("the upper bounds must be increasing, bucket %d has upper bound %v after %v", i, b.UpperBound, prev.UpperBound)
+		}
+		if b.Count < prev.Count {
+			return nil, fmt.Errorf("the counts must be cumulative, bucket %d has count %v after %v", i, b.Count, prev.Count)
+		}
+		bins = append(bins, &bin{lo: prev.UpperBound, hi: b.UpperBound, count: b.Count - prev.Count})
+	}
+	return bins, nil
+}
+
+// binQuantile estimates the q-quantile (0 <= q <= 1) of the observations in
+// the bins by linear interpolation within the bin the quantile falls into,
+// the same way Prometheus does. The quantile of observations in a bin with an
+// infinite upper bound is its lower bound.
+func binQuantile(bins []*bin, q float64) float64 {
+	var total float64
+	for _, b := range bins {
+		total += b.count
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+
+	rank := q * total
+	var cum float64
+	for _, b := range bins {
+		if b.count == 0 || cum+b.count < rank {
+			cum += b.count
+			continue
+		}
+		if math.IsInf(b.hi, 1) {
+			return b.lo
+		}
+		return b.lo + (b.hi-b.lo)*(rank-cum)/b.count
+	}
+	return bins[len(bins)-1].hi
+}
+
+// binPosition returns the position of the value in units of bins, e.g. 2.5
+// for the middle of the third bin. Values outside of the bins are clamped.
+func binPosition(bins []*bin, v float64) float64 {
+	for i, b := range bins {
+		if v > b.hi && i != len(bins)-1 {
+			continue
+		}
+		if v <= b.lo {
+			return float64(i)
+		}
+		if math.IsInf(b.hi, 1) || v >= b.hi {
+			return float64(i + 1)
+		}
+		return float64(i) + (v-b.lo)/(b.hi-b.lo)
+	}
+	return 0
+}
+
+// sortedCopy returns a sorted copy of the values.
+func sortedCopy(values []float64) []float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return sorted
+}
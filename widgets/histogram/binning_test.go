@@ -0,0 +1,297 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestQuantile(t *testing.T) {
+	tests := []struct {
+		desc   string
+		sorted []float64
+		q      float64
+		want   float64
+	}{
+		{desc: "single sample", sorted: []float64{5}, q: 0.5, want: 5},
+		{desc: "minimum", sorted: []float64{1, 2, 3, 4}, q: 0, want: 1},
+		{desc: "maximum", sorted: []float64{1, 2, 3, 4}, q: 1, want: 4},
+		{desc: "exact sample", sorted: []float64{1, 2, 3}, q: 0.5, want: 2},
+		{desc: "interpolates", sorted: []float64{1, 2, 3, 4}, q: 0.5, want: 2.5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := quantile(tc.sorted, tc.q); got != tc.want {
+				t.Errorf("quantile(%v, %v) => %v, want %v", tc.sorted, tc.q, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSampleBins(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		sorted  []float64
+		want    []*bin
+		wantErr bool
+	}{
+		{
+			desc: "no samples",
+		},
+		{
+			desc:   "fixed width aligns the bins",
+			opts:   []Option{FixedWidth(2)},
+			sorted: []float64{1, 2, 3, 4.5},
+			want: []*bin{
+				{lo: 0, hi: 2, count: 1},
+				{lo: 2, hi: 4, count: 2},
+				{lo: 4, hi: 6, count: 1},
+			},
+		},
+		{
+			desc:    "fixed width fails on too many bins",
+			opts:    []Option{FixedWidth(1), MaxBins(2)},
+			sorted:  []float64{0, 5},
+			wantErr: true,
+		},
+		{
+			desc:   "fixed count includes the largest sample in the last bin",
+			opts:   []Option{FixedCount(2)},
+			sorted: []float64{0, 1, 2, 3, 4},
+			want: []*bin{
+				{lo: 0, hi: 2, count: 2},
+				{lo: 2, hi: 4, count: 3},
+			},
+		},
+		{
+			desc:    "fixed count fails on too many bins",
+			opts:    []Option{FixedCount(3), MaxBins(2)},
+			sorted:  []float64{0, 5},
+			wantErr: true,
+		},
+		{
+			desc:   "a single bin for equal samples",
+			opts:   []Option{FixedCount(2)},
+			sorted: []float64{3, 3},
+			want: []*bin{
+				{lo: 3, hi: 3, count: 2},
+			},
+		},
+		{
+			desc:   "Freedman–Diaconis",
+			sorted: []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			// IQR is 3.5, the width is 2*3.5/2 = 3.5 so two bins.
+			want: []*bin{
+				{lo: 0, hi: 3.5, count: 4},
+				{lo: 3.5, hi: 7, count: 4},
+			},
+		},
+		{
+			desc:   "Freedman–Diaconis is limited by the maximum",
+			opts:   []Option{MaxBins(1)},
+			sorted: []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			want: []*bin{
+				{lo: 0, hi: 7, count: 8},
+			},
+		},
+		{
+			desc:   "Freedman–Diaconis falls back to Sturges without interquartile range",
+			sorted: []float64{0, 5, 5, 5, 5, 5, 5, 10},
+			// Sturges gives four bins.
+			want: []*bin{
+				{lo: 0, hi: 2.5, count: 1},
+				{lo: 2.5, hi: 5},
+				{lo: 5, hi: 7.5, count: 6},
+				{lo: 7.5, hi: 10, count: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			opts := newOptions()
+			for _, o := range tc.opts {
+				o.set(opts)
+			}
+			got, err := sampleBins(tc.sorted, opts)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("sampleBins => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("sampleBins => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketBins(t *testing.T) {
+	tests := []struct {
+		desc    string
+		buckets []Bucket
+		want    []*bin
+		wantErr bool
+	}{
+		{
+			desc: "no buckets",
+		},
+		{
+			desc: "converts cumulative counts",
+			buckets: []Bucket{
+				{UpperBound: 0.1, Count: 2},
+				{UpperBound: 0.5, Count: 5},
+				{UpperBound: math.Inf(1), Count: 6},
+			},
+			want: []*bin{
+				{lo: 0, hi: 0.1, count: 2},
+				{lo: 0.1, hi: 0.5, count: 3},
+				{lo: 0.5, hi: math.Inf(1), count: 1},
+			},
+		},
+		{
+			desc: "first bucket with a negative upper bound",
+			buckets: []Bucket{
+				{UpperBound: -1, Count: 2},
+				{UpperBound: 1, Count: 3},
+			},
+			want: []*bin{
+				{lo: -1, hi: -1, count: 2},
+				{lo: -1, hi: 1, count: 1},
+			},
+		},
+		{
+			desc: "fails on decreasing upper bounds",
+			buckets: []Bucket{
+				{UpperBound: 1, Count: 1},
+				{UpperBound: 1, Count: 2},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on decreasing counts",
+			buckets: []Bucket{
+				{UpperBound: 1, Count: 2},
+				{UpperBound: 2, Count: 1},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on infinite upper bound before the last bucket",
+			buckets: []Bucket{
+				{UpperBound: math.Inf(1), Count: 1},
+				{UpperBound: math.Inf(1), Count: 2},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on a NaN upper bound",
+			buckets: []Bucket{
+				{UpperBound: math.NaN(), Count: 1},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on a negative count",
+			buckets: []Bucket{
+				{UpperBound: 1, Count: -1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bucketBins(tc.buckets)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("bucketBins => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("bucketBins => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBinQuantile(t *testing.T) {
+	bins := []*bin{
+		{lo: 0, hi: 1, count: 2},
+		{lo: 1, hi: 2},
+		{lo: 2, hi: 4, count: 6},
+		{lo: 4, hi: math.Inf(1), count: 2},
+	}
+
+	tests := []struct {
+		desc string
+		bins []*bin
+		q    float64
+		want float64
+	}{
+		{desc: "no observations", bins: []*bin{{lo: 0, hi: 1}}, q: 0.5, want: math.NaN()},
+		{desc: "minimum", bins: bins, q: 0, want: 0},
+		{desc: "interpolates in the first bin", bins: bins, q: 0.1, want: 0.5},
+		{desc: "skips empty bins", bins: bins, q: 0.2, want: 1},
+		{desc: "interpolates in a later bin", bins: bins, q: 0.5, want: 3},
+		{desc: "lower bound of the infinite bin", bins: bins, q: 0.99, want: 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := binQuantile(tc.bins, tc.q)
+			if got != tc.want && !(math.IsNaN(got) && math.IsNaN(tc.want)) {
+				t.Errorf("binQuantile(%v) => %v, want %v", tc.q, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBinPosition(t *testing.T) {
+	bins := []*bin{
+		{lo: 0, hi: 2},
+		{lo: 2, hi: 4},
+		{lo: 4, hi: math.Inf(1)},
+	}
+
+	tests := []struct {
+		desc string
+		v    float64
+		want float64
+	}{
+		{desc: "below the bins", v: -1, want: 0},
+		{desc: "lower bound", v: 0, want: 0},
+		{desc: "within the first bin", v: 1, want: 0.5},
+		{desc: "boundary between bins", v: 2, want: 1},
+		{desc: "within the second bin", v: 3.5, want: 1.75},
+		{desc: "lower bound of the infinite bin", v: 4, want: 2},
+		{desc: "within the infinite bin", v: 10, want: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := binPosition(bins, tc.v); got != tc.want {
+				t.Errorf("binPosition(%v) => %v, want %v", tc.v, got, tc.want)
+			}
+		})
+	}
+}
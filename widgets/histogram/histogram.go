@@ -0,0 +1,371 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package histogram is a widget that displays the distribution of values as
+// bars of a bar chart.
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/barchart"
+)
+
+// Histogram displays the distribution of values, either of raw samples that
+// it divides into bins or of pre-bucketed observations in the style of
+// Prometheus histograms. Each bin is displayed as a bar, the X axis under the
+// bars shows the boundaries of the bins. Vertical lines over the bars mark
+// the configured percentiles.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Histogram struct {
+	// bins are the displayed bins.
+	bins []*bin
+	// percentiles are the values of the percentiles in the options, NaN if the
+	// percentile is unknown.
+	percentiles []float64
+
+	// bc draws the bars.
+	bc *barchart.BarChart
+
+	// mu protects the Histogram.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new Histogram.
+func New(opts ...Option) (*Histogram, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Histogram{
+		bc:   barchart.New(barchart.BarGap(0)),
+		opts: opt,
+	}, nil
+}
+
+// Samples sets the raw samples to display, replacing any previously provided
+// samples or buckets. The samples are divided into bins according to the
+// provided options and must be finite numbers.
+func (h *Histogram) Samples(samples []float64) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, s := range samples {
+		if math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("invalid sample %v at index %d, must be a finite number", s, i)
+		}
+	}
+
+	sorted := sortedCopy(samples)
+	bins, err := sampleBins(sorted, h.opts)
+	if err != nil {
+		return err
+	}
+
+	var pcts []float64
+	for _, p := range h.opts.percentiles {
+		pcts = append(pcts, quantile(sorted, p/100))
+	}
+	h.bins = bins
+	h.percentiles = pcts
+	return nil
+}
+
+// Buckets sets pre-bucketed observations to display, replacing any previously
+// provided samples or buckets. Each bucket is displayed as one bin. The
+// buckets must be sorted by their upper bounds and their counts must be
+// cumulative. Percentiles are estimated by linear interpolation within the
+// buckets.
+func (h *Histogram) Buckets(buckets []Bucket) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bins, err := bucketBins(buckets)
+	if err != nil {
+		return err
+	}
+
+	var pcts []float64
+	for _, p := range h.opts.percentiles {
+		pcts = append(pcts, binQuantile(bins, p/100))
+	}
+	h.bins = bins
+	h.percentiles = pcts
+	return nil
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (h *Histogram) SetTheme(t *theme.Theme) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.theme = t
+}
+
+// barColor returns the color of the bars.
+// h.mu must be held when calling this method.
+func (h *Histogram) barColor() cell.Color {
+	if h.opts.barColorSet {
+		return h.opts.barColor
+	}
+	if h.theme != nil {
+		return h.theme.SeriesColor(0)
+	}
+	return barchart.DefaultBarColor
+}
+
+// labelCellOpts returns the cell options of the labels prefixed with the
+// label color of the theme, so that colors in the options take precedence.
+// h.mu must be held when calling this method.
+func (h *Histogram) labelCellOpts() []cell.Option {
+	if h.theme == nil {
+		return h.opts.labelCellOpts
+	}
+	return append([]cell.Option{cell.FgColor(h.theme.Label)}, h.opts.labelCellOpts...)
+}
+
+// valueText formats a boundary of a bin.
+// h.mu must be held when calling this method.
+func (h *Histogram) valueText(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	if h.opts.valueFormat != "" {
+		return fmt.Sprintf(h.opts.valueFormat, v)
+	}
+	rounded, _ := numbers.RoundToNonZeroPlaces(v, 2)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// marker is a percentile marker positioned on the canvas.
+type marker struct {
+	x     int
+	label string
+}
+
+// markers returns the markers of the known percentiles that fall into the
+// visible bins, sorted by their position.
+// h.mu must be held when calling this method.
+func (h *Histogram) markers(visible, binW int) []*marker {
+	var markers []*marker
+	for i, v := range h.percentiles {
+		if math.IsNaN(v) {
+			continue
+		}
+		pos := binPosition(h.bins, v)
+		if pos > float64(visible) {
+			continue
+		}
+		x := int(pos * float64(binW))
+		if max := visible*binW - 1; x > max {
+			x = max
+		}
+		markers = append(markers, &marker{
+			x:     x,
+			label: "p" + strconv.FormatFloat(h.opts.percentiles[i], 'f', -1, 64),
+		})
+	}
+	sort.SliceStable(markers, func(i, j int) bool { return markers[i].x < markers[j].x })
+	return markers
+}
+
+// Draw draws the Histogram widget onto the canvas.
+// Bins that don't fit onto the canvas are omitted.
+// Implements widgetapi.Widget.Draw.
+func (h *Histogram) Draw(cvs *canvas.Canvas) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.bins) == 0 {
+		return nil
+	}
+
+	ar := cvs.Area()
+	binW := ar.Dx() / len(h.bins)
+	if binW < 1 {
+		binW = 1
+	}
+	visible := len(h.bins)
+	if max := ar.Dx() / binW; visible > max {
+		visible = max
+	}
+
+	top := 0
+	if len(h.opts.percentiles) > 0 {
+		// One line for the labels of the percentile markers.
+		top = 1
+	}
+	// The last line is used by the X axis.
+	barsAr := image.Rect(ar.Min.X, ar.Min.Y+top, ar.Min.X+visible*binW, ar.Max.Y-1)
+	if barsAr.Dy() < 1 {
+		return nil
+	}
+
+	if err := h.drawBars(cvs, barsAr, visible, binW); err != nil {
+		return err
+	}
+	if err := h.drawMarkers(cvs, barsAr, h.markers(visible, binW)); err != nil {
+		return err
+	}
+	return h.drawAxis(cvs, barsAr, visible, binW)
+}
+
+// drawBars draws the visible bins as bars of the bar chart into the area.
+// h.mu must be held when calling this method.
+func (h *Histogram) drawBars(cvs *canvas.Canvas, barsAr image.Rectangle, visible, binW int) error {
+	var counts []float64
+	var colors []cell.Color
+	var max float64
+	for _, b := range h.bins[:visible] {
+		counts = append(counts, b.count)
+		colors = append(colors, h.barColor())
+		max = math.Max(max, b.count)
+	}
+	if max == 0 {
+		return nil
+	}
+
+	if err := h.bc.FloatValues(counts, 0, max,
+		barchart.BarWidth(binW),
+		barchart.BarColors(colors),
+	); err != nil {
+		return fmt.Errorf("failed to set the bar values: %v", err)
+	}
+	bcvs, err := canvas.New(barsAr)
+	if err != nil {
+		return fmt.Errorf("canvas.New => %v", err)
+	}
+	if err := h.bc.Draw(bcvs); err != nil {
+		return fmt.Errorf("failed to draw the bars: %v", err)
+	}
+	return bcvs.CopyTo(cvs)
+}
+
+// markerChar is used to draw the vertical lines of percentile markers.
+const markerChar = '│'
+
+// drawMarkers draws the vertical lines of the percentile markers over the
+// bars and their labels above the bars. Labels that would overlap the previous
+// label are omitted.
+// h.mu must be held when calling this method.
+func (h *Histogram) drawMarkers(cvs *canvas.Canvas, barsAr image.Rectangle, markers []*marker) error {
+	nextFree := cvs.Area().Min.X
+	for _, m := range markers {
+		x := barsAr.Min.X + m.x
+		for y := barsAr.Min.Y; y < barsAr.Max.Y; y++ {
+			// Only the foreground color is set, so that the marker keeps the
+			// background color of the bar.
+			if _, err := cvs.SetCell(image.Point{x, y}, markerChar, h.opts.percentileCellOpts...); err != nil {
+				return fmt.Errorf("failed to draw the %s marker: %v", m.label, err)
+			}
+		}
+
+		w := runewidth.StringWidth(m.label)
+		start := x
+		if max := cvs.Area().Max.X - w; start > max {
+			start = max
+		}
+		if start < nextFree {
+			continue
+		}
+		if err := draw.Text(cvs, m.label, image.Point{start, cvs.Area().Min.Y},
+			draw.TextMaxX(cvs.Area().Max.X),
+			draw.TextCellOpts(h.opts.percentileCellOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the %s label: %v", m.label, err)
+		}
+		nextFree = start + w + 1
+	}
+	return nil
+}
+
+// drawAxis draws the boundaries of the visible bins on the line under the
+// bars, each centered under its position. Boundaries that would overlap the
+// previous boundary are omitted.
+// h.mu must be held when calling this method.
+func (h *Histogram) drawAxis(cvs *canvas.Canvas, barsAr image.Rectangle, visible, binW int) error {
+	ar := cvs.Area()
+	nextFree := ar.Min.X
+	for e := 0; e <= visible; e++ {
+		v := h.bins[0].lo
+		if e > 0 {
+			v = h.bins[e-1].hi
+		}
+		t := h.valueText(v)
+		w := runewidth.StringWidth(t)
+
+		start := barsAr.Min.X + e*binW - w/2
+		if max := ar.Max.X - w; start > max {
+			start = max
+		}
+		if start < ar.Min.X {
+			start = ar.Min.X
+		}
+		if start < nextFree {
+			continue
+		}
+		if err := draw.Text(cvs, t, image.Point{start, ar.Max.Y - 1},
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(h.labelCellOpts()...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the X axis: %v", err)
+		}
+		nextFree = start + w + 1
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the Histogram widget.
+func (*Histogram) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Histogram widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Histogram widget.
+func (*Histogram) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Histogram widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*Histogram) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// One line for the percentile labels, the bars and the X axis.
+		MinimumSize:  image.Point{1, 3},
+		WantKeyboard: false,
+		WantMouse:    false,
+	}
+}
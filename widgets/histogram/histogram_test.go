@@ -0,0 +1,319 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc:    "fails on zero bin width",
+			opts:    []Option{FixedWidth(0)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on infinite bin width",
+			opts:    []Option{FixedWidth(math.Inf(1))},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero bin count",
+			opts:    []Option{FixedCount(0)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero maximum bins",
+			opts:    []Option{MaxBins(0)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on percentile too high",
+			opts:    []Option{Percentiles(50, 101)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on a NaN percentile",
+			opts:    []Option{Percentiles(math.NaN())},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSamplesErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		samples []float64
+		wantErr bool
+	}{
+		{
+			desc:    "succeeds on finite samples",
+			samples: []float64{1, 2, 3},
+		},
+		{
+			desc:    "fails on a NaN sample",
+			samples: []float64{1, math.NaN()},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an infinite sample",
+			samples: []float64{math.Inf(-1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on too many bins",
+			opts:    []Option{FixedWidth(1), MaxBins(5)},
+			samples: []float64{0, 10},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			err = h.Samples(tc.samples)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Samples => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// testSamples are samples used in the tests, 0 once, 1 twice, ... 9 ten
+// times.
+func testSamples() []float64 {
+	var samples []float64
+	for v := 0; v < 10; v++ {
+		for i := 0; i <= v; i++ {
+			samples = append(samples, float64(v))
+		}
+	}
+	return samples
+}
+
+func TestHistogram(t *testing.T) {
+	buckets := []Bucket{
+		{UpperBound: 0.1, Count: 10},
+		{UpperBound: 0.25, Count: 40},
+		{UpperBound: 0.5, Count: 90},
+		{UpperBound: 1, Count: 98},
+		{UpperBound: math.Inf(1), Count: 100},
+	}
+
+	tests := []struct {
+		desc    string
+		opts    []Option
+		canvas  image.Rectangle
+		samples []float64
+		buckets []Bucket
+		theme   *theme.Theme // if set, the test case calls Histogram.SetTheme().
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws nothing without data",
+			canvas: image.Rect(0, 0, 20, 6),
+			golden: "Histogram_empty.golden",
+		},
+		{
+			desc:    "fixed count with percentiles",
+			opts:    []Option{FixedCount(10)},
+			canvas:  image.Rect(0, 0, 30, 10),
+			samples: testSamples(),
+			golden:  "Histogram_fixed_count.golden",
+		},
+		{
+			desc:    "fixed width",
+			opts:    []Option{FixedWidth(4), Percentiles(50)},
+			canvas:  image.Rect(0, 0, 30, 8),
+			samples: testSamples(),
+			golden:  "Histogram_fixed_width.golden",
+		},
+		{
+			desc:    "Freedman–Diaconis",
+			canvas:  image.Rect(0, 0, 30, 8),
+			samples: testSamples(),
+			golden:  "Histogram_freedman_diaconis.golden",
+		},
+		{
+			desc:    "without percentiles",
+			opts:    []Option{FixedCount(5), Percentiles()},
+			canvas:  image.Rect(0, 0, 20, 6),
+			samples: testSamples(),
+			golden:  "Histogram_no_percentiles.golden",
+		},
+		{
+			desc:    "Prometheus buckets",
+			canvas:  image.Rect(0, 0, 30, 8),
+			buckets: buckets,
+			golden:  "Histogram_buckets.golden",
+		},
+		{
+			desc:    "omits bins that don't fit",
+			opts:    []Option{FixedCount(10), Percentiles(10)},
+			canvas:  image.Rect(0, 0, 6, 6),
+			samples: testSamples(),
+			golden:  "Histogram_clipped.golden",
+		},
+		{
+			desc: "custom colors and value format",
+			opts: []Option{
+				FixedCount(5),
+				Percentiles(90),
+				BarColor(cell.ColorBlue),
+				PercentileCellOpts(cell.FgColor(cell.ColorRed)),
+				LabelCellOpts(cell.FgColor(cell.ColorGreen)),
+				ValueFormat("%.1f"),
+			},
+			canvas:  image.Rect(0, 0, 30, 6),
+			samples: testSamples(),
+			golden:  "Histogram_custom.golden",
+		},
+		{
+			desc:    "uses colors from the theme",
+			opts:    []Option{FixedCount(5), Percentiles(90)},
+			canvas:  image.Rect(0, 0, 30, 6),
+			samples: testSamples(),
+			theme: &theme.Theme{
+				Label:  cell.ColorGreen,
+				Series: []cell.Color{cell.ColorBlue},
+			},
+			golden: "Histogram_theme.golden",
+		},
+		{
+			desc: "explicit colors take precedence over the theme",
+			opts: []Option{
+				FixedCount(5),
+				Percentiles(90),
+				BarColor(cell.ColorBlue),
+				PercentileCellOpts(cell.FgColor(cell.ColorRed)),
+				LabelCellOpts(cell.FgColor(cell.ColorGreen)),
+				ValueFormat("%.1f"),
+			},
+			canvas:  image.Rect(0, 0, 30, 6),
+			samples: testSamples(),
+			theme: &theme.Theme{
+				Label:  cell.ColorMagenta,
+				Series: []cell.Color{cell.ColorYellow},
+			},
+			golden: "Histogram_custom.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				h.SetTheme(tc.theme)
+			}
+			if tc.samples != nil {
+				if err := h.Samples(tc.samples); err != nil {
+					t.Fatalf("Samples => unexpected error: %v", err)
+				}
+			}
+			if tc.buckets != nil {
+				if err := h.Buckets(tc.buckets); err != nil {
+					t.Fatalf("Buckets => unexpected error: %v", err)
+				}
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := h.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	h, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := h.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	h, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := h.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	h, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := h.Options()
+	want := widgetapi.Options{
+		MinimumSize: image.Point{1, 3},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary histogramdemo displays a couple of Histogram widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/histogram"
+)
+
+// playSamples periodically sets new normally distributed samples.
+// Exits when the context expires.
+func playSamples(ctx context.Context, h *histogram.Histogram, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			var samples []float64
+			for i := 0; i < 1000; i++ {
+				samples = append(samples, 100+15*rand.NormFloat64())
+			}
+			if err := h.Samples(samples); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// playBuckets periodically adds exponentially distributed latencies into
+// Prometheus-style buckets. Exits when the context expires.
+func playBuckets(ctx context.Context, h *histogram.Histogram, delay time.Duration) {
+	bounds := []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, math.Inf(1)}
+	counts := make([]float64, len(bounds))
+
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for i := 0; i < 100; i++ {
+				latency := rand.ExpFloat64() / 20
+				for b, ub := range bounds {
+					if latency <= ub {
+						counts[b]++
+					}
+				}
+			}
+
+			var buckets []histogram.Bucket
+			for i, ub := range bounds {
+				buckets = append(buckets, histogram.Bucket{UpperBound: ub, Count: counts[i]})
+			}
+			if err := h.Buckets(buckets); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	samples, err := histogram.New()
+	if err != nil {
+		panic(err)
+	}
+	go playSamples(ctx, samples, 1*time.Second)
+
+	latency, err := histogram.New(
+		histogram.BarColor(cell.ColorBlue),
+		histogram.Percentiles(50, 95, 99),
+	)
+	if err != nil {
+		panic(err)
+	}
+	go playBuckets(ctx, latency, 1*time.Second)
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Normal distribution"),
+				container.PlaceWidget(samples),
+			),
+			container.Bottom(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Request latency (s)"),
+				container.PlaceWidget(latency),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(1*time.Second)); err != nil {
+		panic(err)
+	}
+}
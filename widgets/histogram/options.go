@@ -0,0 +1,204 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+// options.go contains configurable options for Histogram.
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// binning is the method used to divide samples into bins.
+type binning int
+
+// String implements fmt.Stringer()
+func (b binning) String() string {
+	if n, ok := binningNames[b]; ok {
+		return n
+	}
+	return "binningUnknown"
+}
+
+// binningNames maps binning values to human readable names.
+var binningNames = map[binning]string{
+	binningFreedmanDiaconis: "binningFreedmanDiaconis",
+	binningFixedWidth:       "binningFixedWidth",
+	binningFixedCount:       "binningFixedCount",
+}
+
+const (
+	binningFreedmanDiaconis binning = iota
+	binningFixedWidth
+	binningFixedCount
+)
+
+// options holds the provided options.
+type options struct {
+	binning  binning
+	binWidth float64
+	binCount int
+	maxBins  int
+
+	percentiles        []float64
+	percentileCellOpts []cell.Option
+	labelCellOpts      []cell.Option
+	valueFormat        string
+
+	barColor    cell.Color
+	barColorSet bool
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.binning == binningFixedWidth && (o.binWidth <= 0 || math.IsNaN(o.binWidth) || math.IsInf(o.binWidth, 0)) {
+		return fmt.Errorf("invalid bin width %v, must be a positive number", o.binWidth)
+	}
+	if o.binning == binningFixedCount && o.binCount < 1 {
+		return fmt.Errorf("invalid bin count %d, must be a positive number", o.binCount)
+	}
+	if o.maxBins < 1 {
+		return fmt.Errorf("invalid maximum number of bins %d, must be a positive number", o.maxBins)
+	}
+	for _, p := range o.percentiles {
+		if min, max := 0.0, 100.0; !(p >= min && p <= max) {
+			return fmt.Errorf("invalid percentile %v, must be in range %v <= p <= %v", p, min, max)
+		}
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		binning:            binningFreedmanDiaconis,
+		maxBins:            DefaultMaxBins,
+		percentiles:        DefaultPercentiles,
+		percentileCellOpts: []cell.Option{cell.FgColor(DefaultPercentileColor)},
+	}
+}
+
+// FreedmanDiaconis divides samples into bins whose width is determined by the
+// Freedman–Diaconis rule from the interquartile range and the number of the
+// samples. If the samples don't have any interquartile range, the number of
+// bins is determined by the Sturges' formula instead.
+// This is the default binning.
+func FreedmanDiaconis() Option {
+	return option(func(opts *options) {
+		opts.binning = binningFreedmanDiaconis
+	})
+}
+
+// FixedWidth divides samples into bins of the specified width. The bins are
+// aligned to multiples of the width. Must be a positive number.
+func FixedWidth(width float64) Option {
+	return option(func(opts *options) {
+		opts.binning = binningFixedWidth
+		opts.binWidth = width
+	})
+}
+
+// FixedCount divides samples into the specified number of bins of equal
+// width that span from the smallest to the largest sample. Must be a positive
+// number.
+func FixedCount(count int) Option {
+	return option(func(opts *options) {
+		opts.binning = binningFixedCount
+		opts.binCount = count
+	})
+}
+
+// DefaultMaxBins is the default value for the MaxBins option.
+const DefaultMaxBins = 100
+
+// MaxBins sets the largest number of bins the samples are divided into.
+// The Freedman–Diaconis rule widens its bins to stay within the limit, while
+// the fixed binnings fail with an error if they would exceed it.
+// Defaults to DefaultMaxBins.
+func MaxBins(n int) Option {
+	return option(func(opts *options) {
+		opts.maxBins = n
+	})
+}
+
+// DefaultPercentiles are the default value for the Percentiles option.
+var DefaultPercentiles = []float64{50, 90, 99}
+
+// Percentiles sets the percentiles that are marked by vertical lines over the
+// bars, each labeled with its name, e.g. "p90". Valid range is
+// 0 <= p <= 100. Providing no percentiles disables the markers.
+// Defaults to DefaultPercentiles.
+func Percentiles(ps ...float64) Option {
+	return option(func(opts *options) {
+		opts.percentiles = ps
+	})
+}
+
+// DefaultPercentileColor is the default color of the percentile markers,
+// unless specified otherwise via the PercentileCellOpts option.
+const DefaultPercentileColor = cell.ColorYellow
+
+// PercentileCellOpts sets cell options on cells that contain the percentile
+// markers and their labels.
+func PercentileCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.percentileCellOpts = cOpts
+	})
+}
+
+// LabelCellOpts sets cell options on cells that contain the labels of the X
+// axis.
+// The labels use the label color of the theme set on the container unless the
+// cell options specify another color.
+func LabelCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.labelCellOpts = cOpts
+	})
+}
+
+// BarColor sets the color of the bars. If not set, the bars use the first
+// series color of the theme set on the container or the
+// barchart.DefaultBarColor if there is no theme.
+func BarColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.barColor = c
+		opts.barColorSet = true
+	})
+}
+
+// ValueFormat sets the format of the bin boundaries displayed on the X axis.
+// The format is a fmt verb for a float64 value, e.g. "%.1f". If not set,
+// values are displayed with the smallest number of decimal places necessary.
+func ValueFormat(format string) Option {
+	return option(func(opts *options) {
+		opts.valueFormat = format
+	})
+}
@@ -0,0 +1,561 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// annotation.go contains code that draws the horizontal lines, the vertical
+// markers and the Y bands.
+
+import (
+	"fmt"
+	"image"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+)
+
+// AnnotationOption is used to provide options to HorizontalLine,
+// VerticalMarker, YBand and the methods of the LineChart that add
+// annotations.
+type AnnotationOption interface {
+	// set sets the provided option.
+	set(*annotation)
+}
+
+// annotationOption implements AnnotationOption.
+type annotationOption func(*annotation)
+
+// set implements AnnotationOption.set.
+func (ao annotationOption) set(a *annotation) {
+	ao(a)
+}
+
+// annotation stores the provided annotation options.
+type annotation struct {
+	id       string
+	text     string
+	color    cell.Color
+	colorSet bool
+	rightY   bool
+}
+
+// newAnnotation returns a new annotation with the provided options.
+func newAnnotation(opts []AnnotationOption) annotation {
+	a := annotation{}
+	for _, o := range opts {
+		o.set(&a)
+	}
+	return a
+}
+
+// cellOpts returns the cell options for the annotation, the defOpts are used
+// if the annotation doesn't have a color.
+func (a *annotation) cellOpts(defOpts []cell.Option) []cell.Option {
+	if a.colorSet {
+		return []cell.Option{cell.FgColor(a.color)}
+	}
+	return defOpts
+}
+
+// AnnotationText sets a short text displayed next to the horizontal line,
+// the vertical marker or at the top of the Y band.
+func AnnotationText(text string) AnnotationOption {
+	return annotationOption(func(a *annotation) {
+		a.text = text
+	})
+}
+
+// AnnotationColor sets the color of the horizontal line, the vertical marker
+// and their text. For a Y band, this is the background color of the band.
+// If not provided, lines and markers use the cell options of the axes and Y
+// bands use DefaultYBandColor.
+func AnnotationColor(c cell.Color) AnnotationOption {
+	return annotationOption(func(a *annotation) {
+		a.color = c
+		a.colorSet = true
+	})
+}
+
+// AnnotationID identifies the annotation, so that it can be removed by
+// calling RemoveAnnotation. Adding an annotation with the same ID as an
+// existing one replaces the existing annotation.
+func AnnotationID(id string) AnnotationOption {
+	return annotationOption(func(a *annotation) {
+		a.id = id
+	})
+}
+
+// AnnotationRightYAxis places the horizontal line or the Y band according
+// to the Y axis on the right side of the graph. See SeriesRightYAxis.
+// Has no effect on vertical markers.
+func AnnotationRightYAxis() AnnotationOption {
+	return annotationOption(func(a *annotation) {
+		a.rightY = true
+	})
+}
+
+// hLine is a horizontal line at a value on the Y axis.
+type hLine struct {
+	annotation
+	value float64
+}
+
+// vMarker is a vertical marker at a position on the X axis.
+type vMarker struct {
+	annotation
+	// x is the position of the value in the series, including the values
+	// dropped from streaming series.
+	x int
+}
+
+// yBand is a shaded band between two values on the Y axis.
+type yBand struct {
+	annotation
+	min, max float64
+}
+
+// HorizontalLine draws a horizontal line across the graph at the specified
+// value on the Y axis, e.g. to indicate a threshold. The text of the line is
+// displayed above its right end. The Y axis is extended to include the
+// value.
+// Can be provided multiple times to draw multiple lines. Lines can also be
+// added after the line chart was created by calling AddHorizontalLine.
+func HorizontalLine(value float64, opts ...AnnotationOption) Option {
+	return option(func(o *options) {
+		o.hLines = append(o.hLines, &hLine{
+			annotation: newAnnotation(opts),
+			value:      value,
+		})
+	})
+}
+
+// VerticalMarker draws a vertical line across the graph at the specified
+// position on the X axis, i.e. the index of a value in the series, e.g. to
+// indicate an event. The text of the marker is displayed at its top.
+// The position of values in streaming series includes the values that were
+// already dropped from the series, so the marker stays at the same value as
+// older values are dropped. See SeriesCapacity.
+// Markers outside of the displayed range on the X axis aren't drawn.
+// Can be provided multiple times to draw multiple markers. Markers can also
+// be added after the line chart was created by calling AddVerticalMarker.
+func VerticalMarker(x int, opts ...AnnotationOption) Option {
+	return option(func(o *options) {
+		o.vMarkers = append(o.vMarkers, &vMarker{
+			annotation: newAnnotation(opts),
+			x:          x,
+		})
+	})
+}
+
+// DefaultYBandColor is the default background color of the Y bands.
+var DefaultYBandColor = cell.ColorNumber(52)
+
+// YBand shades the part of the graph between the min and the max values on
+// the Y axis by setting the background color of the cells. The text of the
+// band is displayed in its top left corner. The Y axis is extended to include
+// both values.
+// Can be provided multiple times to shade multiple bands. Bands can also be
+// added after the line chart was created by calling AddYBand.
+func YBand(min, max float64, opts ...AnnotationOption) Option {
+	return option(func(o *options) {
+		o.yBands = append(o.yBands, newYBand(min, max, opts))
+	})
+}
+
+// newYBand returns a new Y band between the two values.
+func newYBand(min, max float64, opts []AnnotationOption) *yBand {
+	if min > max {
+		min, max = max, min
+	}
+	return &yBand{
+		annotation: newAnnotation(opts),
+		min:        min,
+		max:        max,
+	}
+}
+
+// AddHorizontalLine adds a horizontal line to the line chart.
+// See HorizontalLine for details.
+func (lc *LineChart) AddHorizontalLine(value float64, opts ...AnnotationOption) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.addHLine(&hLine{
+		annotation: newAnnotation(opts),
+		value:      value,
+	})
+	lc.updateYAxes()
+}
+
+// AddVerticalMarker adds a vertical marker to the line chart.
+// See VerticalMarker for details.
+func (lc *LineChart) AddVerticalMarker(x int, opts ...AnnotationOption) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.addVMarker(&vMarker{
+		annotation: newAnnotation(opts),
+		x:          x,
+	})
+}
+
+// AddYBand adds a Y band to the line chart.
+// See YBand for details.
+func (lc *LineChart) AddYBand(min, max float64, opts ...AnnotationOption) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.addYBand(newYBand(min, max, opts))
+	lc.updateYAxes()
+}
+
+// RemoveAnnotation removes the horizontal line, the vertical marker or the Y
+// band with the provided ID. See AnnotationID.
+func (lc *LineChart) RemoveAnnotation(id string) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if !lc.removeAnnotation(id) {
+		return fmt.Errorf("annotation %q doesn't exist", id)
+	}
+	lc.updateYAxes()
+	return nil
+}
+
+// ClearAnnotations removes all the horizontal lines, the vertical markers and
+// the Y bands, including those provided as options to New.
+func (lc *LineChart) ClearAnnotations() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.hLines = nil
+	lc.vMarkers = nil
+	lc.yBands = nil
+	lc.updateYAxes()
+}
+
+// addHLine adds the horizontal line, replacing any annotation with the same
+// ID.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) addHLine(hl *hLine) {
+	lc.removeAnnotation(hl.id)
+	lc.hLines = append(lc.hLines, hl)
+}
+
+// addVMarker adds the vertical marker, replacing any annotation with the
+// same ID.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) addVMarker(vm *vMarker) {
+	lc.removeAnnotation(vm.id)
+	lc.vMarkers = append(lc.vMarkers, vm)
+}
+
+// addYBand adds the Y band, replacing any annotation with the same ID.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) addYBand(yb *yBand) {
+	lc.removeAnnotation(yb.id)
+	lc.yBands = append(lc.yBands, yb)
+}
+
+// removeAnnotation removes the annotation with the provided ID. Annotations
+// without an ID are never removed. Returns true if an annotation was
+// removed.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) removeAnnotation(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	var removed bool
+	var hLines []*hLine
+	for _, hl := range lc.hLines {
+		if hl.id == id {
+			removed = true
+			continue
+		}
+		hLines = append(hLines, hl)
+	}
+	var vMarkers []*vMarker
+	for _, vm := range lc.vMarkers {
+		if vm.id == id {
+			removed = true
+			continue
+		}
+		vMarkers = append(vMarkers, vm)
+	}
+	var yBands []*yBand
+	for _, yb := range lc.yBands {
+		if yb.id == id {
+			removed = true
+			continue
+		}
+		yBands = append(yBands, yb)
+	}
+	lc.hLines, lc.vMarkers, lc.yBands = hLines, vMarkers, yBands
+	return removed
+}
+
+// droppedValues returns the largest number of values dropped from any of the
+// streaming series. Used to place the vertical markers.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) droppedValues() int {
+	var res int
+	for _, sv := range lc.series {
+		if sv.buf != nil && sv.buf.Dropped() > res {
+			res = sv.buf.Dropped()
+		}
+	}
+	return res
+}
+
+// annotationValues returns the values of the horizontal lines and the Y
+// bands placed according to the left or the right Y axis.
+// Annotations placed according to the right Y axis use the left one if the
+// right Y axis isn't drawn.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) annotationValues(right bool) []float64 {
+	hasRight := lc.hasRightYAxis()
+	onSide := func(a *annotation) bool {
+		return (a.rightY && hasRight) == right
+	}
+
+	var res []float64
+	for _, hl := range lc.hLines {
+		if onSide(&hl.annotation) {
+			res = append(res, hl.value)
+		}
+	}
+	for _, yb := range lc.yBands {
+		if onSide(&yb.annotation) {
+			res = append(res, yb.min, yb.max)
+		}
+	}
+	return res
+}
+
+// annotationYDetails returns the details of the Y axis the annotation is
+// placed according to.
+func annotationYDetails(a *annotation, yd, ryd *axes.YDetails) *axes.YDetails {
+	if a.rightY && ryd != nil {
+		return ryd
+	}
+	return yd
+}
+
+// annotationPixelY returns the Y coordinate of the pixel on the braille
+// canvas that represents the value. The value is placed at the closest edge
+// of the graph if it is outside of the range of the Y axis. Returns false if
+// the value cannot be displayed on a logarithmic scale.
+func annotationPixelY(yd *axes.YDetails, v float64) (int, bool, error) {
+	if yd.Scale.Mode == axes.YScaleModeLog10 && v <= 0 {
+		return 0, false, nil
+	}
+	switch {
+	case v >= yd.Scale.Max.Value:
+		return 0, true, nil
+	case v <= yd.Scale.Min.Value:
+		return yd.Scale.GraphHeight*braille.RowMult - 1, true, nil
+	}
+	y, err := yd.Scale.ValueToPixel(v)
+	if err != nil {
+		return 0, false, fmt.Errorf("yd.Scale.ValueToPixel(%v) => %v", v, err)
+	}
+	return y, true, nil
+}
+
+// markerPixelX returns the X coordinate of the pixel on the braille canvas
+// where the vertical marker is drawn. The dropped is the number of values
+// dropped from the streaming series. Returns false if the marker is outside
+// of the displayed range.
+func markerPixelX(xd *axes.XDetails, vm *vMarker, dropped int) (int, bool, error) {
+	i := vm.x - dropped
+	if i < int(xd.Scale.Min.Value) || i > int(xd.Scale.Max.Value) {
+		return 0, false, nil
+	}
+	x, err := xd.Scale.ValueToPixel(i)
+	if err != nil {
+		return 0, false, fmt.Errorf("xd.Scale.ValueToPixel(%v) => %v", i, err)
+	}
+	return x, true, nil
+}
+
+// drawAnnotationLines draws the horizontal lines and the vertical markers
+// onto the braille canvas of the graph.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) drawAnnotationLines(bc *braille.Canvas, xd *axes.XDetails, yd, ryd *axes.YDetails, axesOpts []cell.Option) error {
+	ar := bc.Area()
+	for _, hl := range lc.hLines {
+		y, ok, err := annotationPixelY(annotationYDetails(&hl.annotation, yd, ryd), hl.value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := draw.BrailleLine(bc,
+			image.Point{ar.Min.X, y},
+			image.Point{ar.Max.X - 1, y},
+			draw.BrailleLineCellOpts(hl.cellOpts(axesOpts)...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the horizontal line: %v", err)
+		}
+	}
+
+	dropped := lc.droppedValues()
+	for _, vm := range lc.vMarkers {
+		x, ok, err := markerPixelX(xd, vm, dropped)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := draw.BrailleLine(bc,
+			image.Point{x, ar.Min.Y},
+			image.Point{x, ar.Max.Y - 1},
+			draw.BrailleLineCellOpts(vm.cellOpts(axesOpts)...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the vertical marker: %v", err)
+		}
+	}
+	return nil
+}
+
+// drawYBands shades the Y bands on the canvas. The graphAr is the area of the
+// canvas occupied by the graph.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) drawYBands(cvs *canvas.Canvas, graphAr image.Rectangle, yd, ryd *axes.YDetails) error {
+	for _, yb := range lc.yBands {
+		sd := annotationYDetails(&yb.annotation, yd, ryd)
+		top, ok, err := annotationPixelY(sd, yb.max)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		bottom, ok, err := annotationPixelY(sd, yb.min)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			bottom = sd.Scale.GraphHeight*braille.RowMult - 1
+		}
+
+		color := DefaultYBandColor
+		if yb.colorSet {
+			color = yb.color
+		}
+		for row := top / braille.RowMult; row <= bottom/braille.RowMult; row++ {
+			for x := graphAr.Min.X; x < graphAr.Max.X; x++ {
+				p := image.Point{x, graphAr.Min.Y + row}
+				c, err := cvs.Cell(p)
+				if err != nil {
+					return err
+				}
+				if _, err := cvs.SetCell(p, c.Rune, cell.BgColor(color)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if yb.text != "" {
+			pos := image.Point{graphAr.Min.X, graphAr.Min.Y + top/braille.RowMult}
+			if err := drawAnnotationText(cvs, yb.text, pos, graphAr.Max.X, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drawAnnotationTexts draws the texts of the horizontal lines and the
+// vertical markers. The graphAr is the area of the canvas occupied by the
+// graph.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) drawAnnotationTexts(cvs *canvas.Canvas, graphAr image.Rectangle, xd *axes.XDetails, yd, ryd *axes.YDetails, axesOpts []cell.Option) error {
+	for _, hl := range lc.hLines {
+		if hl.text == "" {
+			continue
+		}
+		y, ok, err := annotationPixelY(annotationYDetails(&hl.annotation, yd, ryd), hl.value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		// Above the line, unless the line is on the top row.
+		row := y/braille.RowMult - 1
+		if row < 0 {
+			row = 0
+		}
+		x := graphAr.Max.X - runewidth.StringWidth(hl.text)
+		if x < graphAr.Min.X {
+			x = graphAr.Min.X
+		}
+		pos := image.Point{x, graphAr.Min.Y + row}
+		if err := drawAnnotationText(cvs, hl.text, pos, graphAr.Max.X, hl.cellOpts(axesOpts)); err != nil {
+			return err
+		}
+	}
+
+	dropped := lc.droppedValues()
+	for _, vm := range lc.vMarkers {
+		if vm.text == "" {
+			continue
+		}
+		x, ok, err := markerPixelX(xd, vm, dropped)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		// Right of the marker, unless it doesn't fit there.
+		lineX := graphAr.Min.X + x/braille.ColMult
+		width := runewidth.StringWidth(vm.text)
+		startX := lineX + 1
+		if startX+width > graphAr.Max.X && lineX-width >= graphAr.Min.X {
+			startX = lineX - width
+		}
+		if startX >= graphAr.Max.X {
+			continue
+		}
+		pos := image.Point{startX, graphAr.Min.Y}
+		if err := drawAnnotationText(cvs, vm.text, pos, graphAr.Max.X, vm.cellOpts(axesOpts)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawAnnotationText draws the text of an annotation at the position,
+// trimming it at maxX.
+func drawAnnotationText(cvs *canvas.Canvas, text string, pos image.Point, maxX int, cOpts []cell.Option) error {
+	if err := draw.Text(cvs, text, pos,
+		draw.TextMaxX(maxX),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cOpts...),
+	); err != nil {
+		return fmt.Errorf("failed to draw the annotation text: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/faketerm"
+)
+
+func TestAnnotations(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		// update is called after the series were set, if not nil.
+		update        func(*LineChart) error
+		wantUpdateErr bool
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc: "horizontal line with a text",
+			opts: []Option{
+				HorizontalLine(75, AnnotationText("SLO"), AnnotationColor(cell.ColorRed)),
+			},
+			golden: "Annotation_hline.golden",
+		},
+		{
+			desc: "horizontal line extends the Y axis",
+			opts: []Option{
+				HorizontalLine(200, AnnotationText("limit")),
+			},
+			golden: "Annotation_hline_extends.golden",
+		},
+		{
+			desc: "vertical markers with texts",
+			opts: []Option{
+				VerticalMarker(1, AnnotationText("deploy"), AnnotationColor(cell.ColorBlue)),
+				VerticalMarker(4, AnnotationText("end")),
+			},
+			golden: "Annotation_vmarker.golden",
+		},
+		{
+			desc: "markers outside of the X axis aren't drawn",
+			opts: []Option{
+				VerticalMarker(10, AnnotationText("later")),
+			},
+			golden: "Annotation_vmarker_outside.golden",
+		},
+		{
+			desc: "Y band with a text",
+			opts: []Option{
+				YBand(90, 60, AnnotationText("danger"), AnnotationColor(cell.ColorRed)),
+			},
+			golden: "Annotation_yband.golden",
+		},
+		{
+			desc: "annotations on the right Y axis",
+			opts: []Option{
+				HorizontalLine(5, AnnotationText("right"), AnnotationRightYAxis()),
+				YBand(0, 2, AnnotationRightYAxis()),
+			},
+			golden: "Annotation_right.golden",
+		},
+		{
+			desc: "annotations added after the line chart was created",
+			update: func(lc *LineChart) error {
+				lc.AddHorizontalLine(75, AnnotationText("SLO"), AnnotationColor(cell.ColorRed))
+				return nil
+			},
+			golden: "Annotation_hline.golden",
+		},
+		{
+			desc: "markers and bands added after the line chart was created",
+			update: func(lc *LineChart) error {
+				lc.AddYBand(90, 60, AnnotationText("danger"), AnnotationColor(cell.ColorRed))
+				lc.AddVerticalMarker(10, AnnotationText("later"))
+				return nil
+			},
+			golden: "Annotation_yband.golden",
+		},
+		{
+			desc: "removed annotation isn't drawn and no longer extends the Y axis",
+			opts: []Option{
+				HorizontalLine(75, AnnotationText("SLO"), AnnotationColor(cell.ColorRed)),
+				HorizontalLine(200, AnnotationText("limit"), AnnotationID("limit")),
+			},
+			update: func(lc *LineChart) error {
+				return lc.RemoveAnnotation("limit")
+			},
+			golden: "Annotation_hline.golden",
+		},
+		{
+			desc: "annotation with the same ID replaces the existing one",
+			opts: []Option{
+				HorizontalLine(200, AnnotationText("limit"), AnnotationID("slo")),
+			},
+			update: func(lc *LineChart) error {
+				lc.AddHorizontalLine(75, AnnotationText("SLO"), AnnotationColor(cell.ColorRed), AnnotationID("slo"))
+				return nil
+			},
+			golden: "Annotation_hline.golden",
+		},
+		{
+			desc: "fails to remove an annotation that doesn't exist",
+			opts: []Option{
+				HorizontalLine(75, AnnotationText("SLO"), AnnotationColor(cell.ColorRed), AnnotationID("slo")),
+			},
+			update: func(lc *LineChart) error {
+				return lc.RemoveAnnotation("limit")
+			},
+			wantUpdateErr: true,
+			golden:        "Annotation_hline.golden",
+		},
+		{
+			desc: "clear removes all the annotations",
+			opts: []Option{
+				HorizontalLine(200, AnnotationText("limit")),
+				VerticalMarker(1, AnnotationText("deploy")),
+				YBand(90, 60, AnnotationText("danger")),
+			},
+			update: func(lc *LineChart) error {
+				lc.ClearAnnotations()
+				return nil
+			},
+			golden: "Annotation_none.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc := New(tc.opts...)
+			if err := lc.Series("first", []float64{0, 50, 100, 20, 60}); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			if err := lc.Series("second", []float64{10, 0, 5, 2}, SeriesRightYAxis()); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			if tc.update != nil {
+				err := tc.update(lc)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+			}
+
+			cvs, err := canvas.New(image.Rect(0, 0, 30, 12))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := lc.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestVerticalMarkerFollowsStreamingSeries verifies that a vertical marker
+// stays at the same value when older values are dropped from a streaming
+// series.
+func TestVerticalMarkerFollowsStreamingSeries(t *testing.T) {
+	size := image.Point{30, 12}
+
+	streaming := New(VerticalMarker(3, AnnotationText("deploy")))
+	if err := streaming.Series("first", []float64{0, 50, 100, 20}, SeriesCapacity(5)); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	for _, v := range []float64{60, 30, 80} {
+		if err := streaming.AppendPoint("first", v); err != nil {
+			t.Fatalf("AppendPoint => unexpected error: %v", err)
+		}
+	}
+
+	// The streaming series dropped the first two values, the marker stays at
+	// the value 20.
+	static := New(VerticalMarker(1, AnnotationText("deploy")))
+	if err := static.Series("first", []float64{100, 20, 60, 30, 80}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	var terms []*faketerm.Terminal
+	for _, lc := range []*LineChart{static, streaming} {
+		cvs, err := canvas.New(image.Rectangle{Max: size})
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := lc.Draw(cvs); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		term := faketerm.MustNew(size)
+		if err := cvs.Apply(term); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		terms = append(terms, term)
+	}
+	if diff := faketerm.Diff(terms[0], terms[1]); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
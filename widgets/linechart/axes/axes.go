@@ -18,6 +18,7 @@ package axes
 import (
 	"fmt"
 	"image"
+	"math"
 )
 
 const (
@@ -30,12 +31,44 @@ const (
 	yAxisWidth = 1
 )
 
+// YPlacement determines the side of the graph where the Y axis is placed.
+type YPlacement int
+
+// String implements fmt.Stringer()
+func (yp YPlacement) String() string {
+	if n, ok := yPlacementNames[yp]; ok {
+		return n
+	}
+	return "YPlacementUnknown"
+}
+
+// yPlacementNames maps YPlacement values to human readable names.
+var yPlacementNames = map[YPlacement]string{
+	YPlacementLeft:  "YPlacementLeft",
+	YPlacementRight: "YPlacementRight",
+}
+
+// Supported Y axis placements.
+const (
+	// YPlacementLeft places the Y axis on the left side of the graph, the
+	// labels are to the left of the axis and aligned to the right.
+	YPlacementLeft YPlacement = iota
+
+	// YPlacementRight places the Y axis on the right side of the graph at
+	// the edge of the canvas, the labels are to the right of the axis and
+	// aligned to the left.
+	YPlacementRight
+)
+
 // YDetails contain information about the Y axis that will be drawn onto the
 // canvas.
 type YDetails struct {
 	// Width in character cells of the Y axis and its character labels.
 	Width int
 
+	// Placement is the side of the graph where the Y axis is placed.
+	Placement YPlacement
+
 	// Start is the point where the Y axis starts.
 	// Both coordinates of Start are less than End.
 	Start image.Point
@@ -57,11 +90,13 @@ type Y struct {
 	min *Value
 	// max is the largest value on the Y axis.
 	max *Value
+	// placement is the side of the graph where the Y axis is placed.
+	placement YPlacement
 	// details about the Y axis as it will be drawn.
 	details *YDetails
 }
 
-// NewY returns a new Y instance.
+// NewY returns a new Y instance placed on the left side of the graph.
 // The minVal and maxVal represent the minimum and maximum value that will be
 // displayed on the line chart among all of the series.
 func NewY(minVal, maxVal float64) *Y {
@@ -70,34 +105,54 @@ func NewY(minVal, maxVal float64) *Y {
 	return y
 }
 
+// NewRightY is like NewY, but the Y axis is placed on the right side of the
+// graph at the edge of the canvas.
+func NewRightY(minVal, maxVal float64) *Y {
+	y := NewY(minVal, maxVal)
+	y.placement = YPlacementRight
+	return y
+}
+
 // Update updates the stored minVal and maxVal.
 func (y *Y) Update(minVal, maxVal float64) {
 	y.min, y.max = NewValue(minVal, nonZeroDecimals), NewValue(maxVal, nonZeroDecimals)
 }
 
-// RequiredWidth calculates the minimum width required in order to draw the Y axis.
-func (y *Y) RequiredWidth() int {
+// RequiredWidth calculates the minimum width required in order to draw the Y
+// axis in the scale mode.
+func (y *Y) RequiredWidth(mode YScaleMode) int {
+	min, max := y.min, y.max
+	if mode == YScaleModeLog10 && min.Value > 0 {
+		// The scale extends to the powers of ten around the values, these
+		// are the outermost labels.
+		minExp, maxExp := logExps(min.Value, max.Value)
+		min = NewValue(math.Pow(10, minExp), nonZeroDecimals)
+		max = NewValue(math.Pow(10, maxExp), nonZeroDecimals)
+	}
+
 	// This is an estimation only, it is possible that more labels in the
 	// middle will be generated and might be wider than this. Such cases are
 	// handled on the call to Details when the size of canvas is known.
 	return widestLabel([]*Label{
-		{Value: y.min},
-		{Value: y.max},
+		{Value: min},
+		{Value: max},
 	}) + yAxisWidth
 }
 
 // Details retrieves details about the Y axis required to draw it on a canvas
-// of the provided area.
-func (y *Y) Details(cvsAr image.Rectangle) (*YDetails, error) {
+// of the provided area. The mode determines how the axis scales to the
+// values. An axis placed on the right is positioned at the right edge of the
+// provided area.
+func (y *Y) Details(cvsAr image.Rectangle, mode YScaleMode) (*YDetails, error) {
 	cvsWidth := cvsAr.Dx()
 	cvsHeight := cvsAr.Dy()
 	maxWidth := cvsWidth - 1 // Reserve one row for the line chart itself.
-	if req := y.RequiredWidth(); maxWidth < req {
+	if req := y.RequiredWidth(mode); maxWidth < req {
 		return nil, fmt.Errorf("the received maxWidth %d is smaller than the reported required width %d", maxWidth, req)
 	}
 
 	graphHeight := cvsHeight - 2 // One row for the X axis and one for its labels.
-	scale, err := NewYScale(y.min.Value, y.max.Value, graphHeight, nonZeroDecimals)
+	scale, err := NewYScale(y.min.Value, y.max.Value, graphHeight, nonZeroDecimals, mode)
 	if err != nil {
 		return nil, err
 	}
@@ -125,12 +180,21 @@ func (y *Y) Details(cvsAr image.Rectangle) (*YDetails, error) {
 		width = maxWidth
 	}
 
+	axisX := width - 1
+	if y.placement == YPlacementRight {
+		axisX = cvsAr.Max.X - width
+		// The labels are next to the axis, aligned to the left.
+		for _, l := range labels {
+			l.Pos.X = axisX + yAxisWidth
+		}
+	}
 	return &YDetails{
-		Width:  width,
-		Start:  image.Point{width - 1, 0},
-		End:    image.Point{width - 1, graphHeight},
-		Scale:  scale,
-		Labels: labels,
+		Width:     width,
+		Placement: y.placement,
+		Start:     image.Point{axisX, 0},
+		End:       image.Point{axisX, graphHeight},
+		Scale:     scale,
+		Labels:    labels,
 	}, nil
 }
 
@@ -168,13 +232,26 @@ type XDetails struct {
 // customLabels are the desired labels for the X axis, these are preferred if
 // provided.
 func NewXDetails(numPoints int, yStart image.Point, cvsAr image.Rectangle, customLabels map[int]string) (*XDetails, error) {
+	if numPoints < 0 {
+		return nil, fmt.Errorf("numPoints cannot be negative, got %d", numPoints)
+	}
+	max := numPoints - 1
+	if max < 0 {
+		max = 0
+	}
+	return NewXDetailsRange(0, max, yStart, cvsAr, customLabels)
+}
+
+// NewXDetailsRange is like NewXDetails, but the X axis only displays the
+// values (positions in the series) in the range min <= v <= max.
+func NewXDetailsRange(min, max int, yStart image.Point, cvsAr image.Rectangle, customLabels map[int]string) (*XDetails, error) {
 	if min := 3; cvsAr.Dy() < min {
 		return nil, fmt.Errorf("the canvas isn't tall enough to accommodate the X axis, its labels and the line chart, got height %d, minimum is %d", cvsAr.Dy(), min)
 	}
 
 	// The space between the start of the axis and the end of the canvas.
 	graphWidth := cvsAr.Dx() - yStart.X - 1
-	scale, err := NewXScale(numPoints, graphWidth, nonZeroDecimals)
+	scale, err := NewXScaleRange(min, max, graphWidth, nonZeroDecimals)
 	if err != nil {
 		return nil, err
 	}
@@ -193,3 +270,33 @@ func NewXDetails(numPoints int, yStart image.Point, cvsAr image.Rectangle, custo
 		Labels: labels,
 	}, nil
 }
+
+// NewXDetailsValues is like NewXDetailsRange, but the X axis displays
+// arbitrary values in the range min <= v <= max instead of positions in a
+// series. This is used when plotting pairs of values.
+func NewXDetailsValues(min, max float64, yStart image.Point, cvsAr image.Rectangle) (*XDetails, error) {
+	if min := 3; cvsAr.Dy() < min {
+		return nil, fmt.Errorf("the canvas isn't tall enough to accommodate the X axis, its labels and the graph, got height %d, minimum is %d", cvsAr.Dy(), min)
+	}
+
+	// The space between the start of the axis and the end of the canvas.
+	graphWidth := cvsAr.Dx() - yStart.X - 1
+	scale, err := NewXScaleValues(min, max, graphWidth, nonZeroDecimals)
+	if err != nil {
+		return nil, err
+	}
+
+	// One point horizontally for the Y axis.
+	// Two points vertically, one for the X axis and one for its labels.
+	graphZero := image.Point{yStart.X + 1, cvsAr.Dy() - 3}
+	labels, err := xValueLabels(scale, graphZero)
+	if err != nil {
+		return nil, err
+	}
+	return &XDetails{
+		Start:  image.Point{yStart.X, cvsAr.Dy() - 2}, // One row for the labels.
+		End:    image.Point{yStart.X + graphWidth, cvsAr.Dy() - 2},
+		Scale:  scale,
+		Labels: labels,
+	}, nil
+}
@@ -32,6 +32,8 @@ func TestY(t *testing.T) {
 		minVal    float64
 		maxVal    float64
 		update    *updateY
+		right     bool
+		mode      YScaleMode
 		cvsAr     image.Rectangle
 		wantWidth int
 		want      *YDetails
@@ -71,7 +73,7 @@ func TestY(t *testing.T) {
 				Width: 2,
 				Start: image.Point{1, 0},
 				End:   image.Point{1, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals),
+				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
 				Labels: []*Label{
 					{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
 					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
@@ -88,7 +90,7 @@ func TestY(t *testing.T) {
 				Width: 5,
 				Start: image.Point{4, 0},
 				End:   image.Point{4, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals),
+				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
 				Labels: []*Label{
 					{NewValue(0, nonZeroDecimals), image.Point{3, 1}},
 					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
@@ -105,28 +107,97 @@ func TestY(t *testing.T) {
 				Width: 5,
 				Start: image.Point{4, 0},
 				End:   image.Point{4, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals),
+				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
 				Labels: []*Label{
 					{NewValue(0, nonZeroDecimals), image.Point{3, 1}},
 					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
 				},
 			},
 		},
+		{
+			desc:      "right axis is placed at the edge of the canvas",
+			minVal:    0,
+			maxVal:    3,
+			right:     true,
+			cvsAr:     image.Rect(0, 0, 10, 4),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:     5,
+				Placement: YPlacementRight,
+				Start:     image.Point{5, 0},
+				End:       image.Point{5, 2},
+				Scale:     mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
+				Labels: []*Label{
+					{NewValue(0, nonZeroDecimals), image.Point{6, 1}},
+					{NewValue(1.72, nonZeroDecimals), image.Point{6, 0}},
+				},
+			},
+		},
+		{
+			desc:      "right axis is placed at the edge of an offset canvas area",
+			minVal:    0,
+			maxVal:    3,
+			right:     true,
+			cvsAr:     image.Rect(4, 0, 14, 4),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:     5,
+				Placement: YPlacementRight,
+				Start:     image.Point{9, 0},
+				End:       image.Point{9, 2},
+				Scale:     mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
+				Labels: []*Label{
+					{NewValue(0, nonZeroDecimals), image.Point{10, 1}},
+					{NewValue(1.72, nonZeroDecimals), image.Point{10, 0}},
+				},
+			},
+		},
+		{
+			desc:      "log scale requires the width of the powers of ten around the values",
+			minVal:    1,
+			maxVal:    2,
+			mode:      YScaleModeLog10,
+			cvsAr:     image.Rect(0, 0, 3, 4),
+			wantWidth: 3,
+			wantErr:   true,
+		},
+		{
+			desc:      "right axis, cvsWidth equals required width",
+			minVal:    0,
+			maxVal:    3,
+			right:     true,
+			cvsAr:     image.Rect(0, 0, 3, 4),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:     2,
+				Placement: YPlacementRight,
+				Start:     image.Point{1, 0},
+				End:       image.Point{1, 2},
+				Scale:     mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
+				Labels: []*Label{
+					{NewValue(0, nonZeroDecimals), image.Point{2, 1}},
+					{NewValue(1.72, nonZeroDecimals), image.Point{2, 0}},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
 			y := NewY(tc.minVal, tc.maxVal)
+			if tc.right {
+				y = NewRightY(tc.minVal, tc.maxVal)
+			}
 			if tc.update != nil {
 				y.Update(tc.update.minVal, tc.update.maxVal)
 			}
 
-			gotWidth := y.RequiredWidth()
+			gotWidth := y.RequiredWidth(tc.mode)
 			if gotWidth != tc.wantWidth {
 				t.Errorf("RequiredWidth => got %v, want %v", gotWidth, tc.wantWidth)
 			}
 
-			got, err := y.Details(tc.cvsAr)
+			got, err := y.Details(tc.cvsAr, tc.mode)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Details => unexpected error: %v, wantErr: %v", err, tc.wantErr)
 			}
@@ -224,3 +295,141 @@ func TestNewXDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestNewXDetailsRange(t *testing.T) {
+	tests := []struct {
+		desc    string
+		min     int
+		max     int
+		yStart  image.Point
+		cvsAr   image.Rectangle
+		want    *XDetails
+		wantErr bool
+	}{
+		{
+			desc:    "fails when max is less than min",
+			min:     2,
+			max:     1,
+			yStart:  image.Point{0, 0},
+			cvsAr:   image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc:   "labels start at the minimum",
+			min:    5,
+			max:    6,
+			yStart: image.Point{0, 0},
+			cvsAr:  image.Rect(0, 0, 6, 3),
+			want: &XDetails{
+				Start: image.Point{0, 1},
+				End:   image.Point{5, 1},
+				Scale: func() *XScale {
+					s, err := NewXScaleRange(5, 6, 5, nonZeroDecimals)
+					if err != nil {
+						panic(err)
+					}
+					return s
+				}(),
+				Labels: []*Label{
+					{
+						Value: NewValue(5, nonZeroDecimals),
+						Pos:   image.Point{1, 2},
+					},
+					{
+						Value: NewValue(6, nonZeroDecimals),
+						Pos:   image.Point{5, 2},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewXDetailsRange(tc.min, tc.max, tc.yStart, tc.cvsAr, nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewXDetailsRange => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewXDetailsRange => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewXDetailsValues(t *testing.T) {
+	tests := []struct {
+		desc    string
+		min     float64
+		max     float64
+		yStart  image.Point
+		cvsAr   image.Rectangle
+		want    *XDetails
+		wantErr bool
+	}{
+		{
+			desc:    "fails when max is less than min",
+			min:     2,
+			max:     1,
+			yStart:  image.Point{0, 0},
+			cvsAr:   image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc:    "fails when the canvas is too short",
+			min:     0,
+			max:     1,
+			yStart:  image.Point{0, 0},
+			cvsAr:   image.Rect(0, 0, 10, 2),
+			wantErr: true,
+		},
+		{
+			desc:   "labels with fractional values",
+			min:    0,
+			max:    1,
+			yStart: image.Point{0, 0},
+			cvsAr:  image.Rect(0, 0, 11, 3),
+			want: &XDetails{
+				Start: image.Point{0, 1},
+				End:   image.Point{10, 1},
+				Scale: func() *XScale {
+					s, err := NewXScaleValues(0, 1, 10, nonZeroDecimals)
+					if err != nil {
+						panic(err)
+					}
+					return s
+				}(),
+				Labels: []*Label{
+					{
+						Value: NewValue(0, nonZeroDecimals),
+						Pos:   image.Point{1, 2},
+					},
+					{
+						Value: NewValue(0.53, nonZeroDecimals),
+						Pos:   image.Point{6, 2},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewXDetailsValues(tc.min, tc.max, tc.yStart, tc.cvsAr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewXDetailsValues => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewXDetailsValues => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,8 +19,11 @@ package axes
 import (
 	"fmt"
 	"image"
+	"math"
 
 	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/numbers"
 )
 
 // Label is one value label on an axis.
@@ -47,6 +50,9 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 	if min := 1; labelWidth < min {
 		return nil, fmt.Errorf("cannot place labels in label area width %d, minimum is %d", labelWidth, min)
 	}
+	if scale.Mode == YScaleModeLog10 {
+		return yLogLabels(scale, labelWidth)
+	}
 
 	var labels []*Label
 	const labelSpacing = 4
@@ -75,6 +81,41 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 	return labels, nil
 }
 
+// yLogLabels returns labels that should be placed next to the Y axis on the
+// YScaleModeLog10 scale. The labels are placed at the rows that contain the
+// powers of ten. Labels that would share a row with a previous label are
+// skipped.
+func yLogLabels(scale *YScale, labelWidth int) ([]*Label, error) {
+	minExp := int(numbers.Round(math.Log10(scale.Min.Value)))
+	maxExp := int(numbers.Round(math.Log10(scale.Max.Value)))
+
+	var labels []*Label
+	lastRow := -1
+	for exp := minExp; exp <= maxExp; exp++ {
+		v := NewValue(math.Pow(10, float64(exp)), scale.Min.NonZeroDecimals)
+		pixelY, err := scale.ValueToPixel(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the row for label %v: %v", v, err)
+		}
+		row := pixelY / braille.RowMult
+		if row == lastRow {
+			continue
+		}
+		lastRow = row
+
+		ar := rowLabelArea(row, labelWidth)
+		pos, err := align.Text(ar, v.Text(), align.HorizontalRight, align.VerticalMiddle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to align the label value: %v", err)
+		}
+		labels = append(labels, &Label{
+			Value: v,
+			Pos:   pos,
+		})
+	}
+	return labels, nil
+}
+
 // rowLabelArea determines the area available for labels on the specified row.
 // The row is the Y coordinate of the row, Y coordinates grow down.
 func rowLabelArea(row int, labelWidth int) image.Rectangle {
@@ -167,8 +208,9 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string)
 	const minSpacing = 3
 	var res []*Label
 
-	next := 0
-	for haveLabels := 0; haveLabels <= int(scale.Max.Value); haveLabels = len(res) {
+	first, last := int(scale.Min.Value), int(scale.Max.Value)
+	next := first
+	for haveLabels := 0; haveLabels <= last-first; haveLabels = len(res) {
 		label, err := colLabel(scale, space, next, customLabels)
 		if err != nil {
 			return nil, err
@@ -179,7 +221,7 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string)
 		res = append(res, label)
 
 		next++
-		if next > int(scale.Max.Value) {
+		if next > last {
 			break
 		}
 		nextCell, err := scale.ValueToCell(next)
@@ -202,6 +244,39 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string)
 	return res, nil
 }
 
+// xValueLabels returns labels that should be placed under the X axis of a
+// scale created by NewXScaleValues. The labels are placed from the left with
+// at least the same spacing as on the Y axis.
+// The graphZero is the (0, 0) point of the graph area on the canvas.
+// Labels are returned in an increasing value order.
+func xValueLabels(scale *XScale, graphZero image.Point) ([]*Label, error) {
+	space := newXSpace(graphZero, scale.GraphWidth)
+	const minSpacing = 4
+	var res []*Label
+	seen := map[string]bool{}
+	for {
+		label, err := colLabel(scale, space, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		if label == nil {
+			break
+		}
+		if !seen[label.Value.Text()] {
+			res = append(res, label)
+			seen[label.Value.Text()] = true
+		}
+
+		if space.Remaining() <= minSpacing {
+			break
+		}
+		if err := space.Sub(minSpacing); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 // colLabel returns a label placed either at the beginning of the space.
 // The space is adjusted according to how much space was taken by the label.
 // Returns nil, nil if the label doesn't fit in the space.
@@ -28,6 +28,7 @@ func TestYLabels(t *testing.T) {
 		min         float64
 		max         float64
 		graphHeight int
+		mode        YScaleMode
 		labelWidth  int
 		want        []*Label
 		wantErr     bool
@@ -125,11 +126,49 @@ func TestYLabels(t *testing.T) {
 				{NewValue(4.16, nonZeroDecimals), image.Point{0, 1}},
 			},
 		},
+		{
+			desc:        "adaptive scale, labels start at the minimum",
+			min:         100,
+			max:         105,
+			graphHeight: 5,
+			mode:        YScaleModeAdaptive,
+			labelWidth:  3,
+			want: []*Label{
+				{NewValue(100, nonZeroDecimals), image.Point{0, 4}},
+				{NewValue(104.32, nonZeroDecimals), image.Point{0, 0}},
+			},
+		},
+		{
+			desc:        "log scale, labels at powers of ten",
+			min:         1,
+			max:         1000,
+			graphHeight: 6,
+			mode:        YScaleModeLog10,
+			labelWidth:  4,
+			want: []*Label{
+				{NewValue(1, nonZeroDecimals), image.Point{3, 5}},
+				{NewValue(10, nonZeroDecimals), image.Point{2, 3}},
+				{NewValue(100, nonZeroDecimals), image.Point{1, 2}},
+				{NewValue(1000, nonZeroDecimals), image.Point{0, 0}},
+			},
+		},
+		{
+			desc:        "log scale, skips labels that share a row",
+			min:         1,
+			max:         1000,
+			graphHeight: 2,
+			mode:        YScaleModeLog10,
+			labelWidth:  4,
+			want: []*Label{
+				{NewValue(1, nonZeroDecimals), image.Point{3, 1}},
+				{NewValue(100, nonZeroDecimals), image.Point{1, 0}},
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			scale, err := NewYScale(tc.min, tc.max, tc.graphHeight, nonZeroDecimals)
+			scale, err := NewYScale(tc.min, tc.max, tc.graphHeight, nonZeroDecimals, tc.mode)
 			if err != nil {
 				t.Fatalf("NewYScale => unexpected error: %v", err)
 			}
@@ -151,8 +190,11 @@ func TestYLabels(t *testing.T) {
 func TestXLabels(t *testing.T) {
 	const nonZeroDecimals = 2
 	tests := []struct {
-		desc         string
-		numPoints    int
+		desc      string
+		numPoints int
+		// first is the first value displayed on the axis, non-zero when the
+		// line chart is zoomed.
+		first        int
 		graphWidth   int
 		graphZero    image.Point
 		customLabels map[int]string
@@ -301,13 +343,41 @@ func TestXLabels(t *testing.T) {
 				{NewValue(72, nonZeroDecimals), image.Point{4, 3}},
 			},
 		},
+		{
+			desc:       "zoomed into a range of points",
+			numPoints:  4,
+			first:      10,
+			graphWidth: 100,
+			graphZero:  image.Point{0, 1},
+			want: []*Label{
+				{NewValue(10, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(11, nonZeroDecimals), image.Point{31, 3}},
+				{NewValue(12, nonZeroDecimals), image.Point{62, 3}},
+				{NewValue(13, nonZeroDecimals), image.Point{94, 3}},
+			},
+		},
+		{
+			desc:       "zoomed into a range of points with custom labels",
+			numPoints:  2,
+			first:      10,
+			graphWidth: 100,
+			graphZero:  image.Point{0, 1},
+			customLabels: map[int]string{
+				0:  "a",
+				11: "b",
+			},
+			want: []*Label{
+				{NewValue(10, nonZeroDecimals), image.Point{0, 3}},
+				{NewTextValue("b"), image.Point{98, 3}},
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			scale, err := NewXScale(tc.numPoints, tc.graphWidth, nonZeroDecimals)
+			scale, err := NewXScaleRange(tc.first, tc.first+tc.numPoints-1, tc.graphWidth, nonZeroDecimals)
 			if err != nil {
-				t.Fatalf("NewXScale => unexpected error: %v", err)
+				t.Fatalf("NewXScaleRange => unexpected error: %v", err)
 			}
 			t.Logf("scale step: %v", scale.Step.Rounded)
 			got, err := xLabels(scale, tc.graphZero, tc.customLabels)
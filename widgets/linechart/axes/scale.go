@@ -18,11 +18,48 @@ package axes
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/mum4k/termdash/canvas/braille"
 	"github.com/mum4k/termdash/numbers"
 )
 
+// YScaleMode determines how the Y axis scales to the values.
+type YScaleMode int
+
+// String implements fmt.Stringer()
+func (ysm YScaleMode) String() string {
+	if n, ok := yScaleModeNames[ysm]; ok {
+		return n
+	}
+	return "YScaleModeUnknown"
+}
+
+// yScaleModeNames maps YScaleMode values to human readable names.
+var yScaleModeNames = map[YScaleMode]string{
+	YScaleModeAnchored: "YScaleModeAnchored",
+	YScaleModeAdaptive: "YScaleModeAdaptive",
+	YScaleModeLog10:    "YScaleModeLog10",
+}
+
+// Supported Y scale modes.
+const (
+	// YScaleModeAnchored is a linear scale that always includes the zero
+	// value. I.e. the axis starts at zero if all the values are positive and
+	// ends at zero if all the values are negative.
+	YScaleModeAnchored YScaleMode = iota
+
+	// YScaleModeAdaptive is a linear scale that starts at the minimum and
+	// ends at the maximum value.
+	YScaleModeAdaptive
+
+	// YScaleModeLog10 is a logarithmic scale with base 10. The axis starts at
+	// the closest power of ten smaller or equal to the minimum value and ends
+	// at the closest power of ten larger or equal to the maximum value.
+	// Only positive values can be displayed on this scale.
+	YScaleModeLog10
+)
+
 // YScale is the scale of the Y axis.
 type YScale struct {
 	// Min is the minimum value on the axis.
@@ -30,7 +67,11 @@ type YScale struct {
 	// Max is the maximum value on the axis.
 	Max *Value
 	// Step is the step in the value between pixels.
+	// On the YScaleModeLog10 scale, this is the step in the exponent of the
+	// value.
 	Step *Value
+	// Mode is the mode of the scale.
+	Mode YScaleMode
 
 	// GraphHeight is the height in cells of the area on the canvas that is
 	// dedicated to the graph itself.
@@ -43,8 +84,8 @@ type YScale struct {
 // the height of the graph. The nonZeroDecimals dictates rounding of the
 // calculated scale, see NewValue for details.
 // Max must be greater or equal to min. The graphHeight must be a positive
-// number.
-func NewYScale(min, max float64, graphHeight, nonZeroDecimals int) (*YScale, error) {
+// number. Both min and max must be positive in the YScaleModeLog10 mode.
+func NewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMode) (*YScale, error) {
 	if max < min {
 		return nil, fmt.Errorf("max(%v) cannot be less than min(%v)", max, min)
 	}
@@ -55,23 +96,61 @@ func NewYScale(min, max float64, graphHeight, nonZeroDecimals int) (*YScale, err
 	brailleHeight := graphHeight * braille.RowMult
 	usablePixels := brailleHeight - 1 // One pixel reserved for value zero.
 
-	if min > 0 { // If we only have positive data points, make the scale zero based (min).
-		min = 0
-	}
-	if max < 0 { // If we only have negative data points, make the scale zero based (max).
-		max = 0
+	switch mode {
+	case YScaleModeAnchored:
+		if min > 0 { // If we only have positive data points, make the scale zero based (min).
+			min = 0
+		}
+		if max < 0 { // If we only have negative data points, make the scale zero based (max).
+			max = 0
+		}
+
+	case YScaleModeAdaptive:
+		if min == max { // Nothing to adapt to, use the anchored scale.
+			return NewYScale(min, max, graphHeight, nonZeroDecimals, YScaleModeAnchored)
+		}
+
+	case YScaleModeLog10:
+		if min <= 0 {
+			return nil, fmt.Errorf("the values must be positive on the %v scale, got min(%v)", mode, min)
+		}
+		minExp, maxExp := logExps(min, max)
+		return &YScale{
+			Min:           NewValue(math.Pow(10, minExp), nonZeroDecimals),
+			Max:           NewValue(math.Pow(10, maxExp), nonZeroDecimals),
+			Step:          NewValue((maxExp-minExp)/float64(usablePixels), nonZeroDecimals),
+			Mode:          mode,
+			GraphHeight:   graphHeight,
+			brailleHeight: brailleHeight,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported Y scale mode %v", mode)
 	}
+
 	diff := max - min
 	step := NewValue(diff/float64(usablePixels), nonZeroDecimals)
 	return &YScale{
 		Min:           NewValue(min, nonZeroDecimals),
 		Max:           NewValue(max, nonZeroDecimals),
 		Step:          step,
+		Mode:          mode,
 		GraphHeight:   graphHeight,
 		brailleHeight: brailleHeight,
 	}, nil
 }
 
+// logExps returns the exponents of the powers of ten the YScaleModeLog10
+// scale extends to, so that it contains the positive min and max values.
+func logExps(min, max float64) (minExp, maxExp float64) {
+	minExp = math.Floor(math.Log10(min))
+	maxExp = math.Ceil(math.Log10(max))
+	if maxExp == minExp {
+		maxExp++
+	}
+	return minExp, maxExp
+}
+
 // PixelToValue given a Y coordinate of the pixel, returns its value according
 // to the scale. The coordinate must be within bounds of the graph height
 // provided to NewYScale. Y coordinates grow down.
@@ -86,30 +165,32 @@ func (ys *YScale) PixelToValue(y int) (float64, error) {
 		return ys.Min.Rounded, nil
 	case pos == ys.brailleHeight-1:
 		return ys.Max.Rounded, nil
+	case ys.Mode == YScaleModeLog10:
+		// The unrounded step, rounding errors grow exponentially.
+		return math.Pow(10, math.Log10(ys.Min.Value)+float64(pos)*ys.Step.Value), nil
 	default:
-		v := float64(pos) * ys.Step.Rounded
-		if ys.Min.Value < 0 {
-			diff := -1 * ys.Min.Value
-			v -= diff
-		}
-		return v, nil
+		return ys.Min.Value + float64(pos)*ys.Step.Rounded, nil
 	}
 }
 
 // ValueToPixel given a value, determines the Y coordinate of the pixel that
 // most closely represents the value on the line chart according to the scale.
 // The value must be within the bounds provided to NewYScale. Y coordinates
-// grow down.
+// grow down. Only positive values can be converted on the YScaleModeLog10
+// scale.
 func (ys *YScale) ValueToPixel(v float64) (int, error) {
-	if ys.Step.Rounded == 0 {
-		return 0, nil
+	if ys.Mode == YScaleModeLog10 {
+		if v <= 0 {
+			return 0, fmt.Errorf("the value must be positive on the %v scale, got %v", ys.Mode, v)
+		}
+		pos := int(numbers.Round((math.Log10(v) - math.Log10(ys.Min.Value)) / ys.Step.Value))
+		return positionToY(pos, ys.brailleHeight)
 	}
 
-	if ys.Min.Value < 0 {
-		diff := -1 * ys.Min.Value
-		v += diff
+	if ys.Step.Rounded == 0 {
+		return 0, nil
 	}
-	pos := int(numbers.Round(v / ys.Step.Rounded))
+	pos := int(numbers.Round((v - ys.Min.Value) / ys.Step.Rounded))
 	return positionToY(pos, ys.brailleHeight)
 }
 
@@ -148,6 +229,9 @@ type XScale struct {
 	GraphWidth int
 	// brailleWidth is the width of the braille canvas based on the GraphWidth.
 	brailleWidth int
+	// continuous indicates that the scale displays arbitrary values instead of
+	// positions in a series, labels of such scale aren't rounded to integers.
+	continuous bool
 }
 
 // NewXScale calculates the scale of the X axis, given the number of data
@@ -160,26 +244,65 @@ func NewXScale(numPoints int, graphWidth, nonZeroDecimals int) (*XScale, error)
 	if numPoints < 0 {
 		return nil, fmt.Errorf("numPoints cannot be negative, got %d", numPoints)
 	}
-	if min := 1; graphWidth < min {
-		return nil, fmt.Errorf("graphWidth must be at least %d, got %d", min, graphWidth)
+	max := numPoints - 1
+	if max < 0 {
+		max = 0
+	}
+	return NewXScaleRange(0, max, graphWidth, nonZeroDecimals)
+}
+
+// NewXScaleRange is like NewXScale, but the X axis only displays the values
+// (positions in the series) in the range min <= v <= max. This is used when
+// the line chart is zoomed.
+// The min must be zero or positive number and max must be greater or equal to
+// min. The graphWidth must be a positive number.
+func NewXScaleRange(min, max int, graphWidth, nonZeroDecimals int) (*XScale, error) {
+	if min < 0 {
+		return nil, fmt.Errorf("min cannot be negative, got %d", min)
+	}
+	if max < min {
+		return nil, fmt.Errorf("max(%d) cannot be less than min(%d)", max, min)
+	}
+	if minWidth := 1; graphWidth < minWidth {
+		return nil, fmt.Errorf("graphWidth must be at least %d, got %d", minWidth, graphWidth)
 	}
 
 	brailleWidth := graphWidth * braille.ColMult
 	usablePixels := brailleWidth - 1 // One pixel reserved for value zero.
 
-	const min float64 = 0
-	max := float64(numPoints - 1)
-	if max < 0 {
-		max = 0
-	}
-	diff := max - min
+	diff := float64(max - min)
 	step := NewValue(diff/float64(usablePixels), nonZeroDecimals)
+	return &XScale{
+		Min:          NewValue(float64(min), nonZeroDecimals),
+		Max:          NewValue(float64(max), nonZeroDecimals),
+		Step:         step,
+		GraphWidth:   graphWidth,
+		brailleWidth: brailleWidth,
+	}, nil
+}
+
+// NewXScaleValues is like NewXScaleRange, but the X axis displays arbitrary
+// values in the range min <= v <= max instead of positions in a series. This
+// is used when plotting pairs of values.
+// Max must be greater or equal to min. The graphWidth must be a positive
+// number.
+func NewXScaleValues(min, max float64, graphWidth, nonZeroDecimals int) (*XScale, error) {
+	if max < min {
+		return nil, fmt.Errorf("max(%v) cannot be less than min(%v)", max, min)
+	}
+	if minWidth := 1; graphWidth < minWidth {
+		return nil, fmt.Errorf("graphWidth must be at least %d, got %d", minWidth, graphWidth)
+	}
+
+	brailleWidth := graphWidth * braille.ColMult
+	usablePixels := brailleWidth - 1 // One pixel reserved for value zero.
 	return &XScale{
 		Min:          NewValue(min, nonZeroDecimals),
 		Max:          NewValue(max, nonZeroDecimals),
-		Step:         step,
+		Step:         NewValue((max-min)/float64(usablePixels), nonZeroDecimals),
 		GraphWidth:   graphWidth,
 		brailleWidth: brailleWidth,
+		continuous:   true,
 	}, nil
 }
 
@@ -197,7 +320,7 @@ func (xs *XScale) PixelToValue(x int) (float64, error) {
 	case x == xs.brailleWidth-1:
 		return xs.Max.Rounded, nil
 	default:
-		return float64(x) * xs.Step.Rounded, nil
+		return xs.Min.Value + float64(x)*xs.Step.Rounded, nil
 	}
 }
 
@@ -206,14 +329,24 @@ func (xs *XScale) PixelToValue(x int) (float64, error) {
 // The value must be within the bounds provided to NewXScale. X coordinates
 // grow right.
 func (xs *XScale) ValueToPixel(v int) (int, error) {
-	fv := float64(v)
-	if min, max := xs.Min.Value, xs.Max.Rounded; fv < min || fv > max {
+	return xs.FloatValueToPixel(float64(v))
+}
+
+// FloatValueToPixel is like ValueToPixel, but accepts values with fractional
+// parts, e.g. on scales created by NewXScaleValues.
+func (xs *XScale) FloatValueToPixel(v float64) (int, error) {
+	if min, max := xs.Min.Value, xs.Max.Value; v < min || v > max {
 		return 0, fmt.Errorf("invalid value %v, must be in range %v <= v <= %v", v, min, max)
 	}
 	if xs.Step.Rounded == 0 {
 		return 0, nil
 	}
-	return int(numbers.Round(fv / xs.Step.Rounded)), nil
+	p := int(numbers.Round((v - xs.Min.Value) / xs.Step.Rounded))
+	if max := xs.brailleWidth - 1; p > max {
+		// The rounded step can place the maximum value past the last pixel.
+		p = max
+	}
+	return p, nil
 }
 
 // ValueToCell given a value, determines the X coordinate of the cell that
@@ -231,12 +364,16 @@ func (xs *XScale) ValueToCell(v int) (int, error) {
 // CellLabel given an X coordinate of a cell on the canvas, determines value of the
 // label that should be next to it. The X coordinate must be within the
 // graphWidth provided to NewXScale. X coordinates grow right.
-// The returned value is rounded to the nearest int, rounding half away from zero.
+// The returned value is rounded to the nearest int, rounding half away from
+// zero, unless the scale was created by NewXScaleValues.
 func (xs *XScale) CellLabel(x int) (*Value, error) {
 	v, err := xs.PixelToValue(x * braille.ColMult)
 	if err != nil {
 		return nil, err
 	}
+	if xs.continuous {
+		return NewValue(v, xs.Min.NonZeroDecimals), nil
+	}
 	return NewValue(numbers.Round(v), xs.Min.NonZeroDecimals), nil
 }
 
@@ -22,8 +22,8 @@ import (
 )
 
 // mustNewYScale returns a new YScale or panics.
-func mustNewYScale(min, max float64, graphHeight, nonZeroDecimals int) *YScale {
-	s, err := NewYScale(min, max, graphHeight, nonZeroDecimals)
+func mustNewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMode) *YScale {
+	s, err := NewYScale(min, max, graphHeight, nonZeroDecimals, mode)
 	if err != nil {
 		panic(err)
 	}
@@ -74,6 +74,7 @@ func TestYScale(t *testing.T) {
 		max               float64
 		graphHeight       int
 		nonZeroDecimals   int
+		mode              YScaleMode
 		pixelToValueTests []pixelToValueTest
 		valueToPixelTests []valueToPixelTest
 		cellLabelTests    []cellLabelTest
@@ -366,10 +367,135 @@ func TestYScale(t *testing.T) {
 				{111, -0.19, false},
 			},
 		},
+		{
+			desc:            "fails on unsupported mode",
+			min:             0,
+			max:             1,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleMode(-1),
+			wantErr:         true,
+		},
+		{
+			desc:            "adaptive scale starts at the minimum",
+			min:             10,
+			max:             13,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeAdaptive,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 10, false},
+				{2, 11, false},
+				{1, 12, false},
+				{0, 13, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{10, 3, false},
+				{11, 2, false},
+				{12, 1, false},
+				{13, 0, false},
+				{9, 0, true},
+			},
+			cellLabelTests: []cellLabelTest{
+				{0, NewValue(10, 2), false},
+			},
+		},
+		{
+			desc:            "adaptive scale with negative values",
+			min:             -13,
+			max:             -10,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeAdaptive,
+			pixelToValueTests: []pixelToValueTest{
+				{3, -13, false},
+				{0, -10, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{-13, 3, false},
+				{-12, 2, false},
+				{-10, 0, false},
+			},
+		},
+		{
+			desc:            "adaptive scale is anchored when min equals max",
+			min:             5,
+			max:             5,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeAdaptive,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 0, false},
+				{0, 5, false},
+			},
+		},
+		{
+			desc:            "log scale fails on zero min",
+			min:             0,
+			max:             10,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLog10,
+			wantErr:         true,
+		},
+		{
+			desc:            "log scale, one pixel per power of ten",
+			min:             1,
+			max:             1000,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLog10,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 1, false},
+				{2, 10, false},
+				{1, 100, false},
+				{0, 1000, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{1, 3, false},
+				{10, 2, false},
+				{100, 1, false},
+				{1000, 0, false},
+				{0, 0, true},
+				{-1, 0, true},
+			},
+			cellLabelTests: []cellLabelTest{
+				{0, NewValue(1, 2), false},
+			},
+		},
+		{
+			desc:            "log scale is extended to powers of ten",
+			min:             2,
+			max:             50,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLog10,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 1, false},
+				{0, 100, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{1, 3, false},
+				{10, 1, false},
+				{100, 0, false},
+			},
+		},
+		{
+			desc:            "log scale covers at least one power of ten",
+			min:             10,
+			max:             10,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLog10,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 10, false},
+				{0, 100, false},
+			},
+		},
 	}
 
 	for _, test := range tests {
-		scale, err := NewYScale(test.min, test.max, test.graphHeight, test.nonZeroDecimals)
+		scale, err := NewYScale(test.min, test.max, test.graphHeight, test.nonZeroDecimals, test.mode)
 		if (err != nil) != test.wantErr {
 			t.Errorf("NewYScale => unexpected error: %v, wantErr: %v", err, test.wantErr)
 		}
@@ -698,3 +824,242 @@ func TestXScale(t *testing.T) {
 		})
 	}
 }
+
+func TestXScaleRange(t *testing.T) {
+	tests := []struct {
+		desc              string
+		min               int
+		max               int
+		graphWidth        int
+		pixelToValueTests []pixelToValueTest
+		valueToPixelTests []valueToPixelTest
+		cellLabelTests    []cellLabelTest
+		wantErr           bool
+	}{
+		{
+			desc:       "fails when min negative",
+			min:        -1,
+			max:        1,
+			graphWidth: 1,
+			wantErr:    true,
+		},
+		{
+			desc:       "fails when max less than min",
+			min:        2,
+			max:        1,
+			graphWidth: 1,
+			wantErr:    true,
+		},
+		{
+			desc:       "fails when graphWidth zero",
+			min:        0,
+			max:        1,
+			graphWidth: 0,
+			wantErr:    true,
+		},
+		{
+			desc:       "integer scale offset by min",
+			min:        10,
+			max:        15,
+			graphWidth: 3,
+			pixelToValueTests: []pixelToValueTest{
+				{0, 10, false},
+				{1, 11, false},
+				{4, 14, false},
+				{5, 15, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{9, 0, true},
+				{10, 0, false},
+				{11, 1, false},
+				{15, 5, false},
+				{16, 0, true},
+			},
+			cellLabelTests: []cellLabelTest{
+				{0, NewValue(10, 2), false},
+				{1, NewValue(12, 2), false},
+				{2, NewValue(14, 2), false},
+			},
+		},
+		{
+			desc:       "float scale offset by min",
+			min:        100,
+			max:        111,
+			graphWidth: 3,
+			pixelToValueTests: []pixelToValueTest{
+				{0, 100, false},
+				{1, 102.21, false},
+				{5, 111, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{100, 0, false},
+				{102, 1, false},
+				{111, 5, false},
+			},
+			cellLabelTests: []cellLabelTest{
+				{0, NewValue(100, 2), false},
+				{1, NewValue(104, 2), false},
+				{2, NewValue(109, 2), false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		scale, err := NewXScaleRange(test.min, test.max, test.graphWidth, nonZeroDecimals)
+		if (err != nil) != test.wantErr {
+			t.Errorf("NewXScaleRange(%q) => unexpected error: %v, wantErr: %v", test.desc, err, test.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+
+		t.Run(test.desc, func(t *testing.T) {
+			for _, tc := range test.pixelToValueTests {
+				got, err := scale.PixelToValue(tc.pixel)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("PixelToValue => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("PixelToValue(%v) => %v, want %v", tc.pixel, got, tc.want)
+				}
+			}
+			for _, tc := range test.valueToPixelTests {
+				got, err := scale.ValueToPixel(int(tc.value))
+				if (err != nil) != tc.wantErr {
+					t.Errorf("ValueToPixel(%v) => unexpected error: %v, wantErr: %v", tc.value, err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("ValueToPixel(%v) => %v, want %v", tc.value, got, tc.want)
+				}
+			}
+			for _, tc := range test.cellLabelTests {
+				got, err := scale.CellLabel(tc.cell)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("CellLabel => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				if diff := pretty.Compare(tc.want, got); diff != "" {
+					t.Errorf("CellLabel(%v) => unexpected diff (-want, +got):\n%s", tc.cell, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestXScaleValues(t *testing.T) {
+	tests := []struct {
+		desc              string
+		min               float64
+		max               float64
+		graphWidth        int
+		pixelToValueTests []pixelToValueTest
+		valueToPixelTests []valueToPixelTest
+		cellLabelTests    []cellLabelTest
+		wantErr           bool
+	}{
+		{
+			desc:       "fails when max less than min",
+			min:        2,
+			max:        1,
+			graphWidth: 1,
+			wantErr:    true,
+		},
+		{
+			desc:       "fails when graphWidth zero",
+			min:        0,
+			max:        1,
+			graphWidth: 0,
+			wantErr:    true,
+		},
+		{
+			desc:       "labels aren't rounded to integers",
+			min:        0,
+			max:        1,
+			graphWidth: 3,
+			pixelToValueTests: []pixelToValueTest{
+				{0, 0, false},
+				{1, 0.2, false},
+				{5, 1, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{-0.1, 0, true},
+				{0, 0, false},
+				{0.41, 2, false},
+				{0.5, 3, false},
+				{1, 5, false},
+				{1.1, 0, true},
+			},
+			cellLabelTests: []cellLabelTest{
+				{0, NewValue(0, 2), false},
+				{1, NewValue(0.4, 2), false},
+				{2, NewValue(0.8, 2), false},
+			},
+		},
+		{
+			desc:       "negative values",
+			min:        -10,
+			max:        0,
+			graphWidth: 3,
+			pixelToValueTests: []pixelToValueTest{
+				{0, -10, false},
+				{1, -8, false},
+				{5, 0, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{-10, 0, false},
+				{-5, 3, false},
+				{0, 5, false},
+			},
+			cellLabelTests: []cellLabelTest{
+				{0, NewValue(-10, 2), false},
+				{1, NewValue(-6, 2), false},
+				{2, NewValue(-2, 2), false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		scale, err := NewXScaleValues(test.min, test.max, test.graphWidth, nonZeroDecimals)
+		if (err != nil) != test.wantErr {
+			t.Errorf("NewXScaleValues(%q) => unexpected error: %v, wantErr: %v", test.desc, err, test.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+
+		t.Run(test.desc, func(t *testing.T) {
+			for _, tc := range test.pixelToValueTests {
+				got, err := scale.PixelToValue(tc.pixel)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("PixelToValue => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("PixelToValue(%v) => %v, want %v", tc.pixel, got, tc.want)
+				}
+			}
+			for _, tc := range test.valueToPixelTests {
+				got, err := scale.FloatValueToPixel(tc.value)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("FloatValueToPixel(%v) => unexpected error: %v, wantErr: %v", tc.value, err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FloatValueToPixel(%v) => %v, want %v", tc.value, got, tc.want)
+				}
+			}
+			for _, tc := range test.cellLabelTests {
+				got, err := scale.CellLabel(tc.cell)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("CellLabel => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				if diff := pretty.Compare(tc.want, got); diff != "" {
+					t.Errorf("CellLabel(%v) => unexpected diff (-want, +got):\n%s", tc.cell, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// crosshair.go contains code that draws the crosshair and the values of the
+// series at its position.
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+)
+
+// crosshairDecimals is the number of non-zero decimal places of the values
+// displayed next to the crosshair.
+const crosshairDecimals = 2
+
+// toggleCrosshair places the crosshair on the value of the X axis displayed
+// at the X coordinate of the cell in the graph. Removes the crosshair if it
+// is already placed on that value.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) toggleCrosshair(x int) error {
+	v, err := lc.xDetails.Scale.PixelToValue(x * braille.ColMult)
+	if err != nil {
+		return fmt.Errorf("failed to determine the value at the crosshair: %v", err)
+	}
+	pos := int(numbers.Round(v))
+	if lc.crosshairSet && lc.crosshair == pos {
+		lc.crosshairSet = false
+		return nil
+	}
+	lc.crosshairSet = true
+	lc.crosshair = pos
+	return nil
+}
+
+// crosshairVisible determines if the crosshair should be drawn on the X axis.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) crosshairVisible(xd *axes.XDetails) bool {
+	if !lc.opts.crosshair || !lc.crosshairSet {
+		return false
+	}
+	v := float64(lc.crosshair)
+	return v >= xd.Scale.Min.Value && v <= xd.Scale.Max.Value
+}
+
+// drawCrosshairLine draws the vertical line of the crosshair on the braille
+// canvas of the graph.
+func (lc *LineChart) drawCrosshairLine(bc *braille.Canvas, xd *axes.XDetails, cOpts []cell.Option) error {
+	if !lc.crosshairVisible(xd) {
+		return nil
+	}
+	x, err := xd.Scale.ValueToPixel(lc.crosshair)
+	if err != nil {
+		return fmt.Errorf("failed to determine the position of the crosshair: %v", err)
+	}
+	ar := bc.Area()
+	if err := draw.BrailleLine(bc,
+		image.Point{x, ar.Min.Y},
+		image.Point{x, ar.Max.Y - 1},
+		draw.BrailleLineCellOpts(cOpts...),
+	); err != nil {
+		return fmt.Errorf("failed to draw the crosshair: %v", err)
+	}
+	return nil
+}
+
+// crosshairLine is one line of text displayed next to the crosshair.
+type crosshairLine struct {
+	text  string
+	cOpts []cell.Option
+}
+
+// drawCrosshairValues draws the value on the X axis and the values of all the
+// series at the position of the crosshair next to it. The values are placed
+// on the right side of the crosshair if they fit, otherwise on its left.
+func (lc *LineChart) drawCrosshairValues(cvs *canvas.Canvas, xd *axes.XDetails, graphAr image.Rectangle, names []string, labelOpts []cell.Option) error {
+	if !lc.crosshairVisible(xd) {
+		return nil
+	}
+
+	xLabel, ok := lc.xLabels[lc.crosshair]
+	if !ok {
+		xLabel = axes.NewValue(float64(lc.crosshair), crosshairDecimals).Text()
+	}
+	lines := []*crosshairLine{
+		{text: xLabel, cOpts: labelOpts},
+	}
+	for si, name := range names {
+		sv := lc.series[name]
+		if lc.crosshair >= len(sv.values) || math.IsNaN(sv.values[lc.crosshair]) {
+			continue
+		}
+		v := axes.NewValue(sv.values[lc.crosshair], crosshairDecimals)
+		lines = append(lines, &crosshairLine{
+			text:  fmt.Sprintf("%s: %s", name, v.Text()),
+			cOpts: lc.seriesCellOpts(si, name),
+		})
+	}
+
+	var width int
+	for _, l := range lines {
+		if w := runewidth.StringWidth(l.text); w > width {
+			width = w
+		}
+	}
+
+	cellX, err := xd.Scale.ValueToCell(lc.crosshair)
+	if err != nil {
+		return fmt.Errorf("failed to determine the position of the crosshair: %v", err)
+	}
+	lineX := graphAr.Min.X + cellX
+	startX := lineX + 1
+	if startX+width > graphAr.Max.X && lineX-width >= graphAr.Min.X {
+		startX = lineX - width
+	}
+	if width > graphAr.Max.X-startX {
+		width = graphAr.Max.X - startX
+	}
+
+	for i, l := range lines {
+		pos := image.Point{startX, graphAr.Min.Y + i}
+		if width < 1 || !pos.In(graphAr) {
+			break
+		}
+		// Pad the text so that it covers the graph underneath.
+		text := runewidth.FillRight(l.text, width)
+		if err := draw.Text(cvs, text, pos,
+			draw.TextMaxX(startX+width),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(l.cOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the crosshair values: %v", err)
+		}
+	}
+	return nil
+}
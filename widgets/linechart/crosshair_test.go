@@ -0,0 +1,147 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+// click returns the mouse events of a click at the point.
+func click(p image.Point) []*terminalapi.Mouse {
+	return []*terminalapi.Mouse{
+		{Position: p, Button: mouse.ButtonLeft},
+		{Position: p, Button: mouse.ButtonRelease},
+	}
+}
+
+func TestCrosshair(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		clicks []image.Point
+		// golden is the name of the golden file with the expected content,
+		// empty if the content isn't compared.
+		golden  string
+		wantSet bool
+		wantPos int
+	}{
+		{
+			desc:    "no crosshair without a click",
+			opts:    []Option{Crosshair()},
+			wantSet: false,
+		},
+		{
+			desc:    "click places the crosshair",
+			opts:    []Option{Crosshair()},
+			clicks:  []image.Point{{12, 3}},
+			golden:  "Crosshair_left.golden",
+			wantSet: true,
+			wantPos: 1,
+		},
+		{
+			desc:    "values are placed on the left near the right edge",
+			opts:    []Option{Crosshair()},
+			clicks:  []image.Point{{26, 3}},
+			golden:  "Crosshair_right.golden",
+			wantSet: true,
+			wantPos: 3,
+		},
+		{
+			desc:    "works with zoom enabled",
+			opts:    []Option{Crosshair(), EnableZoom()},
+			clicks:  []image.Point{{12, 3}},
+			wantSet: true,
+			wantPos: 1,
+		},
+		{
+			desc:    "click on another value moves the crosshair",
+			opts:    []Option{Crosshair()},
+			clicks:  []image.Point{{12, 3}, {19, 3}},
+			wantSet: true,
+			wantPos: 2,
+		},
+		{
+			desc:    "second click on the same value removes the crosshair",
+			opts:    []Option{Crosshair()},
+			clicks:  []image.Point{{12, 3}, {12, 5}},
+			wantSet: false,
+		},
+		{
+			desc:    "click outside of the graph is ignored",
+			opts:    []Option{Crosshair()},
+			clicks:  []image.Point{{2, 3}},
+			wantSet: false,
+		},
+		{
+			desc:    "click is ignored when the crosshair is disabled",
+			opts:    []Option{EnableZoom()},
+			clicks:  []image.Point{{12, 3}},
+			wantSet: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc := New(tc.opts...)
+			if err := lc.Series("first", []float64{0, 10, 20, 30}, SeriesCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			if err := lc.Series("second", []float64{30, 20, 10, 0}, SeriesCellOpts(cell.FgColor(cell.ColorBlue))); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(image.Rect(0, 0, 30, 10))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := lc.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, p := range tc.clicks {
+				for _, m := range click(p) {
+					if err := lc.Mouse(m); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				}
+			}
+			if err := lc.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if lc.crosshairSet != tc.wantSet || (tc.wantSet && lc.crosshair != tc.wantPos) {
+				t.Errorf("crosshair => set:%v pos:%d, want set:%v pos:%d", lc.crosshairSet, lc.crosshair, tc.wantSet, tc.wantPos)
+			}
+
+			if tc.golden == "" {
+				return
+			}
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
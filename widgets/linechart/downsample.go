@@ -0,0 +1,288 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// downsample.go contains code that reduces the number of values drawn to the
+// resolution of the canvas.
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+)
+
+// Downsample determines how the values of a series that has more values than
+// there are pixel columns on the graph are reduced before they are drawn.
+type Downsample int
+
+// String implements fmt.Stringer()
+func (d Downsample) String() string {
+	if n, ok := downsampleNames[d]; ok {
+		return n
+	}
+	return "DownsampleUnknown"
+}
+
+// downsampleNames maps Downsample values to human readable names.
+var downsampleNames = map[Downsample]string{
+	DownsampleNone:    "DownsampleNone",
+	DownsampleMinMax:  "DownsampleMinMax",
+	DownsampleLTTB:    "DownsampleLTTB",
+	DownsampleAverage: "DownsampleAverage",
+}
+
+// Supported downsampling strategies.
+// Values that fall into the same pixel column of the graph form a bucket. A
+// bucket without any value that can be displayed forms a gap in the series.
+const (
+	// DownsampleNone draws all the values.
+	DownsampleNone Downsample = iota
+
+	// DownsampleMinMax draws the smallest and the largest value of each
+	// bucket, so that spikes remain visible.
+	DownsampleMinMax
+
+	// DownsampleLTTB draws one value of each bucket, selected using the
+	// Largest-Triangle-Three-Buckets algorithm. This preserves the visual
+	// shape of the series.
+	DownsampleLTTB
+
+	// DownsampleAverage draws the average of the values of each bucket,
+	// which smooths out noise.
+	DownsampleAverage
+)
+
+// sample is a value of a series at a position on the X axis.
+type sample struct {
+	// idx is the position of the value in the series.
+	idx int
+	// v is the value.
+	v float64
+}
+
+// bucket is a range of positions in the series whose values fall into the
+// same pixel column.
+type bucket struct {
+	first, last int
+}
+
+// displayable asserts whether the value can be displayed on the Y axis.
+func displayable(yd *axes.YDetails, v float64) bool {
+	return !math.IsNaN(v) && !(yd.Scale.Mode == axes.YScaleModeLog10 && v <= 0)
+}
+
+// downsample returns the samples at positions first <= i <= last in the
+// values that should be drawn. All the values are returned if there aren't
+// more of them than pixel columns on the graph.
+func downsample(d Downsample, xd *axes.XDetails, yd *axes.YDetails, values []float64, first, last int) ([]*sample, error) {
+	if d == DownsampleNone || last-first+1 <= xd.Scale.GraphWidth*braille.ColMult {
+		var samples []*sample
+		for i := first; i <= last; i++ {
+			samples = append(samples, &sample{idx: i, v: values[i]})
+		}
+		return samples, nil
+	}
+
+	buckets, err := pixelBuckets(xd, first, last)
+	if err != nil {
+		return nil, err
+	}
+	switch d {
+	case DownsampleMinMax:
+		return minMaxSamples(yd, values, buckets), nil
+	case DownsampleLTTB:
+		return lttbSamples(yd, values, buckets), nil
+	case DownsampleAverage:
+		return averageSamples(yd, values, buckets), nil
+	default:
+		return nil, fmt.Errorf("unsupported downsampling %v", d)
+	}
+}
+
+// pixelBuckets groups the positions first <= i <= last by the pixel column
+// they fall into. The boundaries of the buckets are calculated from the scale
+// of the X axis, so the cost depends on the width of the graph rather than on
+// the number of values.
+func pixelBuckets(xd *axes.XDetails, first, last int) ([]bucket, error) {
+	pixel := func(i int) (int, error) {
+		x, err := xd.Scale.ValueToPixel(i)
+		if err != nil {
+			return 0, fmt.Errorf("failure for value [%d], xd.Scale.ValueToPixel => %v", i, err)
+		}
+		return x, nil
+	}
+
+	step := xd.Scale.Step.Rounded
+	lastPixel := xd.Scale.GraphWidth*braille.ColMult - 1
+	var buckets []bucket
+	for i := first; i <= last; {
+		x, err := pixel(i)
+		if err != nil {
+			return nil, err
+		}
+
+		end := last
+		if step > 0 && x < lastPixel {
+			// ValueToPixel rounds, so the pixel column x holds the positions
+			// up to half a step past the value of the pixel.
+			end = int(math.Ceil(xd.Scale.Min.Value+(float64(x)+0.5)*step)) - 1
+			if end > last {
+				end = last
+			}
+			if end < i {
+				end = i
+			}
+			// Correct the rounding errors of the floating point arithmetic.
+			for ; end > i; end-- {
+				ex, err := pixel(end)
+				if err != nil {
+					return nil, err
+				}
+				if ex == x {
+					break
+				}
+			}
+			for end < last {
+				ex, err := pixel(end + 1)
+				if err != nil {
+					return nil, err
+				}
+				if ex != x {
+					break
+				}
+				end++
+			}
+		}
+		buckets = append(buckets, bucket{first: i, last: end})
+		i = end + 1
+	}
+	return buckets, nil
+}
+
+// minMaxSamples returns the smallest and the largest value of each bucket in
+// the order in which they appear in the series.
+func minMaxSamples(yd *axes.YDetails, values []float64, buckets []bucket) []*sample {
+	var samples []*sample
+	for _, b := range buckets {
+		minI, maxI := -1, -1
+		for i := b.first; i <= b.last; i++ {
+			v := values[i]
+			if !displayable(yd, v) {
+				continue
+			}
+			if minI == -1 || v < values[minI] {
+				minI = i
+			}
+			if maxI == -1 || v > values[maxI] {
+				maxI = i
+			}
+		}
+
+		switch {
+		case minI == -1:
+			samples = append(samples, &sample{idx: b.first, v: math.NaN()})
+		case minI == maxI:
+			samples = append(samples, &sample{idx: minI, v: values[minI]})
+		case minI < maxI:
+			samples = append(samples, &sample{idx: minI, v: values[minI]}, &sample{idx: maxI, v: values[maxI]})
+		default:
+			samples = append(samples, &sample{idx: maxI, v: values[maxI]}, &sample{idx: minI, v: values[minI]})
+		}
+	}
+	return samples
+}
+
+// bucketAverage returns the average position and value of the displayable
+// values in the bucket. Returns false if the bucket doesn't have any.
+func bucketAverage(yd *axes.YDetails, values []float64, b bucket) (idx, v float64, ok bool) {
+	var count int
+	for i := b.first; i <= b.last; i++ {
+		if !displayable(yd, values[i]) {
+			continue
+		}
+		idx += float64(i)
+		v += values[i]
+		count++
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+	return idx / float64(count), v / float64(count), true
+}
+
+// averageSamples returns the average value of each bucket.
+func averageSamples(yd *axes.YDetails, values []float64, buckets []bucket) []*sample {
+	var samples []*sample
+	for _, b := range buckets {
+		_, v, ok := bucketAverage(yd, values, b)
+		if !ok {
+			v = math.NaN()
+		}
+		samples = append(samples, &sample{idx: b.first, v: v})
+	}
+	return samples
+}
+
+// lttbSamples returns one value of each bucket selected by the
+// Largest-Triangle-Three-Buckets algorithm. The selected value forms the
+// triangle with the largest area together with the value selected in the
+// previous bucket and the average of the next bucket.
+// The first and the last displayable values of each uninterrupted part of the
+// series are always selected.
+func lttbSamples(yd *axes.YDetails, values []float64, buckets []bucket) []*sample {
+	var samples []*sample
+	var prev *sample
+	for bi, b := range buckets {
+		var candidates []int
+		for i := b.first; i <= b.last; i++ {
+			if displayable(yd, values[i]) {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) == 0 {
+			samples = append(samples, &sample{idx: b.first, v: math.NaN()})
+			prev = nil
+			continue
+		}
+
+		var avgIdx, avgV float64
+		nextOK := false
+		if bi < len(buckets)-1 {
+			avgIdx, avgV, nextOK = bucketAverage(yd, values, buckets[bi+1])
+		}
+
+		var selected int
+		switch {
+		case prev == nil:
+			selected = candidates[0]
+		case !nextOK:
+			selected = candidates[len(candidates)-1]
+		default:
+			largest := -1.0
+			for _, i := range candidates {
+				area := math.Abs((float64(prev.idx)-avgIdx)*(values[i]-prev.v) - (float64(prev.idx)-float64(i))*(avgV-prev.v))
+				if area > largest {
+					largest = area
+					selected = i
+				}
+			}
+		}
+		prev = &sample{idx: selected, v: values[selected]}
+		samples = append(samples, prev)
+	}
+	return samples
+}
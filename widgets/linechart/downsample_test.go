@@ -0,0 +1,252 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+)
+
+func TestDownsample(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		desc       string
+		downsample Downsample
+		values     []float64
+		// graphWidth is the width of the graph in cells.
+		graphWidth int
+		mode       axes.YScaleMode
+		want       []*sample
+		wantErr    bool
+	}{
+		{
+			desc:       "no downsampling draws all the values",
+			downsample: DownsampleNone,
+			values:     []float64{1, 5, 2, 9, 3, 4},
+			graphWidth: 1,
+			want: []*sample{
+				{0, 1}, {1, 5}, {2, 2}, {3, 9}, {4, 3}, {5, 4},
+			},
+		},
+		{
+			desc:       "all the values are drawn when they fit",
+			downsample: DownsampleMinMax,
+			values:     []float64{1, 5, 2, 9},
+			graphWidth: 2,
+			want: []*sample{
+				{0, 1}, {1, 5}, {2, 2}, {3, 9},
+			},
+		},
+		{
+			desc:       "min and max per column",
+			downsample: DownsampleMinMax,
+			values:     []float64{1, 5, 2, 9, 3, 4, nan, nan, nan, nan, 7, 6},
+			graphWidth: 2,
+			want: []*sample{
+				{0, 1}, {1, 5},
+				{2, 2}, {3, 9},
+				{6, nan},
+				{10, 7}, {11, 6},
+			},
+		},
+		{
+			desc:       "min and max per column skip non-positive values on the logarithmic scale",
+			downsample: DownsampleMinMax,
+			values:     []float64{1, 5, 2, 9, -3, 4, 0, 0, 0, 0, 7, 6},
+			graphWidth: 2,
+			mode:       axes.YScaleModeLog10,
+			want: []*sample{
+				{0, 1}, {1, 5},
+				{2, 2}, {3, 9},
+				{6, nan},
+				{10, 7}, {11, 6},
+			},
+		},
+		{
+			desc:       "average per column",
+			downsample: DownsampleAverage,
+			values:     []float64{1, 5, 2, 9, 3, 4, nan, nan, nan, nan, 7, 6},
+			graphWidth: 2,
+			want: []*sample{
+				{0, 3},
+				{2, 4.5},
+				{6, nan},
+				{10, 6.5},
+			},
+		},
+		{
+			desc:       "LTTB selects the values that form the largest triangles",
+			downsample: DownsampleLTTB,
+			values:     []float64{1, 5, 2, 9, 3, 4, 8, 0, 2, 3, 7, 6},
+			graphWidth: 2,
+			want: []*sample{
+				{0, 1},
+				{3, 9},
+				{7, 0},
+				{11, 6},
+			},
+		},
+		{
+			desc:       "LTTB keeps the edges of gaps",
+			downsample: DownsampleLTTB,
+			values:     []float64{1, 5, 2, 9, 3, 4, nan, nan, nan, nan, 7, 6},
+			graphWidth: 2,
+			want: []*sample{
+				{0, 1},
+				{5, 4},
+				{6, nan},
+				{10, 7},
+			},
+		},
+		{
+			desc:       "fails on unsupported downsampling",
+			downsample: Downsample(-1),
+			values:     []float64{1, 5, 2, 9, 3, 4},
+			graphWidth: 1,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cvsAr := image.Rect(0, 0, tc.graphWidth+1, 5)
+			xd, err := axes.NewXDetails(len(tc.values), image.Point{0, 0}, cvsAr, nil)
+			if err != nil {
+				t.Fatalf("NewXDetails => unexpected error: %v", err)
+			}
+			yd := &axes.YDetails{
+				Scale: &axes.YScale{Mode: tc.mode},
+			}
+
+			got, err := downsample(tc.downsample, xd, yd, tc.values, 0, len(tc.values)-1)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("downsample => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("downsample => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestPixelBuckets verifies that the bucket boundaries calculated from the
+// scale match the pixel columns of the individual positions.
+func TestPixelBuckets(t *testing.T) {
+	for _, numPoints := range []int{2, 3, 7, 28, 29, 100, 997, 10000} {
+		for _, graphWidth := range []int{1, 2, 3, 14, 37, 80} {
+			for _, zoom := range [][2]int{{0, numPoints - 1}, {1, numPoints - 1}, {numPoints / 3, numPoints / 2}} {
+				min, max := zoom[0], zoom[1]
+				if min >= max {
+					continue
+				}
+				cvsAr := image.Rect(0, 0, graphWidth+1, 5)
+				xd, err := axes.NewXDetailsRange(min, max, image.Point{0, 0}, cvsAr, nil)
+				if err != nil {
+					t.Fatalf("NewXDetailsRange => unexpected error: %v", err)
+				}
+
+				var want []bucket
+				lastX := -1
+				for i := min; i <= max; i++ {
+					x, err := xd.Scale.ValueToPixel(i)
+					if err != nil {
+						t.Fatalf("ValueToPixel => unexpected error: %v", err)
+					}
+					if len(want) == 0 || x != lastX {
+						want = append(want, bucket{first: i, last: i})
+						lastX = x
+						continue
+					}
+					want[len(want)-1].last = i
+				}
+
+				got, err := pixelBuckets(xd, min, max)
+				if err != nil {
+					t.Fatalf("pixelBuckets => unexpected error: %v", err)
+				}
+				if diff := pretty.Compare(want, got); diff != "" {
+					t.Errorf("pixelBuckets(%d points, width %d, range %d-%d) => unexpected diff (-want, +got):\n%s", numPoints, graphWidth, min, max, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestDownsampleDraw(t *testing.T) {
+	// A long series with a single spike.
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = float64(i % 10)
+	}
+	values[555] = 100
+
+	tests := []struct {
+		desc       string
+		downsample Downsample
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:       "min and max",
+			downsample: DownsampleMinMax,
+			golden:     "Downsample_minmax.golden",
+		},
+		{
+			desc:       "LTTB",
+			downsample: DownsampleLTTB,
+			golden:     "Downsample_lttb.golden",
+		},
+		{
+			desc:       "average",
+			downsample: DownsampleAverage,
+			golden:     "Downsample_average.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc := New(Downsampling(tc.downsample))
+			if err := lc.Series("first", values); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(image.Rect(0, 0, 30, 10))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := lc.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// legend.go contains code that places and draws the legend.
+
+import "github.com/mum4k/termdash/widgets/linechart/legend"
+
+// LegendPlacement determines where the legend is drawn.
+type LegendPlacement = legend.Placement
+
+// Supported legend placements.
+const (
+	// LegendNone means the legend isn't drawn.
+	LegendNone = legend.None
+
+	// LegendTop places the legend in a row above the line chart.
+	LegendTop = legend.Top
+	// LegendBottom places the legend in a row below the labels of the X axis.
+	LegendBottom = legend.Bottom
+	// LegendRight places the legend in a column to the right of the line
+	// chart, one series per row.
+	LegendRight = legend.Right
+
+	// LegendTopLeft places the legend inside of the graph, into its top left
+	// corner, one series per row.
+	LegendTopLeft = legend.TopLeft
+	// LegendTopRight places the legend inside of the graph, into its top
+	// right corner, one series per row.
+	LegendTopRight = legend.TopRight
+	// LegendBottomLeft places the legend inside of the graph, into its bottom
+	// left corner, one series per row.
+	LegendBottomLeft = legend.BottomLeft
+	// LegendBottomRight places the legend inside of the graph, into its
+	// bottom right corner, one series per row.
+	LegendBottomRight = legend.BottomRight
+)
+
+// legendEntries returns the legend entries of the series with the names.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) legendEntries(names []string) []*legend.Entry {
+	var res []*legend.Entry
+	for si, name := range names {
+		res = append(res, &legend.Entry{
+			Name:     name,
+			CellOpts: lc.seriesCellOpts(si, name),
+		})
+	}
+	return res
+}
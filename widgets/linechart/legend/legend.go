@@ -0,0 +1,235 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package legend places and draws the legends of widgets that display
+// multiple series, e.g. the LineChart and the Scatter widgets.
+package legend
+
+import (
+	"fmt"
+	"image"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+)
+
+// Placement determines where the legend is drawn.
+type Placement int
+
+// String implements fmt.Stringer()
+func (p Placement) String() string {
+	if n, ok := placementNames[p]; ok {
+		return n
+	}
+	return "LegendPlacementUnknown"
+}
+
+// placementNames maps Placement values to human readable names.
+var placementNames = map[Placement]string{
+	None:        "LegendNone",
+	Top:         "LegendTop",
+	Bottom:      "LegendBottom",
+	Right:       "LegendRight",
+	TopLeft:     "LegendTopLeft",
+	TopRight:    "LegendTopRight",
+	BottomLeft:  "LegendBottomLeft",
+	BottomRight: "LegendBottomRight",
+}
+
+// Supported legend placements.
+const (
+	// None means the legend isn't drawn.
+	None Placement = iota
+
+	// Top places the legend in a row above the chart.
+	Top
+	// Bottom places the legend in a row below the chart.
+	Bottom
+	// Right places the legend in a column to the right of the chart, one
+	// series per row.
+	Right
+
+	// TopLeft places the legend inside of the graph, into its top left
+	// corner, one series per row.
+	TopLeft
+	// TopRight places the legend inside of the graph, into its top right
+	// corner, one series per row.
+	TopRight
+	// BottomLeft places the legend inside of the graph, into its bottom left
+	// corner, one series per row.
+	BottomLeft
+	// BottomRight places the legend inside of the graph, into its bottom
+	// right corner, one series per row.
+	BottomRight
+)
+
+// Inside determines if the legend is drawn inside of the graph.
+func (p Placement) Inside() bool {
+	switch p {
+	case TopLeft, TopRight, BottomLeft, BottomRight:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultMarker is drawn in front of the name of series that don't have a
+// marker.
+const DefaultMarker = '⣿'
+
+// Spacing is the number of cells between the legend entries placed in a row
+// and between the legend and the chart.
+const Spacing = 1
+
+// Entry is the entry of one series in the legend.
+type Entry struct {
+	// Name is the name of the series.
+	Name string
+	// Marker is drawn in front of the name, DefaultMarker is used if zero.
+	Marker rune
+	// CellOpts are the cell options of the entry, usually the color of the
+	// series.
+	CellOpts []cell.Option
+}
+
+// text returns the text of the entry.
+func (e *Entry) text() string {
+	marker := e.Marker
+	if marker == 0 {
+		marker = DefaultMarker
+	}
+	return fmt.Sprintf("%c %s", marker, e.Name)
+}
+
+// width returns the width of the widest entry.
+func width(entries []*Entry) int {
+	var widest int
+	for _, e := range entries {
+		if w := runewidth.StringWidth(e.text()); w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// Layout splits the canvas area into the area for the chart and the area for
+// a legend that is drawn outside of the graph. The returned legend area is
+// empty if the legend isn't drawn outside of the graph or if the canvas is
+// too small to fit both the chart and the legend.
+// The minSize is the minimum size required by the chart.
+func Layout(cvsAr image.Rectangle, p Placement, entries []*Entry, minSize image.Point) (chartAr, legendAr image.Rectangle) {
+	if len(entries) == 0 {
+		return cvsAr, image.ZR
+	}
+
+	switch p {
+	case Top:
+		if cvsAr.Dy()-1 < minSize.Y {
+			return cvsAr, image.ZR
+		}
+		return image.Rect(cvsAr.Min.X, cvsAr.Min.Y+1, cvsAr.Max.X, cvsAr.Max.Y),
+			image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Min.Y+1)
+
+	case Bottom:
+		if cvsAr.Dy()-1 < minSize.Y {
+			return cvsAr, image.ZR
+		}
+		return image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Max.Y-1),
+			image.Rect(cvsAr.Min.X, cvsAr.Max.Y-1, cvsAr.Max.X, cvsAr.Max.Y)
+
+	case Right:
+		w := width(entries) + Spacing
+		if cvsAr.Dx()-w < minSize.X {
+			return cvsAr, image.ZR
+		}
+		split := cvsAr.Max.X - w
+		return image.Rect(cvsAr.Min.X, cvsAr.Min.Y, split, cvsAr.Max.Y),
+			image.Rect(split+Spacing, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Max.Y)
+
+	default:
+		return cvsAr, image.ZR
+	}
+}
+
+// InsideArea returns the area inside of the graph area where the legend is
+// drawn for placements inside of the graph. Returns an empty area for the
+// other placements.
+func InsideArea(graphAr image.Rectangle, p Placement, entries []*Entry) image.Rectangle {
+	w := width(entries)
+	if w > graphAr.Dx() {
+		w = graphAr.Dx()
+	}
+	h := len(entries)
+	if h > graphAr.Dy() {
+		h = graphAr.Dy()
+	}
+
+	var min image.Point
+	switch p {
+	case TopLeft:
+		min = graphAr.Min
+	case TopRight:
+		min = image.Point{graphAr.Max.X - w, graphAr.Min.Y}
+	case BottomLeft:
+		min = image.Point{graphAr.Min.X, graphAr.Max.Y - h}
+	case BottomRight:
+		min = image.Point{graphAr.Max.X - w, graphAr.Max.Y - h}
+	default:
+		return image.ZR
+	}
+	return image.Rectangle{min, min.Add(image.Point{w, h})}
+}
+
+// Draw draws the legend entries into the area on the canvas. The entries are
+// drawn in a row for the Top and Bottom placements, otherwise one entry per
+// row. Entries that don't fit are omitted.
+func Draw(cvs *canvas.Canvas, ar image.Rectangle, p Placement, entries []*Entry) error {
+	if ar.Empty() {
+		return nil
+	}
+
+	inRow := p == Top || p == Bottom
+	pos := ar.Min
+	for i, e := range entries {
+		if !pos.In(ar) {
+			break
+		}
+		text := e.text()
+		if !inRow {
+			// Pad the entries so that they cover the graph underneath.
+			text = runewidth.FillRight(text, ar.Dx())
+		}
+
+		w := runewidth.StringWidth(text)
+		if inRow && i > 0 && pos.X+w > ar.Max.X {
+			break // Only the first entry gets trimmed.
+		}
+		if err := draw.Text(cvs, text, pos,
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(e.CellOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the legend: %v", err)
+		}
+
+		if inRow {
+			pos.X += w + Spacing
+		} else {
+			pos.Y++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legend
+
+import (
+	"image"
+	"testing"
+)
+
+// entries returns legend entries with the names.
+func entries(names ...string) []*Entry {
+	var res []*Entry
+	for _, n := range names {
+		res = append(res, &Entry{Name: n})
+	}
+	return res
+}
+
+func TestLayout(t *testing.T) {
+	cvsAr := image.Rect(0, 0, 20, 10)
+	minSize := image.Point{5, 4}
+	tests := []struct {
+		desc         string
+		cvsAr        image.Rectangle
+		p            Placement
+		entries      []*Entry
+		wantChartAr  image.Rectangle
+		wantLegendAr image.Rectangle
+	}{
+		{
+			desc:         "no legend",
+			cvsAr:        cvsAr,
+			p:            None,
+			entries:      entries("a"),
+			wantChartAr:  cvsAr,
+			wantLegendAr: image.ZR,
+		},
+		{
+			desc:         "no series",
+			cvsAr:        cvsAr,
+			p:            Top,
+			wantChartAr:  cvsAr,
+			wantLegendAr: image.ZR,
+		},
+		{
+			desc:         "legend on the top",
+			cvsAr:        cvsAr,
+			p:            Top,
+			entries:      entries("a"),
+			wantChartAr:  image.Rect(0, 1, 20, 10),
+			wantLegendAr: image.Rect(0, 0, 20, 1),
+		},
+		{
+			desc:         "legend on the bottom",
+			cvsAr:        cvsAr,
+			p:            Bottom,
+			entries:      entries("a"),
+			wantChartAr:  image.Rect(0, 0, 20, 9),
+			wantLegendAr: image.Rect(0, 9, 20, 10),
+		},
+		{
+			desc:         "legend on the right is as wide as the widest entry",
+			cvsAr:        cvsAr,
+			p:            Right,
+			entries:      entries("a", "abc"),
+			wantChartAr:  image.Rect(0, 0, 14, 10),
+			wantLegendAr: image.Rect(15, 0, 20, 10),
+		},
+		{
+			desc:         "legend on the right accounts for wide markers",
+			cvsAr:        cvsAr,
+			p:            Right,
+			entries:      []*Entry{{Name: "a", Marker: '世'}},
+			wantChartAr:  image.Rect(0, 0, 15, 10),
+			wantLegendAr: image.Rect(16, 0, 20, 10),
+		},
+		{
+			desc:         "no space for the legend on the top",
+			cvsAr:        image.Rect(0, 0, 20, 4),
+			p:            Top,
+			entries:      entries("a"),
+			wantChartAr:  image.Rect(0, 0, 20, 4),
+			wantLegendAr: image.ZR,
+		},
+		{
+			desc:         "no space for the legend on the right",
+			cvsAr:        image.Rect(0, 0, 8, 10),
+			p:            Right,
+			entries:      entries("a"),
+			wantChartAr:  image.Rect(0, 0, 8, 10),
+			wantLegendAr: image.ZR,
+		},
+		{
+			desc:         "legend inside of the graph doesn't take space",
+			cvsAr:        cvsAr,
+			p:            TopLeft,
+			entries:      entries("a"),
+			wantChartAr:  cvsAr,
+			wantLegendAr: image.ZR,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotChartAr, gotLegendAr := Layout(tc.cvsAr, tc.p, tc.entries, minSize)
+			if gotChartAr != tc.wantChartAr || gotLegendAr != tc.wantLegendAr {
+				t.Errorf("Layout => %v, %v, want %v, %v", gotChartAr, gotLegendAr, tc.wantChartAr, tc.wantLegendAr)
+			}
+		})
+	}
+}
+
+func TestInsideArea(t *testing.T) {
+	graphAr := image.Rect(5, 0, 20, 8)
+	ents := entries("a", "abc")
+	tests := []struct {
+		desc    string
+		graphAr image.Rectangle
+		p       Placement
+		want    image.Rectangle
+	}{
+		{
+			desc:    "top left",
+			graphAr: graphAr,
+			p:       TopLeft,
+			want:    image.Rect(5, 0, 10, 2),
+		},
+		{
+			desc:    "top right",
+			graphAr: graphAr,
+			p:       TopRight,
+			want:    image.Rect(15, 0, 20, 2),
+		},
+		{
+			desc:    "bottom left",
+			graphAr: graphAr,
+			p:       BottomLeft,
+			want:    image.Rect(5, 6, 10, 8),
+		},
+		{
+			desc:    "bottom right",
+			graphAr: graphAr,
+			p:       BottomRight,
+			want:    image.Rect(15, 6, 20, 8),
+		},
+		{
+			desc:    "limited to the graph area",
+			graphAr: image.Rect(5, 0, 8, 1),
+			p:       TopLeft,
+			want:    image.Rect(5, 0, 8, 1),
+		},
+		{
+			desc:    "not inside of the graph",
+			graphAr: graphAr,
+			p:       Top,
+			want:    image.ZR,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := InsideArea(tc.graphAr, tc.p, ents); got != tc.want {
+				t.Errorf("InsideArea => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/faketerm"
+)
+
+func TestDrawLegend(t *testing.T) {
+	tests := []struct {
+		lp   LegendPlacement
+		size image.Point
+	}{
+		{LegendTop, image.Point{30, 10}},
+		{LegendTop, image.Point{14, 10}},
+		{LegendBottom, image.Point{30, 10}},
+		{LegendRight, image.Point{30, 10}},
+		{LegendTopLeft, image.Point{30, 10}},
+		{LegendTopRight, image.Point{30, 10}},
+		{LegendBottomLeft, image.Point{30, 10}},
+		{LegendBottomRight, image.Point{30, 10}},
+	}
+
+	for _, tc := range tests {
+		name := fmt.Sprintf("%v_%dx%d", tc.lp, tc.size.X, tc.size.Y)
+		t.Run(name, func(t *testing.T) {
+			lc := New(Legend(tc.lp))
+			if err := lc.Series("first", []float64{0, 100}, SeriesCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			if err := lc.Series("second", []float64{100, 0}, SeriesCellOpts(cell.FgColor(cell.ColorBlue))); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(image.Rectangle{Max: tc.size})
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := lc.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", name+".golden"), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
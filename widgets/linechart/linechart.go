@@ -19,29 +19,56 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/canvas"
 	"github.com/mum4k/termdash/canvas/braille"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
-	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/ringbuffer"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/linechart/axes"
+	"github.com/mum4k/termdash/widgets/linechart/legend"
 )
 
 // seriesValues represent values stored in the series.
 type seriesValues struct {
 	// values are the values in the series.
+	// For streaming series these are refreshed from buf before drawing.
 	values []float64
-	// min is the smallest value, zero if values is empty.
-	min float64
-	// max is the largest value, zero if values is empty.
-	max float64
+
+	// buf stores the values of a streaming series, nil for other series.
+	buf *ringbuffer.Buffer
+	// stale indicates that values of a streaming series are out of date with
+	// the content of buf.
+	stale bool
+	// The options for the ring buffer of a streaming series.
+	bufOpts []ringbuffer.Option
+
+	// The smallest and the largest value among all and among the positive
+	// values for series that aren't streaming, streaming series track them in
+	// buf. The ok bools indicate that such values exist.
+	min, max       float64
+	minMaxOK       bool
+	posMin, posMax float64
+	posMinMaxOK    bool
 
 	seriesCellOpts []cell.Option
+	// style determines how the values are drawn.
+	style Style
+	// baseline is the value where the area and the bars start, only valid if
+	// baselineSet is true.
+	baseline    float64
+	baselineSet bool
+	// rightY indicates that the series is plotted against the Y axis on the
+	// right side of the graph.
+	rightY bool
 	// The custom labels provided on a call to Series and a bool indicating if
 	// the labels were provided. This allows resetting them to nil.
 	xLabelsSet bool
@@ -50,14 +77,86 @@ type seriesValues struct {
 
 // newSeriesValues returns a new seriesValues instance.
 func newSeriesValues(values []float64) *seriesValues {
-	min, max := numbers.MinMax(values)
 	return &seriesValues{
 		values: values,
-		min:    min,
-		max:    max,
 	}
 }
 
+// init initializes the series after all the options were set.
+func (sv *seriesValues) init() error {
+	if len(sv.bufOpts) == 0 {
+		sv.min, sv.max, sv.minMaxOK = valuesMinMax(sv.values, false)
+		sv.posMin, sv.posMax, sv.posMinMaxOK = valuesMinMax(sv.values, true)
+		return nil
+	}
+
+	buf, err := ringbuffer.New(sv.bufOpts...)
+	if err != nil {
+		return err
+	}
+	for _, v := range sv.values {
+		buf.Append(v)
+	}
+	sv.buf = buf
+	sv.values = buf.Values()
+	return nil
+}
+
+// len returns the number of values in the series.
+func (sv *seriesValues) len() int {
+	if sv.buf != nil {
+		return sv.buf.Len()
+	}
+	return len(sv.values)
+}
+
+// refresh updates the values of a streaming series from its buffer.
+func (sv *seriesValues) refresh() {
+	if sv.buf != nil && sv.stale {
+		sv.values = sv.buf.Values()
+		sv.stale = false
+	}
+}
+
+// minMax returns the smallest and the largest value in the series. If
+// positive is true, only the positive values are considered. The returned ok
+// is false if there aren't any such values.
+// This has constant cost.
+func (sv *seriesValues) minMax(positive bool) (min, max float64, ok bool) {
+	switch {
+	case sv.buf == nil && positive:
+		return sv.posMin, sv.posMax, sv.posMinMaxOK
+	case sv.buf == nil:
+		return sv.min, sv.max, sv.minMaxOK
+	case positive:
+		return sv.buf.PositiveMinMax()
+	default:
+		return sv.buf.MinMax()
+	}
+}
+
+// valuesMinMax returns the smallest and the largest value, NaN values are
+// ignored. If positive is true, only the positive values are considered.
+// The returned ok is false if there aren't any such values.
+func valuesMinMax(values []float64, positive bool) (min, max float64, ok bool) {
+	for _, v := range values {
+		if math.IsNaN(v) || (positive && v <= 0) {
+			continue
+		}
+		if !ok {
+			min, max, ok = v, v, true
+			continue
+		}
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // LineChart draws line charts.
 //
 // Each line chart has an identifying label and a set of values that are
@@ -68,6 +167,16 @@ func newSeriesValues(values []float64) *seriesValues {
 // largest count of values among all the labeled line charts.
 // The Y axis will be sized so that it can conveniently accommodate the largest
 // value among all the labeled line charts. This determines the used scale.
+// The YAxisAdaptive, YAxisLog10 and YAxisValueRange options change how the Y
+// axis scales to the values. Series provided with the SeriesRightYAxis option
+// are plotted against a second Y axis drawn on the right side of the graph.
+// The SeriesStyle option determines how the values of a series are drawn,
+// NaN values aren't drawn and leave a gap in the series.
+//
+// When enabled with the EnableZoom option, the user can zoom into a range
+// on the X axis by selecting it with the mouse or by pressing the zoom keys
+// and pan the zoomed range with the keyboard or the mouse wheel. The labels on
+// the X axis reflect the zoomed range.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type LineChart struct {
@@ -80,22 +189,59 @@ type LineChart struct {
 
 	// yAxis is the Y axis of the line chart.
 	yAxis *axes.Y
+	// rightYAxis is the Y axis on the right side of the graph. Only drawn if
+	// any of the series was assigned to it.
+	rightYAxis *axes.Y
 
 	// opts are the provided options.
 	opts *options
 
 	// xLabels that were provided on a call to Series.
 	xLabels map[int]string
+
+	// The annotations of the line chart, initialized from the options and
+	// updated by the methods that add and remove annotations.
+	hLines   []*hLine
+	vMarkers []*vMarker
+	yBands   []*yBand
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+
+	// zoom tracks the zoomed range of the X axis.
+	zoom *zoomTracker
+	// xDetails are the details of the X axis from the last call to Draw, nil
+	// if the line chart wasn't drawn yet. Used to map mouse events to values.
+	xDetails *axes.XDetails
+	// chartAr is the area of the canvas occupied by the axes and the graph in
+	// the last call to Draw, i.e. the canvas area without the legend.
+	chartAr image.Rectangle
+
+	// crosshair is the value on the X axis where the crosshair is placed.
+	// Only valid if crosshairSet is true.
+	crosshair    int
+	crosshairSet bool
 }
 
 // New returns a new line chart widget.
 func New(opts ...Option) *LineChart {
 	opt := newOptions(opts...)
-	return &LineChart{
+	lc := &LineChart{
 		series: map[string]*seriesValues{},
-		yAxis:  axes.NewY(0, 0),
 		opts:   opt,
+		zoom:   newZoomTracker(),
+	}
+	for _, hl := range opt.hLines {
+		lc.addHLine(hl)
+	}
+	for _, vm := range opt.vMarkers {
+		lc.addVMarker(vm)
 	}
+	for _, yb := range opt.yBands {
+		lc.addYBand(yb)
+	}
+	lc.updateYAxes()
+	return lc
 }
 
 // SeriesOption is used to provide options to Series.
@@ -117,6 +263,8 @@ func (so seriesOption) set(sv *seriesValues) {
 // cell can only have one set of cell options set. Meaning that where series
 // share a cell, the last drawn series sets the cell options. Series are drawn
 // in alphabetical order based on their name.
+// Series without a color in the cell options use the series colors of the
+// theme set on the container in the order in which they are drawn.
 func SeriesCellOpts(co ...cell.Option) SeriesOption {
 	return seriesOption(func(opts *seriesValues) {
 		opts.seriesCellOpts = co
@@ -135,6 +283,68 @@ func SeriesXLabels(labels map[int]string) SeriesOption {
 	})
 }
 
+// SeriesStyle sets the style in which the values of this series are drawn.
+// Defaults to StyleLine.
+func SeriesStyle(s Style) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.style = s
+	})
+}
+
+// SeriesBaseline sets the value where the area of a series drawn in
+// StyleArea and the columns of a series drawn in StyleBars start. If not
+// provided, these start at the X axis. A baseline outside of the range
+// displayed on the Y axis is placed at the closest edge of the graph.
+func SeriesBaseline(v float64) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.baseline = v
+		opts.baselineSet = true
+	})
+}
+
+// DefaultSeriesCapacity is the maximum number of values held by streaming
+// series created without the SeriesCapacity option.
+const DefaultSeriesCapacity = ringbuffer.DefaultCapacity
+
+// SeriesCapacity makes this a streaming series that holds at most the
+// specified number of values. Values are added to a streaming series by
+// calling AppendPoint, when the series is full the oldest value is dropped.
+// If more values are provided on the call to Series, only the last n are
+// kept.
+// The values of a streaming series are stored in a ring buffer, so the memory
+// and the time spent on each AppendPoint stay constant regardless of how long
+// the line chart runs.
+// Must be a positive number, streaming series created without this option
+// hold at most DefaultSeriesCapacity values.
+func SeriesCapacity(n int) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.bufOpts = append(opts.bufOpts, ringbuffer.Capacity(n))
+	})
+}
+
+// SeriesMaxAge makes this a streaming series that holds the values appended
+// within the specified duration. Values are added to a streaming series by
+// calling AppendPoint, each appended value drops values that are older than
+// the duration. Can be combined with SeriesCapacity.
+// See SeriesCapacity for details about streaming series.
+func SeriesMaxAge(d time.Duration) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.bufOpts = append(opts.bufOpts, ringbuffer.MaxAge(d))
+	})
+}
+
+// SeriesRightYAxis plots this series against a second Y axis drawn on the
+// right side of the graph at the edge of the canvas. The right Y axis scales
+// and labels independently of the Y axis on the left, which allows plotting
+// values with different units on the same line chart.
+// The RightYAxisAdaptive, RightYAxisLog10 and RightYAxisValueRange options
+// configure the scale of the right Y axis.
+func SeriesRightYAxis() SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.rightY = true
+	})
+}
+
 // Series sets the values that should be displayed as the line chart with the
 // provided label.
 // Subsequent calls with the same label replace any previously provided values.
@@ -150,6 +360,9 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 	for _, opt := range opts {
 		opt.set(series)
 	}
+	if err := series.init(); err != nil {
+		return err
+	}
 	if series.xLabelsSet {
 		for i, t := range series.xLabels {
 			if i < 0 {
@@ -163,126 +376,463 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 	}
 
 	lc.series[label] = series
-	lc.yAxis = axes.NewY(series.min, series.max)
+	lc.updateYAxes()
 	return nil
 }
 
+// AppendPoint appends the value to the end of a streaming series with the
+// provided label. The series must be first created by calling Series with
+// the SeriesCapacity or the SeriesMaxAge option.
+// Use math.NaN() to indicate a missing value, which leaves a gap in the
+// series.
+func (lc *LineChart) AppendPoint(label string, v float64) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	sv, ok := lc.series[label]
+	if !ok {
+		return fmt.Errorf("series %q doesn't exist, create it by calling Series first", label)
+	}
+	if sv.buf == nil {
+		return fmt.Errorf("series %q isn't a streaming series, create it with the SeriesCapacity or the SeriesMaxAge option", label)
+	}
+	sv.buf.Append(v)
+	sv.stale = true
+	lc.updateYAxes()
+	return nil
+}
+
+// updateYAxes updates both Y axes to accommodate the stored series.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) updateYAxes() {
+	lc.yAxis = axes.NewY(lc.yRange(lc.opts.yAxis, false))
+	lc.rightYAxis = axes.NewRightY(lc.yRange(lc.opts.rightYAxis, true))
+}
+
+// hasRightYAxis asserts whether any of the series is plotted against the Y
+// axis on the right side of the graph.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) hasRightYAxis() bool {
+	for _, sv := range lc.series {
+		if sv.rightY {
+			return true
+		}
+	}
+	return false
+}
+
+// yRange returns the range of values a Y axis with the provided options must
+// accommodate. This covers the values of all the series plotted against the
+// left or the right Y axis and the value range provided in the options.
+// NaN values are ignored. On the logarithmic scale only the positive values
+// are considered and the range defaults to (1, 10) if there aren't any.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) yRange(yo yAxisOptions, right bool) (float64, float64) {
+	log := yo.scaleMode == axes.YScaleModeLog10
+	var min, max float64
+	found := false
+	include := func(v float64) {
+		if math.IsNaN(v) || (log && v <= 0) {
+			return
+		}
+		if !found {
+			min, max = v, v
+			found = true
+			return
+		}
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	if yo.rangeSet {
+		include(yo.min)
+		include(yo.max)
+	}
+	for _, v := range lc.annotationValues(right) {
+		include(v)
+	}
+	for _, sv := range lc.series {
+		if sv.rightY != right {
+			continue
+		}
+		if min, max, ok := sv.minMax(log); ok {
+			include(min)
+			include(max)
+		}
+	}
+
+	if log && !found {
+		return 1, 10
+	}
+	return min, max
+}
+
 // Draw draws the values as line charts.
 // Implements widgetapi.Widget.Draw.
 func (lc *LineChart) Draw(cvs *canvas.Canvas) error {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
 
-	yd, err := lc.yAxis.Details(cvs.Area())
+	for _, sv := range lc.series {
+		sv.refresh()
+	}
+	names := lc.seriesNames()
+	entries := lc.legendEntries(names)
+	chartAr, legendAr := legend.Layout(cvs.Area(), lc.opts.legend, entries, lc.minSize())
+	chart, err := canvas.New(chartAr)
+	if err != nil {
+		return fmt.Errorf("canvas.New => %v", err)
+	}
+
+	// The area for the left Y axis, the X axis and the graph.
+	leftAr := chart.Area()
+	var ryd *axes.YDetails
+	if lc.hasRightYAxis() {
+		// The width required by the left Y axis is reserved, the right Y axis
+		// can take the remaining width.
+		rightAr := leftAr
+		rightAr.Min.X += lc.yAxis.RequiredWidth(lc.opts.yAxis.scaleMode)
+		d, err := lc.rightYAxis.Details(rightAr, lc.opts.rightYAxis.scaleMode)
+		if err != nil {
+			return fmt.Errorf("lc.rightYAxis.Details => %v", err)
+		}
+		ryd = d
+		leftAr = image.Rect(leftAr.Min.X, leftAr.Min.Y, ryd.Start.X, leftAr.Max.Y)
+	}
+
+	yd, err := lc.yAxis.Details(leftAr, lc.opts.yAxis.scaleMode)
 	if err != nil {
 		return fmt.Errorf("lc.yAxis.Details => %v", err)
 	}
 
-	xd, err := axes.NewXDetails(lc.maxPoints(), yd.Start, cvs.Area(), lc.xLabels)
+	xMin, xMax := lc.zoom.visible(lc.maxPoints() - 1)
+	xd, err := axes.NewXDetailsRange(xMin, xMax, yd.Start, leftAr, lc.xLabels)
 	if err != nil {
-		return fmt.Errorf("NewXDetails => %v", err)
+		return fmt.Errorf("NewXDetailsRange => %v", err)
+	}
+	lc.xDetails = xd
+	lc.chartAr = chartAr
+
+	axesOpts, xLabelOpts, yLabelOpts := lc.axesCellOpts()
+	if err := lc.drawAxes(chart, xd, yd, ryd, axesOpts, xLabelOpts, yLabelOpts); err != nil {
+		return err
+	}
+	if err := lc.drawSeries(chart, xd, yd, ryd, names, axesOpts); err != nil {
+		return err
 	}
 
-	if err := lc.drawAxes(cvs, xd, yd); err != nil {
+	graphAr := graphArea(xd, yd)
+	if err := lc.drawYBands(chart, graphAr, yd, ryd); err != nil {
+		return err
+	}
+	if err := lc.drawAnnotationTexts(chart, graphAr, xd, yd, ryd, axesOpts); err != nil {
+		return err
+	}
+	if err := lc.drawCrosshairValues(chart, xd, graphAr, names, xLabelOpts); err != nil {
+		return err
+	}
+	if lc.opts.legend.Inside() {
+		insideAr := legend.InsideArea(graphAr, lc.opts.legend, entries)
+		if err := legend.Draw(chart, insideAr, lc.opts.legend, entries); err != nil {
+			return err
+		}
+	}
+	if err := lc.drawSelection(chart, xd); err != nil {
 		return err
 	}
-	return lc.drawSeries(cvs, xd, yd)
+	if err := chart.CopyTo(cvs); err != nil {
+		return fmt.Errorf("chart.CopyTo => %v", err)
+	}
+	return legend.Draw(cvs, legendAr, lc.opts.legend, entries)
+}
+
+// axesCellOpts returns the cell options for the axes and the labels on the X
+// and Y axes.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) axesCellOpts() (axesOpts, xLabelOpts, yLabelOpts []cell.Option) {
+	axesOpts = lc.opts.axesCellOpts
+	xLabelOpts = lc.opts.xLabelCellOpts
+	yLabelOpts = lc.opts.yLabelCellOpts
+	if t := lc.theme; t != nil {
+		axesOpts = withFgColor(t.Axis, axesOpts)
+		xLabelOpts = withFgColor(t.Label, xLabelOpts)
+		yLabelOpts = withFgColor(t.Label, yLabelOpts)
+	}
+	return axesOpts, xLabelOpts, yLabelOpts
 }
 
-// drawAxes draws the X,Y axes and their labels.
-func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
+// graphArea returns the area of the canvas available to the graph, i.e. the
+// area between the Y axes and above the X axis.
+func graphArea(xd *axes.XDetails, yd *axes.YDetails) image.Rectangle {
+	return image.Rect(yd.Start.X+1, yd.Start.Y, xd.End.X+1, xd.End.Y)
+}
+
+// drawAxes draws the X,Y axes and their labels. The ryd are the details of the
+// Y axis on the right side of the graph, nil if it isn't drawn.
+func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd, ryd *axes.YDetails, axesOpts, xLabelOpts, yLabelOpts []cell.Option) error {
 	lines := []draw.HVLine{
 		{Start: yd.Start, End: yd.End},
-		{Start: xd.Start, End: xd.End},
 	}
-	if err := draw.HVLines(cvs, lines, draw.HVLineCellOpts(lc.opts.axesCellOpts...)); err != nil {
+	if ryd != nil {
+		// The X axis connects to the right Y axis.
+		lines = append(lines,
+			draw.HVLine{Start: xd.Start, End: image.Point{ryd.End.X, xd.End.Y}},
+			draw.HVLine{Start: ryd.Start, End: ryd.End},
+		)
+	} else {
+		lines = append(lines, draw.HVLine{Start: xd.Start, End: xd.End})
+	}
+	if err := draw.HVLines(cvs, lines, draw.HVLineCellOpts(axesOpts...)); err != nil {
 		return fmt.Errorf("failed to draw the axes: %v", err)
 	}
 
-	for _, l := range yd.Labels {
-		if err := draw.Text(cvs, l.Value.Text(), l.Pos,
-			draw.TextMaxX(yd.Start.X),
-			draw.TextOverrunMode(draw.OverrunModeThreeDot),
-			draw.TextCellOpts(lc.opts.yLabelCellOpts...),
-		); err != nil {
-			return fmt.Errorf("failed to draw the Y labels: %v", err)
+	if err := drawYLabels(cvs, yd, yd.Start.X, yLabelOpts); err != nil {
+		return err
+	}
+	if ryd != nil {
+		if err := drawYLabels(cvs, ryd, cvs.Area().Max.X, yLabelOpts); err != nil {
+			return err
 		}
 	}
 
 	for _, l := range xd.Labels {
-		if err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(lc.opts.xLabelCellOpts...)); err != nil {
+		if err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(xLabelOpts...)); err != nil {
 			return fmt.Errorf("failed to draw the X labels: %v", err)
 		}
 	}
 	return nil
 }
 
-// drawSeries draws the graph representing the stored series.
-func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
-	// The area available to the graph.
-	graphAr := image.Rect(yd.Start.X+1, yd.Start.Y, cvs.Area().Max.X, xd.End.Y)
-	bc, err := braille.New(graphAr)
+// drawYLabels draws the labels of a Y axis. Labels are trimmed at maxX.
+func drawYLabels(cvs *canvas.Canvas, yd *axes.YDetails, maxX int, yLabelOpts []cell.Option) error {
+	for _, l := range yd.Labels {
+		if err := draw.Text(cvs, l.Value.Text(), l.Pos,
+			draw.TextMaxX(maxX),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(yLabelOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the Y labels: %v", err)
+		}
+	}
+	return nil
+}
+
+// drawSeries draws the graph representing the stored series with the provided
+// names. Series assigned to the right Y axis are plotted using the ryd.
+// The crosshair is drawn with the cell options of the axes.
+func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd, ryd *axes.YDetails, names []string, axesOpts []cell.Option) error {
+	bc, err := braille.New(graphArea(xd, yd))
 	if err != nil {
 		return fmt.Errorf("braille.New => %v", err)
 	}
 
+	// Drawn first, so that the series take precedence in shared cells.
+	if err := lc.drawAnnotationLines(bc, xd, yd, ryd, axesOpts); err != nil {
+		return err
+	}
+	if err := lc.drawCrosshairLine(bc, xd, axesOpts); err != nil {
+		return err
+	}
+
+	for si, name := range names {
+		sv := lc.series[name]
+		seriesOpts := lc.seriesCellOpts(si, name)
+		sd := yd
+		if sv.rightY {
+			sd = ryd
+		}
+
+		// Only the values in the zoomed range are drawn.
+		first, last := int(xd.Scale.Min.Value), int(xd.Scale.Max.Value)
+		if last > len(sv.values)-1 {
+			last = len(sv.values) - 1
+		}
+		if first > last {
+			continue
+		}
+		samples, err := downsample(lc.opts.downsample, xd, sd, sv.values, first, last)
+		if err != nil {
+			return fmt.Errorf("failure for series %v: %v", name, err)
+		}
+		points, err := seriesPoints(xd, sd, samples)
+		if err != nil {
+			return fmt.Errorf("failure for series %v: %v", name, err)
+		}
+		baseline, err := baselinePixel(sd, sv)
+		if err != nil {
+			return fmt.Errorf("failure for series %v: %v", name, err)
+		}
+		if err := drawStyled(bc, sv.style, points, baseline, seriesOpts); err != nil {
+			return fmt.Errorf("failed to draw series %v: %v", name, err)
+		}
+	}
+	if err := bc.CopyTo(cvs); err != nil {
+		return fmt.Errorf("bc.Apply => %v", err)
+	}
+	return nil
+}
+
+// seriesNames returns the names of the series in the order in which they are
+// drawn.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) seriesNames() []string {
 	var names []string
 	for name := range lc.series {
 		names = append(names, name)
 	}
 	sort.Strings(names)
+	return names
+}
 
-	for _, name := range names {
-		sv := lc.series[name]
-		if len(sv.values) <= 1 {
-			continue
-		}
+// seriesCellOpts returns the cell options of the series with the name, the si
+// is the index of the series in the order in which they are drawn.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) seriesCellOpts(si int, name string) []cell.Option {
+	cOpts := lc.series[name].seriesCellOpts
+	if lc.theme != nil {
+		cOpts = withFgColor(lc.theme.SeriesColor(si), cOpts)
+	}
+	return cOpts
+}
 
-		prev := sv.values[0]
-		for i := 1; i < len(sv.values); i++ {
-			startX, err := xd.Scale.ValueToPixel(i - 1)
-			if err != nil {
-				return fmt.Errorf("failure for series %v[%d], xd.Scale.ValueToPixel => %v", name, i-1, err)
-			}
-			endX, err := xd.Scale.ValueToPixel(i)
-			if err != nil {
-				return fmt.Errorf("failure for series %v[%d], xd.Scale.ValueToPixel => %v", name, i, err)
-			}
+// drawSelection highlights the range on the graph that the user is selecting
+// with the mouse.
+func (lc *LineChart) drawSelection(cvs *canvas.Canvas, xd *axes.XDetails) error {
+	start, end, ok := lc.zoom.selection()
+	if !ok || !lc.opts.zoom {
+		return nil
+	}
+	if max := xd.Scale.GraphWidth - 1; end > max {
+		end = max
+	}
 
-			startY, err := yd.Scale.ValueToPixel(prev)
-			if err != nil {
-				return fmt.Errorf("failure for series %v[%d], yd.Scale.ValueToPixel => %v", name, i-1, err)
-			}
-			v := sv.values[i]
-			endY, err := yd.Scale.ValueToPixel(v)
+	graphX := xd.Start.X + 1
+	for x := graphX + start; x <= graphX+end; x++ {
+		for y := 0; y < xd.Start.Y; y++ {
+			p := image.Point{x, y}
+			c, err := cvs.Cell(p)
 			if err != nil {
-				return fmt.Errorf("failure for series %v[%d], yd.Scale.ValueToPixel => %v", name, i, err)
+				return err
 			}
-
-			if err := draw.BrailleLine(bc,
-				image.Point{startX, startY},
-				image.Point{endX, endY},
-				draw.BrailleLineCellOpts(sv.seriesCellOpts...),
-			); err != nil {
-				return fmt.Errorf("draw.BrailleLine => %v", err)
+			if _, err := cvs.SetCell(p, c.Rune, cell.BgColor(lc.opts.zoomHighlightColor)); err != nil {
+				return err
 			}
-			prev = v
 		}
 	}
-	if err := bc.CopyTo(cvs); err != nil {
-		return fmt.Errorf("bc.Apply => %v", err)
-	}
 	return nil
 }
 
-// Keyboard implements widgetapi.Widget.Keyboard.
+// withFgColor returns the cell options prefixed with the foreground color,
+// so that colors in the options take precedence.
+func withFgColor(c cell.Color, cOpts []cell.Option) []cell.Option {
+	return append([]cell.Option{cell.FgColor(c)}, cOpts...)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (lc *LineChart) SetTheme(t *theme.Theme) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.theme = t
+}
+
+// Keyboard zooms and pans the X axis.
+// Implements widgetapi.Widget.Keyboard.
 func (lc *LineChart) Keyboard(k *terminalapi.Keyboard) error {
-	return errors.New("the LineChart widget doesn't support keyboard events")
+	if !lc.opts.zoom {
+		return errors.New("the LineChart widget doesn't support keyboard events when zoom is disabled")
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	last := lc.maxPoints() - 1
+	switch {
+	case k.Key == lc.opts.keyZoomIn:
+		lc.zoom.zoomIn(last)
+	case k.Key == lc.opts.keyZoomOut:
+		lc.zoom.zoomOut(last)
+	case k.Key == lc.opts.keyZoomReset:
+		lc.zoom.reset()
+	case k.Key == lc.opts.keyPanLeft:
+		lc.zoom.pan(-1, last)
+	case k.Key == lc.opts.keyPanRight:
+		lc.zoom.pan(1, last)
+	}
+	return nil
 }
 
-// Mouse implements widgetapi.Widget.Mouse.
+// Mouse zooms into the range selected by dragging the mouse with the left
+// button pressed and pans the X axis. If the crosshair is enabled, a click on
+// the graph places the crosshair.
+// Implements widgetapi.Widget.Mouse.
 func (lc *LineChart) Mouse(m *terminalapi.Mouse) error {
-	return errors.New("the LineChart widget doesn't support mouse events")
+	if !lc.opts.zoom && !lc.opts.crosshair {
+		return errors.New("the LineChart widget doesn't support mouse events when zoom and crosshair are disabled")
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	last := lc.maxPoints() - 1
+	zoom := lc.opts.zoom
+	switch b := m.Button; {
+	case zoom && b == lc.opts.mousePanLeft:
+		lc.zoom.pan(-1, last)
+	case zoom && b == lc.opts.mousePanRight:
+		lc.zoom.pan(1, last)
+
+	case b == mouse.ButtonLeft:
+		xd := lc.xDetails
+		if xd == nil {
+			return nil // Not drawn yet.
+		}
+		// Mouse events are relative to the widget's canvas, the chart can
+		// be offset by the legend.
+		p := m.Position.Sub(lc.chartAr.Min)
+		graphX := xd.Start.X + 1
+		if !lc.zoom.dragging && (p.X < graphX || p.Y < 0 || p.Y >= xd.Start.Y) {
+			return nil // Selection must start on the graph.
+		}
+		x := p.X - graphX
+		if x < 0 {
+			x = 0
+		}
+		if max := xd.Scale.GraphWidth - 1; x > max {
+			x = max
+		}
+		lc.zoom.drag(x)
+
+	case b == mouse.ButtonRelease:
+		start, end, ok := lc.zoom.release()
+		if !ok || lc.xDetails == nil {
+			return nil
+		}
+		if start == end { // Just a click, not a selection.
+			if lc.opts.crosshair {
+				return lc.toggleCrosshair(start)
+			}
+			return nil
+		}
+		if !zoom {
+			return nil
+		}
+
+		scale := lc.xDetails.Scale
+		sv, err := scale.CellLabel(start)
+		if err != nil {
+			return fmt.Errorf("failed to determine the start of the selection: %v", err)
+		}
+		ev, err := scale.CellLabel(end)
+		if err != nil {
+			return fmt.Errorf("failed to determine the end of the selection: %v", err)
+		}
+		lc.zoom.zoomTo(int(sv.Value), int(ev.Value), last)
+	}
+	return nil
 }
 
 // Options implements widgetapi.Widget.Options.
@@ -290,15 +840,28 @@ func (lc *LineChart) Options() widgetapi.Options {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
 
+	return widgetapi.Options{
+		MinimumSize:  lc.minSize(),
+		WantKeyboard: lc.opts.zoom,
+		WantMouse:    lc.opts.zoom || lc.opts.crosshair,
+	}
+}
+
+// minSize returns the minimum canvas size required to draw the line chart
+// without the legend.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) minSize() image.Point {
 	// At the very least we need:
 	// - n cells width for the Y axis and its labels as reported by it.
 	// - at least 1 cell width for the graph.
-	reqWidth := lc.yAxis.RequiredWidth() + 1
+	reqWidth := lc.yAxis.RequiredWidth(lc.opts.yAxis.scaleMode) + 1
+	// - n cells width for the right Y axis and its labels if it is drawn.
+	if lc.hasRightYAxis() {
+		reqWidth += lc.rightYAxis.RequiredWidth(lc.opts.rightYAxis.scaleMode)
+	}
 	// - 2 cells height the X axis and its values and 2 for min and max labels on Y.
 	const reqHeight = 4
-	return widgetapi.Options{
-		MinimumSize: image.Point{reqWidth, reqHeight},
-	}
+	return image.Point{reqWidth, reqHeight}
 }
 
 // maxPoints returns the largest number of points among all the series.
@@ -306,7 +869,7 @@ func (lc *LineChart) Options() widgetapi.Options {
 func (lc *LineChart) maxPoints() int {
 	max := 0
 	for _, sv := range lc.series {
-		if num := len(sv.values); num > max {
+		if num := sv.len(); num > max {
 			max = num
 		}
 	}
@@ -15,6 +15,7 @@
 package linechart
 
 import (
+	"fmt"
 	"image"
 	"testing"
 
@@ -25,7 +26,10 @@ import (
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/draw/testdraw"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -63,6 +67,41 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantWriteErr: true,
 		},
+		{
+			desc:   "series fails on invalid capacity",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesCapacity(-1))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "series fails on zero capacity",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesCapacity(0))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "append point fails on unknown series",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.AppendPoint("series", 1)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "append point fails on series that isn't streaming",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("series", nil); err != nil {
+					return err
+				}
+				return lc.AppendPoint("series", 1)
+			},
+			wantWriteErr: true,
+		},
 		{
 			desc:    "draw fails when canvas not wide enough",
 			canvas:  image.Rect(0, 0, 2, 4),
@@ -188,6 +227,328 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "fixed value range extends the Y axis",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YAxisValueRange(0, 200),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 16})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "adaptive Y axis starts at the minimum",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YAxisAdaptive(),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{100, 110})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "100", image.Point{3, 7})
+				testdraw.MustText(c, "105.28", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 1})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "logarithmic Y axis",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YAxisLog10(),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{1, 1000})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
+					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "1", image.Point{3, 7})
+				testdraw.MustText(c, "10", image.Point{2, 5})
+				testdraw.MustText(c, "100", image.Point{1, 2})
+				testdraw.MustText(c, "1000", image.Point{0, 0})
+				testdraw.MustText(c, "0", image.Point{5, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(5, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{29, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "logarithmic Y axis skips values that aren't positive",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YAxisLog10(),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-1, 1, 1000})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
+					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "1", image.Point{3, 7})
+				testdraw.MustText(c, "10", image.Point{2, 5})
+				testdraw.MustText(c, "100", image.Point{1, 2})
+				testdraw.MustText(c, "1000", image.Point{0, 0})
+				testdraw.MustText(c, "0", image.Point{5, 9})
+				testdraw.MustText(c, "1", image.Point{12, 9})
+				testdraw.MustText(c, "2", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(5, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{14, 31}, image.Point{29, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "series on the right Y axis scale independently",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("left", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("right", []float64{2, 0}, SeriesRightYAxis())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y, X and the right Y axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{14, 8}},
+					{Start: image.Point{14, 0}, End: image.Point{14, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{15, 7})
+				testdraw.MustText(c, "1.040", image.Point{15, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{13, 9})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 14, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{15, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{15, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "streaming series drops the oldest values",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{-100, 100, 0}, SeriesCapacity(2)); err != nil {
+					return err
+				}
+				return lc.AppendPoint("first", 100)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "zoomed in with the keyboard",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				EnableZoom(),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 25, 50, 75, 100}); err != nil {
+					return err
+				}
+				return lc.Keyboard(&terminalapi.Keyboard{Key: DefaultZoomKeyIn})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels, the X axis only shows the zoomed range.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "1", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{12, 9})
+				testdraw.MustText(c, "3", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 23}, image.Point{13, 16})
+				testdraw.MustBrailleLine(bc, image.Point{13, 16}, image.Point{27, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights the range selected with the mouse",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				EnableZoom(),
+				ZoomHighlightColor(cell.ColorRed),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				// Draw once so that the widget knows its layout.
+				if err := lc.Draw(testcanvas.MustNew(image.Rect(0, 0, 20, 10))); err != nil {
+					return err
+				}
+				if err := lc.Mouse(&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft}); err != nil {
+					return err
+				}
+				return lc.Mouse(&terminalapi.Mouse{Position: image.Point{9, 5}, Button: mouse.ButtonLeft})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				// The selection.
+				for x := 8; x <= 9; x++ {
+					for y := 0; y < 8; y++ {
+						p := image.Point{x, y}
+						cl := testcanvas.MustCell(c, p)
+						testcanvas.MustSetCell(c, p, cl.Rune, cell.BgColor(cell.ColorRed))
+					}
+				}
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "custom X labels",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -324,6 +685,55 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "uses colors from the theme",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				XLabelCellOpts(cell.FgColor(cell.ColorYellow)),
+			},
+			writes: func(lc *LineChart) error {
+				lc.SetTheme(&theme.Theme{
+					Axis:   cell.ColorRed,
+					Label:  cell.ColorGreen,
+					Series: []cell.Color{cell.ColorBlue, cell.ColorMagenta},
+				})
+				if err := lc.Series("first", []float64{0, 50, 100}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{100, 0}, SeriesCellOpts(cell.FgColor(cell.ColorCyan)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines, draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)))
+
+				// Value labels.
+				yOpts := draw.TextCellOpts(cell.FgColor(cell.ColorGreen))
+				xOpts := draw.TextCellOpts(cell.FgColor(cell.ColorYellow))
+				testdraw.MustText(c, "0", image.Point{4, 7}, yOpts)
+				testdraw.MustText(c, "51.68", image.Point{0, 3}, yOpts)
+				testdraw.MustText(c, "0", image.Point{6, 9}, xOpts)
+				testdraw.MustText(c, "1", image.Point{12, 9}, xOpts)
+				testdraw.MustText(c, "2", image.Point{19, 9}, xOpts)
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 16}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustBrailleLine(bc, image.Point{13, 16}, image.Point{27, 0}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{13, 31}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorCyan)))
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "draw multiple series",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -453,6 +863,7 @@ func TestLineChartDraws(t *testing.T) {
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
+		opts []Option
 		// if not nil, executed before obtaining the options.
 		addSeries func(*LineChart) error
 		want      widgetapi.Options
@@ -463,6 +874,17 @@ func TestOptions(t *testing.T) {
 				MinimumSize: image.Point{3, 4},
 			},
 		},
+		{
+			desc: "wants keyboard and mouse events when zoom is enabled",
+			opts: []Option{
+				EnableZoom(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 4},
+				WantKeyboard: true,
+				WantMouse:    true,
+			},
+		},
 		{
 			desc: "reserves space for longer Y labels",
 			addSeries: func(lc *LineChart) error {
@@ -472,6 +894,54 @@ func TestOptions(t *testing.T) {
 				MinimumSize: image.Point{5, 4},
 			},
 		},
+		{
+			desc: "reserves space for the fixed Y value range",
+			opts: []Option{
+				YAxisValueRange(-100, 100),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.Series("series", []float64{0, 1})
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{6, 4},
+			},
+		},
+		{
+			desc: "reserves space for Y labels of all the series",
+			addSeries: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{-100, 0}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{0, 1})
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{6, 4},
+			},
+		},
+		{
+			desc: "reserves space for the right Y axis",
+			addSeries: func(lc *LineChart) error {
+				if err := lc.Series("left", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("right", []float64{-100, 0}, SeriesRightYAxis())
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{10, 4},
+			},
+		},
+		{
+			desc: "reserves space for the powers of ten on the log scale",
+			opts: []Option{
+				RightYAxisLog10(),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.Series("right", []float64{1, 2}, SeriesRightYAxis())
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{6, 4},
+			},
+		},
 		{
 			desc: "reserves space for negative Y labels",
 			addSeries: func(lc *LineChart) error {
@@ -485,7 +955,7 @@ func TestOptions(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			lc := New()
+			lc := New(tc.opts...)
 
 			if tc.addSeries != nil {
 				if err := tc.addSeries(lc); err != nil {
@@ -499,3 +969,55 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestDrawsAtMinimumSize(t *testing.T) {
+	// axisMode are the options that set the scale mode of the Y axes.
+	type axisMode struct {
+		desc  string
+		left  []Option
+		right []Option
+	}
+	modes := []axisMode{
+		{desc: "anchored"},
+		{desc: "adaptive", left: []Option{YAxisAdaptive()}, right: []Option{RightYAxisAdaptive()}},
+		{desc: "log10", left: []Option{YAxisLog10()}, right: []Option{RightYAxisLog10()}},
+		{desc: "value range", left: []Option{YAxisValueRange(-10, 10)}, right: []Option{RightYAxisValueRange(-10, 10)}},
+	}
+	values := [][]float64{
+		{1, 2},
+		{5, 50000},
+		{0.5, 1.25},
+		{-3, 7},
+	}
+
+	for _, left := range modes {
+		for _, right := range append([]axisMode{{desc: "none"}}, modes...) {
+			for _, v := range values {
+				desc := fmt.Sprintf("left %s, right %s, values %v", left.desc, right.desc, v)
+				t.Run(desc, func(t *testing.T) {
+					opts := append(append([]Option{}, left.left...), right.right...)
+					lc := New(opts...)
+					if err := lc.Series("left", v); err != nil {
+						t.Fatalf("Series => unexpected error: %v", err)
+					}
+					if right.desc != "none" {
+						if err := lc.Series("right", v, SeriesRightYAxis()); err != nil {
+							t.Fatalf("Series => unexpected error: %v", err)
+						}
+					}
+
+					min := lc.Options().MinimumSize
+					for _, size := range []image.Point{min, {min.X, min.Y + 1}, {min.X, min.Y + 2}} {
+						cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+						if err != nil {
+							t.Fatalf("canvas.New => unexpected error: %v", err)
+						}
+						if err := lc.Draw(cvs); err != nil {
+							t.Errorf("Draw at %v => unexpected error: %v", size, err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -86,6 +86,7 @@ func main() {
 		linechart.AxesCellOpts(cell.FgColor(cell.ColorRed)),
 		linechart.YLabelCellOpts(cell.FgColor(cell.ColorGreen)),
 		linechart.XLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		linechart.EnableZoom(),
 	)
 	go playLineChart(ctx, lc, redrawInterval/3)
 	c, err := container.New(
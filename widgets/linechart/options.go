@@ -14,7 +14,12 @@
 
 package linechart
 
-import "github.com/mum4k/termdash/cell"
+import (
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+)
 
 // options.go contains configurable options for LineChart.
 
@@ -29,11 +34,50 @@ type options struct {
 	axesCellOpts   []cell.Option
 	xLabelCellOpts []cell.Option
 	yLabelCellOpts []cell.Option
+
+	zoom               bool
+	zoomHighlightColor cell.Color
+	keyZoomIn          keyboard.Key
+	keyZoomOut         keyboard.Key
+	keyZoomReset       keyboard.Key
+	keyPanLeft         keyboard.Key
+	keyPanRight        keyboard.Key
+	mousePanLeft       mouse.Button
+	mousePanRight      mouse.Button
+
+	legend    LegendPlacement
+	crosshair bool
+
+	yAxis      yAxisOptions
+	rightYAxis yAxisOptions
+
+	downsample Downsample
+
+	hLines   []*hLine
+	vMarkers []*vMarker
+	yBands   []*yBand
+}
+
+// yAxisOptions stores the provided options for one of the Y axes.
+type yAxisOptions struct {
+	scaleMode axes.YScaleMode
+	min       float64
+	max       float64
+	rangeSet  bool
 }
 
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
-	opt := &options{}
+	opt := &options{
+		zoomHighlightColor: DefaultZoomHighlightColor,
+		keyZoomIn:          DefaultZoomKeyIn,
+		keyZoomOut:         DefaultZoomKeyOut,
+		keyZoomReset:       DefaultZoomKeyReset,
+		keyPanLeft:         DefaultPanKeyLeft,
+		keyPanRight:        DefaultPanKeyRight,
+		mousePanLeft:       DefaultPanMouseButtonLeft,
+		mousePanRight:      DefaultPanMouseButtonRight,
+	}
 	for _, o := range opts {
 		o.set(opt)
 	}
@@ -49,6 +93,8 @@ func (o option) set(opts *options) {
 }
 
 // AxesCellOpts set the cell options for the X and Y axes.
+// The axes use the axis color of the theme set on the container unless the
+// cell options specify another color.
 func AxesCellOpts(co ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.axesCellOpts = co
@@ -56,6 +102,8 @@ func AxesCellOpts(co ...cell.Option) Option {
 }
 
 // XLabelCellOpts set the cell options for the labels on the X axis.
+// The labels use the label color of the theme set on the container unless the
+// cell options specify another color.
 func XLabelCellOpts(co ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.xLabelCellOpts = co
@@ -63,8 +111,168 @@ func XLabelCellOpts(co ...cell.Option) Option {
 }
 
 // YLabelCellOpts set the cell options for the labels on the Y axis.
+// The labels use the label color of the theme set on the container unless the
+// cell options specify another color.
 func YLabelCellOpts(co ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.yLabelCellOpts = co
 	})
 }
+
+// YAxisAdaptive configures the Y axis to start at the smallest and end at the
+// largest value among all the series. If not provided, the Y axis always
+// includes zero, i.e. it starts at zero if all the values are positive and
+// ends at zero if all the values are negative.
+func YAxisAdaptive() Option {
+	return option(func(opts *options) {
+		opts.yAxis.scaleMode = axes.YScaleModeAdaptive
+	})
+}
+
+// YAxisLog10 configures the Y axis to use a logarithmic scale with base 10.
+// The axis starts and ends at a power of ten and the labels are placed at the
+// powers of ten. Only positive values are displayed, line segments that
+// connect to a zero or a negative value aren't drawn.
+func YAxisLog10() Option {
+	return option(func(opts *options) {
+		opts.yAxis.scaleMode = axes.YScaleModeLog10
+	})
+}
+
+// YAxisValueRange sets a fixed range of values displayed on the Y axis, so
+// that the axis doesn't change as the values are updated. The range is
+// extended if any of the series has values outside of it. The Y axis still
+// includes zero unless YAxisAdaptive is also provided. When combined with
+// YAxisLog10, a min that isn't positive is ignored.
+func YAxisValueRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.yAxis.min = min
+		opts.yAxis.max = max
+		opts.yAxis.rangeSet = true
+	})
+}
+
+// RightYAxisAdaptive is like YAxisAdaptive, but applies to the Y axis on the
+// right side of the graph. See SeriesRightYAxis.
+func RightYAxisAdaptive() Option {
+	return option(func(opts *options) {
+		opts.rightYAxis.scaleMode = axes.YScaleModeAdaptive
+	})
+}
+
+// RightYAxisLog10 is like YAxisLog10, but applies to the Y axis on the right
+// side of the graph. See SeriesRightYAxis.
+func RightYAxisLog10() Option {
+	return option(func(opts *options) {
+		opts.rightYAxis.scaleMode = axes.YScaleModeLog10
+	})
+}
+
+// RightYAxisValueRange is like YAxisValueRange, but applies to the Y axis on
+// the right side of the graph. See SeriesRightYAxis.
+func RightYAxisValueRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.rightYAxis.min = min
+		opts.rightYAxis.max = max
+		opts.rightYAxis.rangeSet = true
+	})
+}
+
+// EnableZoom enables zooming and panning of the X axis using keyboard and
+// mouse. The line chart then requests keyboard and mouse events, see the
+// ZoomKeys, PanKeys and PanMouseButtons options.
+// Zoom is disabled by default.
+func EnableZoom() Option {
+	return option(func(opts *options) {
+		opts.zoom = true
+	})
+}
+
+// DefaultZoomHighlightColor is the default value for the ZoomHighlightColor
+// option.
+var DefaultZoomHighlightColor = cell.ColorNumber(236)
+
+// ZoomHighlightColor sets the background color of the range on the graph that
+// the user is selecting with the mouse in order to zoom into it.
+func ZoomHighlightColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.zoomHighlightColor = c
+	})
+}
+
+// The default keys for zooming.
+const (
+	DefaultZoomKeyIn    keyboard.Key = '+'
+	DefaultZoomKeyOut   keyboard.Key = '-'
+	DefaultZoomKeyReset keyboard.Key = '0'
+)
+
+// ZoomKeys configures the keys that zoom into the middle of the X axis, zoom
+// out and reset the zoom so that all the values are displayed.
+func ZoomKeys(in, out, reset keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyZoomIn = in
+		opts.keyZoomOut = out
+		opts.keyZoomReset = reset
+	})
+}
+
+// The default keys for panning of the zoomed X axis.
+const (
+	DefaultPanKeyLeft  = keyboard.KeyArrowLeft
+	DefaultPanKeyRight = keyboard.KeyArrowRight
+)
+
+// PanKeys configures the keys that move the zoomed range on the X axis
+// towards the smaller (left) or larger (right) values.
+func PanKeys(left, right keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyPanLeft = left
+		opts.keyPanRight = right
+	})
+}
+
+// The default mouse buttons for panning of the zoomed X axis.
+const (
+	DefaultPanMouseButtonLeft  = mouse.ButtonWheelUp
+	DefaultPanMouseButtonRight = mouse.ButtonWheelDown
+)
+
+// PanMouseButtons configures the mouse buttons that move the zoomed range on
+// the X axis towards the smaller (left) or larger (right) values.
+func PanMouseButtons(left, right mouse.Button) Option {
+	return option(func(opts *options) {
+		opts.mousePanLeft = left
+		opts.mousePanRight = right
+	})
+}
+
+// Legend draws a legend with the name of each series in its color at the
+// specified placement. The legend isn't drawn if the canvas is too small to
+// accommodate both the line chart and the legend.
+// Defaults to LegendNone.
+func Legend(lp LegendPlacement) Option {
+	return option(func(opts *options) {
+		opts.legend = lp
+	})
+}
+
+// Crosshair enables the crosshair. A mouse click on the graph places a
+// vertical line at the clicked value on the X axis and displays the values of
+// all the series at that point next to it. A click on the same value removes
+// the crosshair.
+func Crosshair() Option {
+	return option(func(opts *options) {
+		opts.crosshair = true
+	})
+}
+
+// Downsampling sets the strategy used to reduce the values of series that have
+// more values than there are pixel columns on the graph. This keeps the time
+// needed to draw the series proportional to the width of the canvas.
+// Defaults to DownsampleNone, i.e. all the values are drawn.
+func Downsampling(d Downsample) Option {
+	return option(func(opts *options) {
+		opts.downsample = d
+	})
+}
@@ -0,0 +1,273 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// style.go contains code that draws the series in the different styles.
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+)
+
+// Style determines how the values of a series are drawn.
+type Style int
+
+// String implements fmt.Stringer()
+func (s Style) String() string {
+	if n, ok := styleNames[s]; ok {
+		return n
+	}
+	return "StyleUnknown"
+}
+
+// styleNames maps Style values to human readable names.
+var styleNames = map[Style]string{
+	StyleLine:    "StyleLine",
+	StyleArea:    "StyleArea",
+	StyleStep:    "StyleStep",
+	StyleScatter: "StyleScatter",
+	StyleBars:    "StyleBars",
+}
+
+// Supported styles.
+const (
+	// StyleLine connects the values with straight lines.
+	StyleLine Style = iota
+
+	// StyleArea connects the values with straight lines and fills the area
+	// between the lines and the X axis or the baseline set with the
+	// SeriesBaseline option.
+	StyleArea
+
+	// StyleStep connects the values with horizontal and vertical lines. Each
+	// value is held until the next one, which suits counters.
+	StyleStep
+
+	// StyleScatter draws the values as individual points without connecting
+	// them.
+	StyleScatter
+
+	// StyleBars draws each value as a column that starts at the X axis or at
+	// the baseline set with the SeriesBaseline option.
+	StyleBars
+)
+
+// seriesPoints returns the pixels on the braille canvas that represent the
+// samples of the series. The pixel is nil for values that cannot be displayed,
+// i.e. NaN values and values that aren't positive on the logarithmic scale.
+// These form gaps in the series.
+func seriesPoints(xd *axes.XDetails, yd *axes.YDetails, samples []*sample) ([]*image.Point, error) {
+	var points []*image.Point
+	for _, s := range samples {
+		if !displayable(yd, s.v) {
+			points = append(points, nil)
+			continue
+		}
+
+		x, err := xd.Scale.ValueToPixel(s.idx)
+		if err != nil {
+			return nil, fmt.Errorf("failure for value [%d], xd.Scale.ValueToPixel => %v", s.idx, err)
+		}
+		y, err := yd.Scale.ValueToPixel(s.v)
+		if err != nil {
+			return nil, fmt.Errorf("failure for value [%d], yd.Scale.ValueToPixel => %v", s.idx, err)
+		}
+		points = append(points, &image.Point{x, y})
+	}
+	return points, nil
+}
+
+// baselinePixel returns the Y coordinate of the pixel on the braille canvas
+// where the area and the bars of the series start. This is the X axis unless
+// the series has a baseline. Baselines outside of the Y axis are placed at its
+// closest edge.
+func baselinePixel(yd *axes.YDetails, sv *seriesValues) (int, error) {
+	bottom := yd.Scale.GraphHeight*braille.RowMult - 1
+	switch {
+	case !sv.baselineSet:
+		return bottom, nil
+	case sv.baseline >= yd.Scale.Max.Value:
+		return 0, nil
+	case sv.baseline <= yd.Scale.Min.Value:
+		return bottom, nil
+	}
+
+	y, err := yd.Scale.ValueToPixel(sv.baseline)
+	if err != nil {
+		return 0, fmt.Errorf("failure for the baseline %v, yd.Scale.ValueToPixel => %v", sv.baseline, err)
+	}
+	return y, nil
+}
+
+// drawStyled draws the points in the specified style.
+// The baseline is the Y coordinate where the area and the bars start.
+func drawStyled(bc *braille.Canvas, style Style, points []*image.Point, baseline int, cOpts []cell.Option) error {
+	switch style {
+	case StyleLine:
+		return drawLines(bc, points, cOpts)
+
+	case StyleArea:
+		if err := drawArea(bc, points, baseline, cOpts); err != nil {
+			return err
+		}
+		return drawLines(bc, points, cOpts)
+
+	case StyleStep:
+		return drawSteps(bc, points, cOpts)
+
+	case StyleScatter:
+		for _, p := range points {
+			if p == nil {
+				continue
+			}
+			if err := bc.SetPixel(*p, cOpts...); err != nil {
+				return fmt.Errorf("bc.SetPixel => %v", err)
+			}
+		}
+		return nil
+
+	case StyleBars:
+		for _, p := range points {
+			if p == nil {
+				continue
+			}
+			if err := draw.BrailleLine(bc, *p, image.Point{p.X, baseline}, draw.BrailleLineCellOpts(cOpts...)); err != nil {
+				return fmt.Errorf("draw.BrailleLine => %v", err)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported style %v", style)
+	}
+}
+
+// drawLines connects the neighboring points with straight lines.
+func drawLines(bc *braille.Canvas, points []*image.Point, cOpts []cell.Option) error {
+	for i := 1; i < len(points); i++ {
+		prev, p := points[i-1], points[i]
+		if prev == nil || p == nil {
+			continue
+		}
+		if err := draw.BrailleLine(bc, *prev, *p, draw.BrailleLineCellOpts(cOpts...)); err != nil {
+			return fmt.Errorf("draw.BrailleLine => %v", err)
+		}
+	}
+	return nil
+}
+
+// drawSteps connects the neighboring points with a horizontal line that holds
+// the previous value followed by a vertical line to the next value.
+func drawSteps(bc *braille.Canvas, points []*image.Point, cOpts []cell.Option) error {
+	for i := 1; i < len(points); i++ {
+		prev, p := points[i-1], points[i]
+		if prev == nil || p == nil {
+			continue
+		}
+		corner := image.Point{p.X, prev.Y}
+		for _, l := range [][2]image.Point{{*prev, corner}, {corner, *p}} {
+			if err := draw.BrailleLine(bc, l[0], l[1], draw.BrailleLineCellOpts(cOpts...)); err != nil {
+				return fmt.Errorf("draw.BrailleLine => %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// drawArea fills the area between the lines that connect the neighboring
+// points and the baseline.
+func drawArea(bc *braille.Canvas, points []*image.Point, baseline int, cOpts []cell.Option) error {
+	for i := 1; i < len(points); i++ {
+		prev, p := points[i-1], points[i]
+		if prev == nil || p == nil {
+			continue
+		}
+		if err := fillSegment(bc, *prev, *p, baseline, cOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillSegment fills the area between the line segment from start to end and
+// the baseline. The start must be to the left of the end.
+func fillSegment(bc *braille.Canvas, start, end image.Point, baseline int, cOpts []cell.Option) error {
+	line := draw.BrailleLinePoints(start, end)
+	// The border encloses the area, it consists of the line segment, the
+	// baseline under it and the vertical edges at both ends.
+	border := append([]image.Point{}, line...)
+	for _, l := range [][2]image.Point{
+		{{start.X, baseline}, {end.X, baseline}},
+		{start, {start.X, baseline}},
+		{end, {end.X, baseline}},
+	} {
+		border = append(border, draw.BrailleLinePoints(l[0], l[1])...)
+	}
+	for _, b := range border {
+		if err := bc.SetPixel(b, cOpts...); err != nil {
+			return fmt.Errorf("bc.SetPixel => %v", err)
+		}
+	}
+
+	// The extent of the line segment in each column.
+	type extent struct {
+		top, bottom int
+	}
+	extents := map[int]*extent{}
+	for _, lp := range line {
+		e, ok := extents[lp.X]
+		if !ok {
+			extents[lp.X] = &extent{top: lp.Y, bottom: lp.Y}
+			continue
+		}
+		if lp.Y < e.top {
+			e.top = lp.Y
+		}
+		if lp.Y > e.bottom {
+			e.bottom = lp.Y
+		}
+	}
+
+	// The line segment crosses the baseline at most once, so there are at
+	// most two enclosed areas, one above and one below the baseline.
+	var filledAbove, filledBelow bool
+	for x := start.X + 1; x < end.X; x++ {
+		e := extents[x]
+		var fillStart image.Point
+		switch {
+		case !filledAbove && e.bottom+1 < baseline:
+			fillStart = image.Point{x, e.bottom + 1}
+			filledAbove = true
+		case !filledBelow && e.top-1 > baseline:
+			fillStart = image.Point{x, e.top - 1}
+			filledBelow = true
+		default:
+			continue
+		}
+
+		if err := bc.SetPixel(fillStart, cOpts...); err != nil {
+			return fmt.Errorf("bc.SetPixel => %v", err)
+		}
+		if err := draw.BrailleFill(bc, fillStart, border, draw.BrailleFillCellOpts(cOpts...)); err != nil {
+			return fmt.Errorf("draw.BrailleFill => %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/terminal/faketerm"
+)
+
+func TestStyles(t *testing.T) {
+	tests := []struct {
+		desc   string
+		values []float64
+		opts   []SeriesOption
+		// golden is the name of the golden file with the expected content.
+		golden  string
+		wantErr bool
+	}{
+		{
+			desc:   "line with a gap at a NaN value",
+			values: []float64{0, 50, math.NaN(), 100, 20},
+			golden: "StyleLine_gap.golden",
+		},
+		{
+			desc:   "area down to the X axis",
+			values: []float64{0, 80, 40, 100, 20},
+			opts:   []SeriesOption{SeriesStyle(StyleArea)},
+			golden: "StyleArea.golden",
+		},
+		{
+			desc:   "area down to a baseline crossed by the line",
+			values: []float64{0, 80, 40, 100, 20},
+			opts: []SeriesOption{
+				SeriesStyle(StyleArea),
+				SeriesBaseline(50),
+			},
+			golden: "StyleArea_baseline.golden",
+		},
+		{
+			desc:   "area with a gap at a NaN value",
+			values: []float64{0, 80, math.NaN(), 100, 20},
+			opts:   []SeriesOption{SeriesStyle(StyleArea)},
+			golden: "StyleArea_gap.golden",
+		},
+		{
+			desc:   "step",
+			values: []float64{0, 80, 40, 100, 20},
+			opts:   []SeriesOption{SeriesStyle(StyleStep)},
+			golden: "StyleStep.golden",
+		},
+		{
+			desc:   "scatter",
+			values: []float64{0, 80, 40, 100, math.NaN(), 20},
+			opts:   []SeriesOption{SeriesStyle(StyleScatter)},
+			golden: "StyleScatter.golden",
+		},
+		{
+			desc:   "bars",
+			values: []float64{10, 80, 40, 100, 20},
+			opts:   []SeriesOption{SeriesStyle(StyleBars)},
+			golden: "StyleBars.golden",
+		},
+		{
+			desc:   "bars from a baseline",
+			values: []float64{10, 80, 40, 100, 20},
+			opts: []SeriesOption{
+				SeriesStyle(StyleBars),
+				SeriesBaseline(50),
+			},
+			golden: "StyleBars_baseline.golden",
+		},
+		{
+			desc:    "fails on unsupported style",
+			values:  []float64{0, 100},
+			opts:    []SeriesOption{SeriesStyle(Style(-1))},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc := New()
+			if err := lc.Series("series", tc.values, tc.opts...); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(image.Rect(0, 0, 30, 12))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			err = lc.Draw(cvs)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
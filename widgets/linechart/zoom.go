@@ -0,0 +1,207 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// zoom.go contains code that tracks the zoomed range of the X axis.
+
+// zoomTracker tracks the range of values (positions in the series) visible on
+// the X axis of the line chart.
+//
+// The user zooms into a range either by selecting it with the mouse or with
+// the keyboard and pans the zoomed range with the keyboard or the mouse
+// wheel. The number of points in the series can change between the user
+// inputs, so the methods accept the largest value available on the X axis
+// (positions are zero based) and keep the range within it.
+//
+// This is not thread safe.
+type zoomTracker struct {
+	// zoomed indicates if the chart is zoomed in.
+	// The min and max are only valid when this is true.
+	zoomed bool
+	// min is the first visible value.
+	min int
+	// max is the last visible value.
+	max int
+
+	// dragging indicates if the user is selecting a range with the mouse.
+	// The dragStart and dragEnd are only valid when this is true.
+	dragging bool
+	// dragStart is the X coordinate of the cell where the selection started.
+	dragStart int
+	// dragEnd is the X coordinate of the cell where the selection currently
+	// ends.
+	dragEnd int
+}
+
+// newZoomTracker returns a new zoom tracker that displays all the values.
+func newZoomTracker() *zoomTracker {
+	return &zoomTracker{}
+}
+
+// visible returns the range of values that should be displayed on the X axis.
+// The last is the largest value available on the X axis.
+func (zt *zoomTracker) visible(last int) (int, int) {
+	if last < 0 {
+		last = 0
+	}
+	if !zt.zoomed {
+		return 0, last
+	}
+	if zt.max > last {
+		// The series got shorter, keep the width of the range if possible.
+		width := zt.max - zt.min
+		zt.max = last
+		zt.min = last - width
+		if zt.min < 0 {
+			zt.min = 0
+		}
+	}
+	if zt.min >= zt.max {
+		zt.reset()
+		return 0, last
+	}
+	return zt.min, zt.max
+}
+
+// zoomTo zooms into the range between the two values. The values can be
+// provided in any order. The range must contain at least two values,
+// otherwise it is ignored.
+func (zt *zoomTracker) zoomTo(a, b, last int) {
+	if a > b {
+		a, b = b, a
+	}
+	if a < 0 {
+		a = 0
+	}
+	if b > last {
+		b = last
+	}
+	if b-a < 1 {
+		return
+	}
+	if a == 0 && b == last {
+		zt.reset()
+		return
+	}
+	zt.zoomed = true
+	zt.min = a
+	zt.max = b
+}
+
+// zoomIn halves the width of the visible range, keeping its center.
+func (zt *zoomTracker) zoomIn(last int) {
+	min, max := zt.visible(last)
+	width := (max - min) / 2
+	if width < 1 {
+		return // Cannot zoom in any further.
+	}
+	center := min + (max-min)/2
+	start := center - width/2
+	zt.zoomTo(start, start+width, last)
+}
+
+// zoomOut doubles the width of the visible range, keeping its center if
+// possible. This reverts zoomIn.
+func (zt *zoomTracker) zoomOut(last int) {
+	if !zt.zoomed {
+		return
+	}
+	min, max := zt.visible(last)
+	width := (max-min)*2 + 1 // zoomIn rounds the width down.
+	if width >= last {
+		zt.reset()
+		return
+	}
+	center := min + (max-min)/2
+	start := center - width/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + width
+	if end > last {
+		start -= end - last
+		end = last
+	}
+	zt.zoomTo(start, end, last)
+}
+
+// pan moves the visible range by a quarter of its width. Moves towards
+// smaller values if the direction is negative and towards larger values
+// otherwise. The range doesn't move beyond the available values.
+func (zt *zoomTracker) pan(direction, last int) {
+	if !zt.zoomed {
+		return
+	}
+	min, max := zt.visible(last)
+	width := max - min
+	step := width / 4
+	if step < 1 {
+		step = 1
+	}
+	if direction < 0 {
+		step = -step
+	}
+
+	start := min + step
+	if start < 0 {
+		start = 0
+	}
+	if start+width > last {
+		start = last - width
+	}
+	zt.min = start
+	zt.max = start + width
+}
+
+// reset displays all the values.
+func (zt *zoomTracker) reset() {
+	zt.zoomed = false
+	zt.min = 0
+	zt.max = 0
+}
+
+// drag processes a mouse event that starts or continues the selection of a
+// range at the X coordinate of the cell.
+func (zt *zoomTracker) drag(x int) {
+	if !zt.dragging {
+		zt.dragging = true
+		zt.dragStart = x
+	}
+	zt.dragEnd = x
+}
+
+// release ends the selection of a range. Returns the X coordinates of the
+// cells where the selection started and ended and a bool indicating if there
+// was an ongoing selection.
+func (zt *zoomTracker) release() (int, int, bool) {
+	if !zt.dragging {
+		return 0, 0, false
+	}
+	zt.dragging = false
+	return zt.dragStart, zt.dragEnd, true
+}
+
+// selection returns the X coordinates of the first and the last cell of the
+// range that is being selected with the mouse, ordered from the left. The
+// bool is false if the user isn't selecting a range.
+func (zt *zoomTracker) selection() (int, int, bool) {
+	if !zt.dragging {
+		return 0, 0, false
+	}
+	if zt.dragStart > zt.dragEnd {
+		return zt.dragEnd, zt.dragStart, true
+	}
+	return zt.dragStart, zt.dragEnd, true
+}
@@ -0,0 +1,404 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/canvas/testcanvas"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+)
+
+func TestZoomTracker(t *testing.T) {
+	tests := []struct {
+		desc string
+		// last is the largest value available on the X axis.
+		last    int
+		actions func(*zoomTracker, int)
+		wantMin int
+		wantMax int
+	}{
+		{
+			desc:    "displays all values by default",
+			last:    99,
+			actions: func(*zoomTracker, int) {},
+			wantMin: 0,
+			wantMax: 99,
+		},
+		{
+			desc: "zooms into a range",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(30, 10, last)
+			},
+			wantMin: 10,
+			wantMax: 30,
+		},
+		{
+			desc: "ignores ranges with less than two values",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(10, 10, last)
+			},
+			wantMin: 0,
+			wantMax: 99,
+		},
+		{
+			desc: "limits the range to the available values",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(-10, 200, last)
+			},
+			wantMin: 0,
+			wantMax: 99,
+		},
+		{
+			desc: "zooms into the middle",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomIn(last)
+			},
+			wantMin: 25,
+			wantMax: 74,
+		},
+		{
+			desc: "cannot zoom in beyond two values",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				for i := 0; i < 20; i++ {
+					zt.zoomIn(last)
+				}
+			},
+			wantMin: 49,
+			wantMax: 50,
+		},
+		{
+			desc: "zooms out",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(40, 50, last)
+				zt.zoomOut(last)
+			},
+			wantMin: 35,
+			wantMax: 56,
+		},
+		{
+			desc: "zooming out near the end keeps the range within the values",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(80, 99, last)
+				zt.zoomOut(last)
+			},
+			wantMin: 60,
+			wantMax: 99,
+		},
+		{
+			desc: "zooming out completely displays all values",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomIn(last)
+				zt.zoomOut(last)
+			},
+			wantMin: 0,
+			wantMax: 99,
+		},
+		{
+			desc: "reset displays all values",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(10, 20, last)
+				zt.reset()
+			},
+			wantMin: 0,
+			wantMax: 99,
+		},
+		{
+			desc: "pans right by a quarter of the range",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(10, 30, last)
+				zt.pan(1, last)
+			},
+			wantMin: 15,
+			wantMax: 35,
+		},
+		{
+			desc: "pans left by a quarter of the range",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(10, 30, last)
+				zt.pan(-1, last)
+			},
+			wantMin: 5,
+			wantMax: 25,
+		},
+		{
+			desc: "pans by at least one value",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(10, 11, last)
+				zt.pan(1, last)
+			},
+			wantMin: 11,
+			wantMax: 12,
+		},
+		{
+			desc: "doesn't pan beyond the first value",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(2, 22, last)
+				zt.pan(-1, last)
+			},
+			wantMin: 0,
+			wantMax: 20,
+		},
+		{
+			desc: "doesn't pan beyond the last value",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(77, 97, last)
+				zt.pan(1, last)
+			},
+			wantMin: 79,
+			wantMax: 99,
+		},
+		{
+			desc: "doesn't pan when not zoomed",
+			last: 99,
+			actions: func(zt *zoomTracker, last int) {
+				zt.pan(1, last)
+			},
+			wantMin: 0,
+			wantMax: 99,
+		},
+		{
+			desc: "keeps the range within values when the series get shorter",
+			last: 49,
+			actions: func(zt *zoomTracker, last int) {
+				zt.zoomTo(60, 80, 99)
+			},
+			wantMin: 29,
+			wantMax: 49,
+		},
+		{
+			desc:    "works without values",
+			last:    -1,
+			actions: func(*zoomTracker, int) {},
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			zt := newZoomTracker()
+			tc.actions(zt, tc.last)
+			gotMin, gotMax := zt.visible(tc.last)
+			if gotMin != tc.wantMin || gotMax != tc.wantMax {
+				t.Errorf("visible => (%d, %d), want (%d, %d)", gotMin, gotMax, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
+func TestZoomEvents(t *testing.T) {
+	// Values 0-26 on a 20x10 canvas, the graph has width of 14 cells or 28
+	// pixels, i.e. one value per pixel.
+	var values []float64
+	for i := 0; i <= 26; i++ {
+		values = append(values, float64(i))
+	}
+
+	tests := []struct {
+		desc    string
+		opts    []Option
+		events  []terminalapi.Event
+		wantMin int
+		wantMax int
+		wantErr bool
+	}{
+		{
+			desc: "zooms into the range selected with the mouse",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{10, 3}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{11, 3}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{11, 3}, Button: mouse.ButtonRelease},
+			},
+			wantMin: 4,
+			wantMax: 10,
+		},
+		{
+			desc: "selection can be made from right to left",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{11, 3}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonRelease},
+			},
+			wantMin: 4,
+			wantMax: 10,
+		},
+		{
+			desc: "selection continues outside of the graph",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 9}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 9}, Button: mouse.ButtonRelease},
+			},
+			wantMin: 0,
+			wantMax: 4,
+		},
+		{
+			desc: "release outside of the canvas ends the selection",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{11, 3}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{-5, -3}, Button: mouse.ButtonRelease},
+			},
+			wantMin: 4,
+			wantMax: 10,
+		},
+		{
+			desc: "selection must start on the graph",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{2, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{10, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{10, 2}, Button: mouse.ButtonRelease},
+			},
+			wantMin: 0,
+			wantMax: 26,
+		},
+		{
+			desc: "a click doesn't zoom",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonRelease},
+			},
+			wantMin: 0,
+			wantMax: 26,
+		},
+		{
+			desc: "zooms with the keyboard and pans with the mouse wheel",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: DefaultZoomKeyIn},
+				&terminalapi.Mouse{Button: DefaultPanMouseButtonRight},
+				&terminalapi.Mouse{Button: DefaultPanMouseButtonRight},
+			},
+			wantMin: 13,
+			wantMax: 26,
+		},
+		{
+			desc: "pans with the keyboard",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: DefaultZoomKeyIn},
+				&terminalapi.Keyboard{Key: DefaultPanKeyLeft},
+			},
+			wantMin: 4,
+			wantMax: 17,
+		},
+		{
+			desc: "zooms out and resets with the keyboard",
+			opts: []Option{EnableZoom()},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: DefaultZoomKeyIn},
+				&terminalapi.Keyboard{Key: DefaultZoomKeyIn},
+				&terminalapi.Keyboard{Key: DefaultZoomKeyOut},
+				&terminalapi.Keyboard{Key: DefaultZoomKeyReset},
+			},
+			wantMin: 0,
+			wantMax: 26,
+		},
+		{
+			desc: "uses custom keys and mouse buttons",
+			opts: []Option{
+				EnableZoom(),
+				ZoomKeys('i', 'o', 'r'),
+				PanKeys('h', 'l'),
+				PanMouseButtons(mouse.ButtonWheelDown, mouse.ButtonWheelUp),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: DefaultZoomKeyIn},
+				&terminalapi.Keyboard{Key: 'i'},
+				&terminalapi.Keyboard{Key: 'l'},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			wantMin: 13,
+			wantMax: 26,
+		},
+		{
+			desc: "fails on keyboard events when zoom isn't enabled",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: DefaultZoomKeyIn},
+			},
+			wantMin: 0,
+			wantMax: 26,
+			wantErr: true,
+		},
+		{
+			desc: "fails on mouse events when zoom isn't enabled",
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: DefaultPanMouseButtonRight},
+			},
+			wantMin: 0,
+			wantMax: 26,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc := New(tc.opts...)
+			if err := lc.Series("series", values); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			if err := lc.Draw(testcanvas.MustNew(image.Rect(0, 0, 20, 10))); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var err error
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = lc.Keyboard(e)
+				case *terminalapi.Mouse:
+					err = lc.Mouse(e)
+				}
+				if err != nil {
+					break
+				}
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("processing events => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+
+			gotMin, gotMax := lc.zoom.visible(len(values) - 1)
+			if gotMin != tc.wantMin || gotMax != tc.wantMax {
+				t.Errorf("zoomed range => (%d, %d), want (%d, %d)", gotMin, gotMax, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
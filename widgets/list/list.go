@@ -0,0 +1,515 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package list is a widget that displays a vertical list of items the user can
+// select with the keyboard or the mouse.
+package list
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// List displays a vertical list of items and highlights the selected one,
+// e.g. a sidebar used to pick a service whose metrics other widgets display.
+//
+// The selection is moved with the arrow keys, page up and page down, home and
+// end or the mouse wheel and a click selects the item under the mouse
+// pointer. The list scrolls so that the selected item is always visible.
+// Typing selects the next item that starts with the typed characters, ignoring
+// case. The enter key submits the selected item.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type List struct {
+	// items are the displayed items.
+	items []string
+	// selected is the index of the selected item, -1 if the list is empty.
+	selected int
+	// checked are the checked items when the MultiSelect option is provided.
+	checked map[string]bool
+
+	// first is the index of the first item displayed on the canvas.
+	first int
+	// height is the number of rows on the canvas during the last draw.
+	height int
+
+	// query are the characters typed by the user since the search started.
+	query string
+	// lastTyped is the time when the user typed the last character.
+	lastTyped time.Time
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+
+	// pending are the callbacks to call once mu is released, so that the
+	// callbacks can access the List.
+	pending []func() error
+
+	// mu protects the List.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new List.
+func New(opts ...Option) (*List, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &List{
+		selected: -1,
+		checked:  map[string]bool{},
+		height:   1,
+		now:      time.Now,
+		opts:     o,
+	}, nil
+}
+
+// SetItems sets the items the list displays, replacing any previous items.
+// The items must be unique and non-empty and cannot contain control
+// characters.
+//
+// The selection and the checkmarks of items that remain in the list are kept.
+// If the selected item was removed, the item at the same position is
+// selected.
+func (l *List) SetItems(items []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seen := map[string]bool{}
+	for _, item := range items {
+		if err := validItem(item); err != nil {
+			return err
+		}
+		if seen[item] {
+			return fmt.Errorf("duplicate item %q, the items must be unique", item)
+		}
+		seen[item] = true
+	}
+
+	prev := ""
+	if l.selected >= 0 {
+		prev = l.items[l.selected]
+	}
+	l.items = append([]string(nil), items...)
+	for item := range l.checked {
+		if !seen[item] {
+			delete(l.checked, item)
+		}
+	}
+
+	switch {
+	case len(l.items) == 0:
+		l.selected = -1
+	case seen[prev]:
+		l.selected = l.index(prev)
+	case l.selected < 0:
+		l.selected = 0
+	case l.selected >= len(l.items):
+		l.selected = len(l.items) - 1
+	}
+	return nil
+}
+
+// Select selects the item. Returns an error if the list doesn't contain the
+// item.
+func (l *List) Select(item string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	i := l.index(item)
+	if i < 0 {
+		return fmt.Errorf("the list doesn't contain item %q", item)
+	}
+	l.selected = i
+	return nil
+}
+
+// Selected returns the selected item and its index. Returns -1 and an empty
+// string if the list is empty.
+func (l *List) Selected() (int, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.selected < 0 {
+		return -1, ""
+	}
+	return l.selected, l.items[l.selected]
+}
+
+// Checked returns the checked items in the order in which they appear in the
+// list. Always empty unless the MultiSelect option was provided.
+func (l *List) Checked() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.checkedItems()
+}
+
+// checkedItems returns the checked items in the order of the list.
+// l.mu must be held when calling this method.
+func (l *List) checkedItems() []string {
+	var res []string
+	for _, item := range l.items {
+		if l.checked[item] {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// index returns the index of the item or -1 if the list doesn't contain it.
+// l.mu must be held when calling this method.
+func (l *List) index(item string) int {
+	for i, it := range l.items {
+		if it == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// unlockAndCall releases l.mu and calls the pending callbacks in order.
+// Returns the error of the first callback that fails, the remaining callbacks
+// aren't called.
+// l.mu must be held when calling this method.
+func (l *List) unlockAndCall() error {
+	pending := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	for _, call := range pending {
+		if err := call(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveTo selects the item at the index, the index is clamped to the items of
+// the list. Schedules the OnSelect callback if the selection changed.
+// l.mu must be held when calling this method.
+func (l *List) moveTo(i int) {
+	if len(l.items) == 0 {
+		return
+	}
+	if i < 0 {
+		i = 0
+	}
+	if max := len(l.items) - 1; i > max {
+		i = max
+	}
+	if i == l.selected {
+		return
+	}
+
+	l.selected = i
+	if fn := l.opts.onSelect; fn != nil {
+		item := l.items[i]
+		l.pending = append(l.pending, func() error { return fn(i, item) })
+	}
+}
+
+// toggle checks or unchecks the item at the index and schedules the OnCheck
+// callback.
+// l.mu must be held when calling this method.
+func (l *List) toggle(i int) {
+	if !l.opts.multiSelect || i < 0 || i >= len(l.items) {
+		return
+	}
+
+	item := l.items[i]
+	if l.checked[item] {
+		delete(l.checked, item)
+	} else {
+		l.checked[item] = true
+	}
+	if fn := l.opts.onCheck; fn != nil {
+		checked := l.checkedItems()
+		l.pending = append(l.pending, func() error { return fn(checked) })
+	}
+}
+
+// search extends the search query with the typed character and selects the
+// matching item. The query is started over if the user didn't type for longer
+// than the search timeout.
+// l.mu must be held when calling this method.
+func (l *List) search(r rune) {
+	if !l.searching() {
+		l.query = ""
+	}
+	l.lastTyped = l.now()
+	l.query += strings.ToLower(string(r))
+	l.find()
+}
+
+// find selects the first item starting at the selected one whose prefix
+// matches the search query. Doesn't change the selection if no item matches.
+// l.mu must be held when calling this method.
+func (l *List) find() {
+	for i := 0; i < len(l.items); i++ {
+		idx := (l.selected + i) % len(l.items)
+		if strings.HasPrefix(strings.ToLower(l.items[idx]), l.query) {
+			l.moveTo(idx)
+			return
+		}
+	}
+}
+
+// searching asserts whether the user is typing a search query.
+// l.mu must be held when calling this method.
+func (l *List) searching() bool {
+	return l.query != "" && l.now().Sub(l.lastTyped) <= l.opts.searchTimeout
+}
+
+// Keyboard processes keyboard events, see the documentation of List for the
+// supported keys. With the MultiSelect option, the space key toggles the
+// checkmark of the selected item unless the user is typing a search query.
+// Implements widgetapi.Widget.Keyboard.
+func (l *List) Keyboard(k *terminalapi.Keyboard) error {
+	l.mu.Lock()
+	l.keyboard(k)
+	return l.unlockAndCall()
+}
+
+// keyboard processes the keyboard event.
+// l.mu must be held when calling this method.
+func (l *List) keyboard(k *terminalapi.Keyboard) {
+	switch k.Key {
+	case keyboard.KeyBackspace:
+		if !l.searching() {
+			l.query = ""
+			return
+		}
+		q := []rune(l.query)
+		l.query = string(q[:len(q)-1])
+		l.lastTyped = l.now()
+		l.find()
+		return
+
+	case keyboard.KeySpace:
+		if l.searching() {
+			l.search(' ')
+		} else {
+			l.toggle(l.selected)
+		}
+		return
+	}
+
+	if k.Key >= 0 && !unicode.IsControl(rune(k.Key)) {
+		l.search(rune(k.Key))
+		return
+	}
+
+	l.query = ""
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		l.moveTo(l.selected - 1)
+	case keyboard.KeyArrowDown:
+		l.moveTo(l.selected + 1)
+	case keyboard.KeyPgUp:
+		l.moveTo(l.selected - l.height)
+	case keyboard.KeyPgDn:
+		l.moveTo(l.selected + l.height)
+	case keyboard.KeyHome:
+		l.moveTo(0)
+	case keyboard.KeyEnd:
+		l.moveTo(len(l.items) - 1)
+	case keyboard.KeyEnter:
+		if fn := l.opts.onEnter; l.selected >= 0 && fn != nil {
+			i, item := l.selected, l.items[l.selected]
+			l.pending = append(l.pending, func() error { return fn(i, item) })
+		}
+	}
+}
+
+// Mouse processes mouse events. The left button selects the item under the
+// mouse pointer or toggles its checkmark if the checkbox was clicked, the
+// mouse wheel moves the selection.
+// Implements widgetapi.Widget.Mouse.
+func (l *List) Mouse(m *terminalapi.Mouse) error {
+	l.mu.Lock()
+	l.mouse(m)
+	return l.unlockAndCall()
+}
+
+// mouse processes the mouse event.
+// l.mu must be held when calling this method.
+func (l *List) mouse(m *terminalapi.Mouse) {
+	l.query = ""
+	switch m.Button {
+	case mouse.ButtonLeft:
+		i := l.first + m.Position.Y
+		if m.Position.Y < 0 || m.Position.Y >= l.height || i >= len(l.items) {
+			return
+		}
+		l.moveTo(i)
+		if m.Position.X < len([]rune(checkboxOn)) {
+			l.toggle(i)
+		}
+	case mouse.ButtonWheelUp:
+		l.moveTo(l.selected - 1)
+	case mouse.ButtonWheelDown:
+		l.moveTo(l.selected + 1)
+	}
+}
+
+// The checkboxes displayed in front of the items with the MultiSelect option.
+const (
+	checkboxOn  = "[✓] "
+	checkboxOff = "[ ] "
+)
+
+// minLinesForMarkers are the minimum amount of lines required on the canvas in
+// order to draw the scroll markers ('⇧' and '⇩').
+const minLinesForMarkers = 3
+
+// scroll updates the index of the first displayed item so that the selected
+// item is visible.
+// l.mu must be held when calling this method.
+func (l *List) scroll() {
+	if l.selected < l.first {
+		l.first = l.selected
+	}
+	if l.selected >= l.first+l.height {
+		l.first = l.selected - l.height + 1
+	}
+	if max := len(l.items) - l.height; l.first > max {
+		l.first = max
+	}
+	if l.first < 0 {
+		l.first = 0
+	}
+}
+
+// Draw draws the List widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (l *List) Draw(cvs *canvas.Canvas) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ar := cvs.Area()
+	l.height = ar.Dy()
+	l.scroll()
+
+	textMaxX := ar.Dx()
+	markers := len(l.items) > l.height && l.height >= minLinesForMarkers && ar.Dx() > 1
+	if markers {
+		textMaxX--
+	}
+
+	for y := 0; y < l.height && l.first+y < len(l.items); y++ {
+		i := l.first + y
+		var cOpts []cell.Option
+		if i == l.selected {
+			cOpts = l.selectedCellOpts()
+			row := image.Rect(0, y, textMaxX, y+1)
+			if err := draw.Rectangle(cvs, row, draw.RectCellOpts(cOpts...)); err != nil {
+				return err
+			}
+		}
+
+		text := l.items[i]
+		if l.opts.multiSelect {
+			box := checkboxOff
+			if l.checked[text] {
+				box = checkboxOn
+			}
+			text = box + text
+		}
+		if err := draw.Text(cvs, text, image.Point{0, y},
+			draw.TextMaxX(textMaxX),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(cOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw item %q: %v", l.items[i], err)
+		}
+	}
+
+	if !markers {
+		return nil
+	}
+	if l.first > 0 {
+		if _, err := cvs.SetCell(image.Point{textMaxX, 0}, '⇧'); err != nil {
+			return err
+		}
+	}
+	if l.first+l.height < len(l.items) {
+		if _, err := cvs.SetCell(image.Point{textMaxX, l.height - 1}, '⇩'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectedCellOpts returns the cell options of the selected item. The options
+// are prefixed with the default background color or the color of the focused
+// border of the theme, so that the colors in the options take precedence.
+// l.mu must be held when calling this method.
+func (l *List) selectedCellOpts() []cell.Option {
+	bg := DefaultSelectedColor
+	if l.theme != nil {
+		bg = l.theme.FocusedBorder
+	}
+	return append([]cell.Option{cell.BgColor(bg)}, l.opts.selectedCellOpts...)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (l *List) SetTheme(t *theme.Theme) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.theme = t
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*List) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+}
+
+// validItem validates an item of the list.
+func validItem(item string) error {
+	if item == "" {
+		return errors.New("the items cannot be empty")
+	}
+	for _, r := range item {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("item %q cannot contain control characters, found: %q", item, r)
+		}
+	}
+	return nil
+}
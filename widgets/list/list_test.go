@@ -0,0 +1,711 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc: "succeeds with all the options",
+			opts: []Option{
+				MultiSelect(),
+				SelectedCellOpts(cell.FgColor(cell.ColorRed)),
+				SearchTimeout(time.Second),
+				OnSelect(func(int, string) error { return nil }),
+				OnEnter(func(int, string) error { return nil }),
+				OnCheck(func([]string) error { return nil }),
+			},
+		},
+		{
+			desc:    "fails on zero search timeout",
+			opts:    []Option{SearchTimeout(0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetItems(t *testing.T) {
+	tests := []struct {
+		desc string
+		// sets are the items set in order, only the last one can fail.
+		sets [][]string
+		// selectBefore is selected before the last items are set, ignored if
+		// empty.
+		selectBefore string
+		// checkBefore are checked before the last items are set.
+		checkBefore  []string
+		wantSelected int
+		wantItem     string
+		wantChecked  []string
+		wantErr      bool
+	}{
+		{
+			desc:         "empty list doesn't have a selection",
+			sets:         [][]string{nil},
+			wantSelected: -1,
+		},
+		{
+			desc:         "selects the first item",
+			sets:         [][]string{{"a", "b"}},
+			wantSelected: 0,
+			wantItem:     "a",
+		},
+		{
+			desc:         "keeps the selected item when it moves",
+			sets:         [][]string{{"a", "b", "c"}, {"c", "b"}},
+			selectBefore: "b",
+			wantSelected: 1,
+			wantItem:     "b",
+		},
+		{
+			desc:         "selects the same position when the selected item is removed",
+			sets:         [][]string{{"a", "b", "c"}, {"a", "c"}},
+			selectBefore: "b",
+			wantSelected: 1,
+			wantItem:     "c",
+		},
+		{
+			desc:         "selects the last item when the list gets shorter",
+			sets:         [][]string{{"a", "b", "c"}, {"a"}},
+			selectBefore: "c",
+			wantSelected: 0,
+			wantItem:     "a",
+		},
+		{
+			desc:         "keeps checkmarks of remaining items",
+			sets:         [][]string{{"a", "b", "c"}, {"c", "a"}},
+			checkBefore:  []string{"a", "b"},
+			wantSelected: 1,
+			wantItem:     "a",
+			wantChecked:  []string{"a"},
+		},
+		{
+			desc:         "fails on an empty item",
+			sets:         [][]string{{"a"}, {"b", ""}},
+			wantSelected: 0,
+			wantItem:     "a",
+			wantErr:      true,
+		},
+		{
+			desc:         "fails on duplicate items",
+			sets:         [][]string{{"a"}, {"b", "b"}},
+			wantSelected: 0,
+			wantItem:     "a",
+			wantErr:      true,
+		},
+		{
+			desc:         "fails on control characters",
+			sets:         [][]string{{"a"}, {"b\n"}},
+			wantSelected: 0,
+			wantItem:     "a",
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := New(MultiSelect())
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for i, items := range tc.sets {
+				if last := i == len(tc.sets)-1; last {
+					if tc.selectBefore != "" {
+						if err := l.Select(tc.selectBefore); err != nil {
+							t.Fatalf("Select => unexpected error: %v", err)
+						}
+					}
+					for _, c := range tc.checkBefore {
+						l.toggle(l.index(c))
+					}
+					err := l.SetItems(items)
+					if (err != nil) != tc.wantErr {
+						t.Errorf("SetItems => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+					}
+					break
+				}
+				if err := l.SetItems(items); err != nil {
+					t.Fatalf("SetItems => unexpected error: %v", err)
+				}
+			}
+
+			gotSelected, gotItem := l.Selected()
+			if gotSelected != tc.wantSelected || gotItem != tc.wantItem {
+				t.Errorf("Selected => %d, %q, want %d, %q", gotSelected, gotItem, tc.wantSelected, tc.wantItem)
+			}
+			if diff := pretty.Compare(tc.wantChecked, l.Checked()); diff != "" {
+				t.Errorf("Checked => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := l.SetItems([]string{"a", "b"}); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+	if err := l.Select("c"); err == nil {
+		t.Errorf("Select(c) => got nil err, wanted one")
+	}
+	if err := l.Select("b"); err != nil {
+		t.Fatalf("Select(b) => unexpected error: %v", err)
+	}
+	if i, item := l.Selected(); i != 1 || item != "b" {
+		t.Errorf("Selected => %d, %q, want 1, \"b\"", i, item)
+	}
+}
+
+// event is a keyboard or a mouse event processed by the widget.
+type event struct {
+	k *terminalapi.Keyboard
+	m *terminalapi.Mouse
+	// wait advances the time before the event is processed.
+	wait time.Duration
+}
+
+// key returns a keyboard event.
+func key(k keyboard.Key) *event {
+	return &event{k: &terminalapi.Keyboard{Key: k}}
+}
+
+// typed returns keyboard events that type the text.
+func typed(text string) []*event {
+	var res []*event
+	for _, r := range text {
+		res = append(res, key(keyboard.Key(r)))
+	}
+	return res
+}
+
+// button returns a mouse event with the button at the point.
+func button(b mouse.Button, x, y int) *event {
+	return &event{m: &terminalapi.Mouse{Position: image.Point{x, y}, Button: b}}
+}
+
+// events concatenates the events.
+func events(evs ...interface{}) []*event {
+	var res []*event
+	for _, e := range evs {
+		switch v := e.(type) {
+		case *event:
+			res = append(res, v)
+		case []*event:
+			res = append(res, v...)
+		}
+	}
+	return res
+}
+
+// services are items used in the tests.
+var services = []string{"api", "auth", "billing", "cache", "db", "frontend", "gateway", "search"}
+
+func TestEvents(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  []Option
+		items []string
+		// height is the height of the canvas the widget is drawn on before
+		// the events are processed.
+		height       int
+		events       []*event
+		wantSelected int
+		wantChecked  []string
+		// wantCalls are the calls of the callbacks.
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			desc:         "ignores events on an empty list",
+			height:       3,
+			events:       events(key(keyboard.KeyArrowDown), key(keyboard.KeyEnter), typed("a"), button(mouse.ButtonLeft, 0, 0)),
+			wantSelected: -1,
+		},
+		{
+			desc:         "arrow keys move the selection",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyArrowDown), key(keyboard.KeyArrowDown), key(keyboard.KeyArrowUp)),
+			wantSelected: 1,
+			wantCalls:    []string{"select 1 auth", "select 2 billing", "select 1 auth"},
+		},
+		{
+			desc:         "selection stops at the edges",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyArrowUp), key(keyboard.KeyEnd), key(keyboard.KeyArrowDown)),
+			wantSelected: 7,
+			wantCalls:    []string{"select 7 search"},
+		},
+		{
+			desc:         "page keys move by the height of the canvas",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyPgDn), key(keyboard.KeyPgDn), key(keyboard.KeyPgUp)),
+			wantSelected: 3,
+			wantCalls:    []string{"select 3 cache", "select 6 gateway", "select 3 cache"},
+		},
+		{
+			desc:         "home and end",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyEnd), key(keyboard.KeyHome)),
+			wantSelected: 0,
+			wantCalls:    []string{"select 7 search", "select 0 api"},
+		},
+		{
+			desc:         "enter submits the selected item",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyArrowDown), key(keyboard.KeyEnter)),
+			wantSelected: 1,
+			wantCalls:    []string{"select 1 auth", "enter 1 auth"},
+		},
+		{
+			desc:         "mouse wheel moves the selection",
+			items:        services,
+			height:       3,
+			events:       events(button(mouse.ButtonWheelDown, 0, 0), button(mouse.ButtonWheelDown, 0, 0), button(mouse.ButtonWheelUp, 0, 0)),
+			wantSelected: 1,
+			wantCalls:    []string{"select 1 auth", "select 2 billing", "select 1 auth"},
+		},
+		{
+			desc:         "click selects the item under the mouse",
+			items:        services,
+			height:       3,
+			events:       events(button(mouse.ButtonLeft, 2, 2), button(mouse.ButtonLeft, 2, 2)),
+			wantSelected: 2,
+			wantCalls:    []string{"select 2 billing"},
+		},
+		{
+			desc:         "click accounts for the scrolling",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyEnd), button(mouse.ButtonLeft, 0, 0)),
+			wantSelected: 5,
+			wantCalls:    []string{"select 7 search", "select 5 frontend"},
+		},
+		{
+			desc:         "release outside of the canvas is ignored",
+			items:        services,
+			height:       3,
+			events:       events(button(mouse.ButtonLeft, 2, 1), button(mouse.ButtonRelease, -3, -1), button(mouse.ButtonRelease, 40, 9)),
+			wantSelected: 1,
+			wantCalls:    []string{"select 1 auth"},
+		},
+		{
+			desc:         "click below the items is ignored",
+			items:        []string{"a", "b"},
+			height:       3,
+			events:       events(button(mouse.ButtonLeft, 0, 2)),
+			wantSelected: 0,
+		},
+		{
+			desc:         "typing selects the next matching item",
+			items:        services,
+			height:       3,
+			events:       typed("ca"),
+			wantSelected: 3,
+			wantCalls:    []string{"select 3 cache"},
+		},
+		{
+			desc:         "search ignores case and extends the prefix",
+			items:        services,
+			height:       3,
+			events:       typed("AU"),
+			wantSelected: 1,
+			wantCalls:    []string{"select 1 auth"},
+		},
+		{
+			desc:         "search keeps the selection when nothing matches",
+			items:        services,
+			height:       3,
+			events:       typed("bx"),
+			wantSelected: 2,
+			wantCalls:    []string{"select 2 billing"},
+		},
+		{
+			desc:   "search starts over after the timeout",
+			items:  services,
+			height: 3,
+			events: events(
+				typed("s"),
+				&event{k: &terminalapi.Keyboard{Key: 'a'}, wait: 2 * time.Second},
+			),
+			wantSelected: 0,
+			wantCalls:    []string{"select 7 search", "select 0 api"},
+		},
+		{
+			desc:         "backspace shortens the query",
+			items:        services,
+			height:       3,
+			events:       events(typed("au"), key(keyboard.KeyBackspace), key(keyboard.KeyBackspace), typed("d")),
+			wantSelected: 4,
+			wantCalls:    []string{"select 1 auth", "select 4 db"},
+		},
+		{
+			desc:         "navigation keys end the search",
+			items:        services,
+			height:       3,
+			events:       events(typed("c"), key(keyboard.KeyArrowUp), typed("a")),
+			wantSelected: 0,
+			wantCalls:    []string{"select 3 cache", "select 2 billing", "select 0 api"},
+		},
+		{
+			desc:         "space toggles the checkmark with multi select",
+			opts:         []Option{MultiSelect()},
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeySpace), key(keyboard.KeyArrowDown), key(keyboard.KeySpace), key(keyboard.KeyHome), key(keyboard.KeySpace)),
+			wantSelected: 0,
+			wantChecked:  []string{"auth"},
+			wantCalls: []string{
+				"check [api]",
+				"select 1 auth",
+				"check [api auth]",
+				"select 0 api",
+				"check [auth]",
+			},
+		},
+		{
+			desc:         "space is part of the search query",
+			opts:         []Option{MultiSelect()},
+			items:        []string{"a b", "a c"},
+			height:       3,
+			events:       typed("a c"),
+			wantSelected: 1,
+			wantCalls:    []string{"select 1 a c"},
+		},
+		{
+			desc:         "space doesn't check without multi select",
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeySpace)),
+			wantSelected: 0,
+		},
+		{
+			desc:         "click on the checkbox toggles the checkmark",
+			opts:         []Option{MultiSelect()},
+			items:        services,
+			height:       3,
+			events:       events(button(mouse.ButtonLeft, 1, 1), button(mouse.ButtonLeft, 5, 2)),
+			wantSelected: 2,
+			wantChecked:  []string{"auth"},
+			wantCalls:    []string{"select 1 auth", "check [auth]", "select 2 billing"},
+		},
+		{
+			desc: "forwards errors from callbacks",
+			opts: []Option{
+				OnEnter(func(int, string) error { return errors.New("enter failed") }),
+			},
+			items:        services,
+			height:       3,
+			events:       events(key(keyboard.KeyEnter)),
+			wantSelected: 0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotCalls []string
+			opts := []Option{
+				OnSelect(func(i int, item string) error {
+					gotCalls = append(gotCalls, fmt.Sprintf("select %d %s", i, item))
+					return nil
+				}),
+				OnEnter(func(i int, item string) error {
+					gotCalls = append(gotCalls, fmt.Sprintf("enter %d %s", i, item))
+					return nil
+				}),
+				OnCheck(func(checked []string) error {
+					gotCalls = append(gotCalls, fmt.Sprintf("check %v", checked))
+					return nil
+				}),
+			}
+			l, err := New(append(opts, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+			l.now = func() time.Time { return now }
+			if err := l.SetItems(tc.items); err != nil {
+				t.Fatalf("SetItems => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(image.Rect(0, 0, 10, tc.height))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := l.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				now = now.Add(ev.wait)
+				var err error
+				if ev.k != nil {
+					err = l.Keyboard(ev.k)
+				} else {
+					err = l.Mouse(ev.m)
+				}
+				if err != nil {
+					if !tc.wantErr {
+						t.Fatalf("processing event %+v => unexpected error: %v", ev, err)
+					}
+					break
+				}
+				// Redraw so that the list scrolls like in a running
+				// application.
+				if err := l.Draw(cvs); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if gotSelected, _ := l.Selected(); gotSelected != tc.wantSelected {
+				t.Errorf("Selected => %d, want %d", gotSelected, tc.wantSelected)
+			}
+			if diff := pretty.Compare(tc.wantChecked, l.Checked()); diff != "" {
+				t.Errorf("Checked => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantCalls, gotCalls); diff != "" {
+				t.Errorf("callbacks => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCallbacksCanAccessList(t *testing.T) {
+	var l *List
+	var got []string
+	l, err := New(
+		MultiSelect(),
+		OnSelect(func(int, string) error {
+			_, item := l.Selected()
+			got = append(got, fmt.Sprintf("select %s", item))
+			return nil
+		}),
+		OnEnter(func(int, string) error {
+			_, item := l.Selected()
+			got = append(got, fmt.Sprintf("enter %s", item))
+			return nil
+		}),
+		OnCheck(func([]string) error {
+			got = append(got, fmt.Sprintf("check %v", l.Checked()))
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := l.SetItems(services); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		for _, k := range []keyboard.Key{keyboard.KeyArrowDown, keyboard.KeySpace, keyboard.KeyEnter} {
+			if err := l.Keyboard(&terminalapi.Keyboard{Key: k}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- l.Mouse(&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("processing events => unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("processing events => timed out, the callbacks deadlocked")
+	}
+
+	want := []string{"select auth", "check [auth]", "enter auth", "select api", "check [api auth]"}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("callbacks => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  []Option
+		theme *theme.Theme
+		items []string
+		// update gets called before drawing of the widget.
+		update func(*List) error
+		canvas image.Rectangle
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws empty without items",
+			canvas: image.Rect(0, 0, 10, 3),
+			golden: "List_empty.golden",
+		},
+		{
+			desc:   "items that fit",
+			items:  []string{"api", "auth", "billing"},
+			canvas: image.Rect(0, 0, 10, 4),
+			golden: "List_fit.golden",
+		},
+		{
+			desc:   "long items are trimmed",
+			items:  []string{"a very long item", "b"},
+			canvas: image.Rect(0, 0, 8, 2),
+			golden: "List_trimmed.golden",
+		},
+		{
+			desc:   "marks more items below",
+			items:  services,
+			canvas: image.Rect(0, 0, 10, 3),
+			golden: "List_marker_down.golden",
+		},
+		{
+			desc:  "scrolls to the selected item and marks items on both sides",
+			items: services,
+			update: func(l *List) error {
+				return l.Select("db")
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			golden: "List_scrolled.golden",
+		},
+		{
+			desc:  "no markers on a short canvas",
+			items: services,
+			update: func(l *List) error {
+				return l.Select("db")
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			golden: "List_no_markers.golden",
+		},
+		{
+			desc:  "multi select with checkboxes",
+			opts:  []Option{MultiSelect()},
+			items: []string{"api", "auth", "billing"},
+			update: func(l *List) error {
+				return l.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeySpace})
+			},
+			canvas: image.Rect(0, 0, 12, 3),
+			golden: "List_multi_select.golden",
+		},
+		{
+			desc:   "custom selected cell options",
+			opts:   []Option{SelectedCellOpts(cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorYellow))},
+			items:  []string{"api", "auth"},
+			canvas: image.Rect(0, 0, 6, 2),
+			golden: "List_selected_cellopts.golden",
+		},
+		{
+			desc:   "theme colors the selected item",
+			theme:  theme.Dark(),
+			items:  []string{"api", "auth"},
+			canvas: image.Rect(0, 0, 6, 2),
+			golden: "List_theme.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				l.SetTheme(tc.theme)
+			}
+			if err := l.SetItems(tc.items); err != nil {
+				t.Fatalf("SetItems => unexpected error: %v", err)
+			}
+			if tc.update != nil {
+				if err := tc.update(l); err != nil {
+					t.Fatalf("update => unexpected error: %v", err)
+				}
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := l.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := l.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
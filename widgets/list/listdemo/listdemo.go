@@ -0,0 +1,125 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary listdemo displays a List widget used as a sidebar that selects what
+// another widget displays.
+// Exist when Esc is pressed, other keys are used by the List.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/list"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// serviceNames are the items displayed in the list.
+var serviceNames = []string{
+	"api", "auth", "billing", "cache", "db", "frontend", "gateway", "inventory",
+	"mail", "notifications", "payments", "queue", "search", "storage", "users",
+}
+
+// describe writes made up details about the service into the text widget.
+func describe(t *text.Text, service string) error {
+	t.Reset()
+	if err := t.Write(fmt.Sprintf("Service: %s\n\n", service)); err != nil {
+		return err
+	}
+	return t.Write(fmt.Sprintf("Instances: %d\nLatency:   %dms\nErrors:    %.2f%%\n",
+		1+rand.Intn(10), 5+rand.Intn(200), rand.Float64()*2))
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	details := text.New()
+	events := text.New(text.RollContent())
+
+	services, err := list.New(
+		list.MultiSelect(),
+		list.OnSelect(func(_ int, service string) error {
+			return describe(details, service)
+		}),
+		list.OnEnter(func(_ int, service string) error {
+			return events.Write(fmt.Sprintf("%s: restarting %s\n", time.Now().Format("15:04:05"), service))
+		}),
+		list.OnCheck(func(checked []string) error {
+			return events.Write(fmt.Sprintf("%s: watching %v\n", time.Now().Format("15:04:05"), checked))
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := services.SetItems(serviceNames); err != nil {
+		panic(err)
+	}
+	if err := describe(details, serviceNames[0]); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS ESC TO QUIT, TYPE TO SEARCH, SPACE TO WATCH, ENTER TO RESTART"),
+		container.SplitVertical(
+			container.Left(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Services"),
+				container.PlaceWidget(services),
+			),
+			container.Right(
+				container.SplitHorizontal(
+					container.Top(
+						container.Border(draw.LineStyleLight),
+						container.BorderTitle("Details"),
+						container.PlaceWidget(details),
+					),
+					container.Bottom(
+						container.Border(draw.LineStyleLight),
+						container.BorderTitle("Events"),
+						container.PlaceWidget(events),
+					),
+				),
+			),
+			container.SplitPercent(30),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == keyboard.KeyEsc {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(100*time.Millisecond)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+// options.go contains configurable options for List.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	multiSelect      bool
+	selectedCellOpts []cell.Option
+	searchTimeout    time.Duration
+
+	onSelect CallbackFn
+	onEnter  CallbackFn
+	onCheck  CheckFn
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.searchTimeout <= 0 {
+		return fmt.Errorf("invalid search timeout %v, must be a positive duration", o.searchTimeout)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		searchTimeout: DefaultSearchTimeout,
+	}
+}
+
+// MultiSelect allows the user to check multiple items. Each item is displayed
+// with a checkbox, the space key toggles the checkmark of the selected item.
+func MultiSelect() Option {
+	return option(func(opts *options) {
+		opts.multiSelect = true
+	})
+}
+
+// DefaultSelectedColor is the background color of the selected item when the
+// container doesn't have a theme. Otherwise the color of the focused border is
+// used.
+const DefaultSelectedColor = cell.ColorBlue
+
+// SelectedCellOpts sets the cell options of the row that contains the
+// selected item. These take precedence over the default background color and
+// the colors of the theme.
+func SelectedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectedCellOpts = cOpts
+	})
+}
+
+// DefaultSearchTimeout is the default value for the SearchTimeout option.
+const DefaultSearchTimeout = 1 * time.Second
+
+// SearchTimeout sets how long the widget waits for the next typed character
+// before it starts a new search. Characters typed within the timeout extend
+// the searched prefix.
+func SearchTimeout(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.searchTimeout = d
+	})
+}
+
+// CallbackFn is a function called with an item of the list and its index.
+//
+// The callback function must be light-weight, ideally just storing a value and
+// returning, since it is called while the widget processes the keyboard or
+// mouse event. The callback function must be thread-safe as the events are
+// processed in a separate goroutine. The function is called after the widget
+// released its lock, so it can call the methods of the widget.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type CallbackFn func(index int, item string) error
+
+// OnSelect sets a function that is called when the user selects a different
+// item. Isn't called when the items or the selection are changed by calling
+// methods of the widget.
+func OnSelect(fn CallbackFn) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
+
+// OnEnter sets a function that is called with the selected item when the user
+// presses the enter key.
+func OnEnter(fn CallbackFn) Option {
+	return option(func(opts *options) {
+		opts.onEnter = fn
+	})
+}
+
+// CheckFn is a function called with the checked items in the order in which
+// they appear in the list. The same rules as for CallbackFn apply.
+type CheckFn func(checked []string) error
+
+// OnCheck sets a function that is called when the user checks or unchecks an
+// item. Only used together with the MultiSelect option.
+func OnCheck(fn CheckFn) Option {
+	return option(func(opts *options) {
+		opts.onCheck = fn
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pie
+
+// circle.go assists in calculation of points and angles on a circle.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/trig"
+)
+
+// arc is the part of the circle that represents one slice.
+type arc struct {
+	// start and end are the angles in degrees where the arc starts and ends
+	// in the counter-clockwise direction.
+	start, end int
+	// empty indicates that the slice is too small to be drawn.
+	empty bool
+	// full indicates that the slice covers the entire circle.
+	full bool
+}
+
+// contains asserts whether the angle in degrees falls within the arc.
+func (a *arc) contains(angle int) bool {
+	switch {
+	case a.empty:
+		return false
+	case a.full:
+		return true
+	case a.start <= a.end:
+		return angle >= a.start && angle <= a.end
+	default: // The arc crosses the 0/360 degree point.
+		return angle >= a.start || angle <= a.end
+	}
+}
+
+// normalizeAngle returns the equivalent angle in range 0 <= angle < 360.
+func normalizeAngle(angle int) int {
+	return (angle%trig.MaxAngle + trig.MaxAngle) % trig.MaxAngle
+}
+
+// sliceArcs returns the arcs of the slices that represent the values, given
+// the angle where the first slice starts and the direction in which the slices
+// follow each other. The values must be zero or positive.
+func sliceArcs(values []float64, startAngle, direction int) []*arc {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+
+	var arcs []*arc
+	var cum float64
+	prev := startAngle
+	for _, v := range values {
+		cum += v
+		var size int
+		if total > 0 {
+			size = int(numbers.Round(cum / total * trig.MaxAngle))
+		}
+		next := startAngle + direction*size
+
+		switch d := next - prev; {
+		case d == 0:
+			arcs = append(arcs, &arc{empty: true})
+		case d == trig.MaxAngle || d == -trig.MaxAngle:
+			arcs = append(arcs, &arc{start: 0, end: trig.MaxAngle, full: true})
+		default:
+			start, end := prev, next
+			if d < 0 {
+				start, end = next, prev
+			}
+			start, end = normalizeAngle(start), normalizeAngle(end)
+			if end == 0 {
+				end = trig.MaxAngle
+			}
+			arcs = append(arcs, &arc{start: start, end: end})
+		}
+		prev = next
+	}
+	return arcs
+}
+
+// cellPixel returns the pixel on the braille canvas in the middle of the cell.
+func cellPixel(p image.Point) image.Point {
+	return image.Point{
+		p.X*braille.ColMult + braille.ColMult/2,
+		p.Y*braille.RowMult + braille.RowMult/2,
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pie
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestSliceArcs(t *testing.T) {
+	tests := []struct {
+		desc       string
+		values     []float64
+		startAngle int
+		direction  int
+		want       []*arc
+	}{
+		{
+			desc:       "no values",
+			startAngle: 90,
+			direction:  -1,
+		},
+		{
+			desc:       "all values are zero",
+			values:     []float64{0, 0},
+			startAngle: 90,
+			direction:  -1,
+			want: []*arc{
+				{empty: true},
+				{empty: true},
+			},
+		},
+		{
+			desc:       "single value covers the full circle",
+			values:     []float64{0, 5},
+			startAngle: 90,
+			direction:  -1,
+			want: []*arc{
+				{empty: true},
+				{start: 0, end: 360, full: true},
+			},
+		},
+		{
+			desc:       "clockwise from the top",
+			values:     []float64{1, 1, 2},
+			startAngle: 90,
+			direction:  -1,
+			want: []*arc{
+				{start: 0, end: 90},
+				{start: 270, end: 360},
+				{start: 90, end: 270},
+			},
+		},
+		{
+			desc:       "counter-clockwise from the top",
+			values:     []float64{1, 1, 2},
+			startAngle: 90,
+			direction:  1,
+			want: []*arc{
+				{start: 90, end: 180},
+				{start: 180, end: 270},
+				{start: 270, end: 90},
+			},
+		},
+		{
+			desc:       "angles are rounded without gaps",
+			values:     []float64{1, 1, 1},
+			startAngle: 0,
+			direction:  1,
+			want: []*arc{
+				{start: 0, end: 120},
+				{start: 120, end: 240},
+				{start: 240, end: 360},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := sliceArcs(tc.values, tc.startAngle, tc.direction)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("sliceArcs => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestArcContains(t *testing.T) {
+	tests := []struct {
+		desc  string
+		arc   *arc
+		angle int
+		want  bool
+	}{
+		{
+			desc:  "empty arc contains nothing",
+			arc:   &arc{empty: true},
+			angle: 0,
+			want:  false,
+		},
+		{
+			desc:  "full arc contains everything",
+			arc:   &arc{start: 0, end: 360, full: true},
+			angle: 123,
+			want:  true,
+		},
+		{
+			desc:  "angle inside of the arc",
+			arc:   &arc{start: 10, end: 90},
+			angle: 45,
+			want:  true,
+		},
+		{
+			desc:  "angle outside of the arc",
+			arc:   &arc{start: 10, end: 90},
+			angle: 91,
+			want:  false,
+		},
+		{
+			desc:  "arc across zero contains angle after zero",
+			arc:   &arc{start: 270, end: 90},
+			angle: 10,
+			want:  true,
+		},
+		{
+			desc:  "arc across zero contains angle before zero",
+			arc:   &arc{start: 270, end: 90},
+			angle: 300,
+			want:  true,
+		},
+		{
+			desc:  "arc across zero doesn't contain angle outside",
+			arc:   &arc{start: 270, end: 90},
+			angle: 180,
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.arc.contains(tc.angle); got != tc.want {
+				t.Errorf("contains(%d) => %v, want %v", tc.angle, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pie
+
+// options.go contains configurable options for Pie.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	holePercent  int
+	hideLegend   bool
+	textCellOpts []cell.Option
+
+	// The angle in degrees where the first slice starts.
+	startAngle int
+	// The direction in which the slices follow each other.
+	// Positive for counter-clockwise, negative for clockwise.
+	direction int
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min, max := 0, 100; o.holePercent < min || o.holePercent > max {
+		return fmt.Errorf("invalid hole percent %d, must be in range %d <= p <= %d", o.holePercent, min, max)
+	}
+
+	if min, max := 0, 360; o.startAngle < min || o.startAngle >= max {
+		return fmt.Errorf("invalid start angle %d, must be in range %d <= angle < %d", o.startAngle, min, max)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		startAngle: DefaultStartAngle,
+		direction:  -1,
+	}
+}
+
+// HolePercent sets the size of the "hole" inside the pie as a percentage of
+// its radius, which turns the pie into a donut. When a slice is highlighted,
+// its percentage is displayed in the hole if it fits.
+// Defaults to zero, i.e. no hole. Valid range is 0 <= p <= 100.
+func HolePercent(p int) Option {
+	return option(func(opts *options) {
+		opts.holePercent = p
+	})
+}
+
+// TextCellOpts sets cell options on cells that contain the percentage of the
+// highlighted slice displayed in the hole.
+// The text uses the label color of the theme set on the container unless the
+// cell options specify another color.
+func TextCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.textCellOpts = cOpts
+	})
+}
+
+// HideLegend disables the legend. By default the legend with the labels of
+// the slices and their percentages is displayed to the right of the pie.
+func HideLegend() Option {
+	return option(func(opts *options) {
+		opts.hideLegend = true
+	})
+}
+
+// DefaultStartAngle is the default value for the StartAngle option.
+const DefaultStartAngle = 90
+
+// StartAngle sets the angle in degrees where the first slice starts.
+// Valid values are in range 0 <= angle < 360.
+// Angles start at the X axis and grow counter-clockwise.
+func StartAngle(angle int) Option {
+	return option(func(opts *options) {
+		opts.startAngle = angle
+	})
+}
+
+// Clockwise places the slices after each other in the clockwise direction.
+// This is the default option.
+func Clockwise() Option {
+	return option(func(opts *options) {
+		opts.direction = -1
+	})
+}
+
+// CounterClockwise places the slices after each other in the
+// counter-clockwise direction.
+func CounterClockwise() Option {
+	return option(func(opts *options) {
+		opts.direction = 1
+	})
+}
+
+// SliceOption is used to provide options to Slice().
+type SliceOption interface {
+	// set sets the provided option.
+	set(*slice)
+}
+
+// sliceOption implements SliceOption.
+type sliceOption func(*slice)
+
+// set implements SliceOption.set.
+func (so sliceOption) set(s *slice) {
+	so(s)
+}
+
+// DefaultColors are the colors of the slices, unless specified otherwise via
+// the SliceCellOpts option or by the series colors of the theme set on the
+// container. The first color applies to the first slice, the colors repeat
+// if there are more slices.
+var DefaultColors = []cell.Color{
+	cell.ColorGreen,
+	cell.ColorBlue,
+	cell.ColorYellow,
+	cell.ColorMagenta,
+	cell.ColorCyan,
+	cell.ColorRed,
+}
+
+// SliceCellOpts sets the cell options for the cells that contain the slice
+// and its entry in the legend.
+func SliceCellOpts(co ...cell.Option) SliceOption {
+	return sliceOption(func(s *slice) {
+		s.cellOpts = co
+	})
+}
@@ -0,0 +1,469 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pie is a widget that displays the shares of labeled values as slices
+// of a circle.
+package pie
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sync"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/trig"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// slice is one labeled value displayed on the pie.
+type slice struct {
+	label    string
+	value    float64
+	cellOpts []cell.Option
+}
+
+// Pie displays the shares of labeled values as slices of a circle, e.g. the
+// disk usage by volume. The circle can have a "hole" in the middle. A legend
+// next to the circle lists the labels of the slices and their percentages.
+//
+// A mouse click on a slice or on its entry in the legend highlights the slice,
+// the other slices shrink. Another click on the same slice or a click outside
+// of the slices removes the highlight.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Pie struct {
+	// slices are the displayed slices in the order they were added.
+	slices []*slice
+	// highlighted is the index of the highlighted slice or -1 if none is.
+	highlighted int
+
+	// The layout of the last drawn pie, used to process mouse events.
+	// circleAr is the area on the canvas where the circle is drawn.
+	circleAr image.Rectangle
+	// legendAr is the area on the canvas where the legend is drawn, one slice
+	// per row.
+	legendAr image.Rectangle
+	// mid and radius of the drawn circle on the braille canvas and the radius
+	// of its hole.
+	mid    image.Point
+	radius int
+	holeR  int
+	// arcs are the arcs of the drawn slices.
+	arcs []*arc
+
+	// mu protects the Pie.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new Pie.
+func New(opts ...Option) (*Pie, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Pie{
+		highlighted: -1,
+		opts:        opt,
+	}, nil
+}
+
+// Slice sets the value of the slice with the provided label.
+// A slice that doesn't exist yet is added after the existing slices. The value
+// must be zero or a positive number. Each slice is sized by its share of the
+// sum of the values of all the slices.
+// Provided options override values set on earlier calls for the same label.
+func (p *Pie) Slice(label string, value float64, opts ...SliceOption) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("invalid value %v for slice %q, must be a zero or a positive number", value, label)
+	}
+
+	s := p.slice(label)
+	if s == nil {
+		s = &slice{label: label}
+		p.slices = append(p.slices, s)
+	}
+	s.value = value
+	for _, opt := range opts {
+		opt.set(s)
+	}
+	return nil
+}
+
+// slice returns the slice with the label or nil if it doesn't exist.
+// p.mu must be held when calling this method.
+func (p *Pie) slice(label string) *slice {
+	for _, s := range p.slices {
+		if s.label == label {
+			return s
+		}
+	}
+	return nil
+}
+
+// Clear removes all the slices.
+func (p *Pie) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.slices = nil
+	p.highlighted = -1
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (p *Pie) SetTheme(t *theme.Theme) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.theme = t
+}
+
+// sliceCellOpts returns the cell options of the i-th slice prefixed with its
+// default color, so that colors in the options take precedence.
+// p.mu must be held when calling this method.
+func (p *Pie) sliceCellOpts(i int) []cell.Option {
+	c := DefaultColors[i%len(DefaultColors)]
+	if p.theme != nil {
+		c = p.theme.SeriesColor(i)
+	}
+	return append([]cell.Option{cell.FgColor(c)}, p.slices[i].cellOpts...)
+}
+
+// textCellOpts returns the cell options of the text in the hole prefixed with
+// the label color of the theme, so that colors in the options take
+// precedence.
+// p.mu must be held when calling this method.
+func (p *Pie) textCellOpts() []cell.Option {
+	if p.theme == nil {
+		return p.opts.textCellOpts
+	}
+	return append([]cell.Option{cell.FgColor(p.theme.Label)}, p.opts.textCellOpts...)
+}
+
+// percent returns the share of the i-th slice in percent.
+// p.mu must be held when calling this method.
+func (p *Pie) percent(i int) float64 {
+	var total float64
+	for _, s := range p.slices {
+		total += s.value
+	}
+	if total == 0 {
+		return 0
+	}
+	return p.slices[i].value / total * 100
+}
+
+// percentText formats the percentage.
+func percentText(pct float64) string {
+	return fmt.Sprintf("%.1f%%", pct)
+}
+
+// holeRadius calculates the radius of the "hole" in the pie.
+// Returns zero if no hole should be drawn.
+func (p *Pie) holeRadius(radius int) int {
+	r := int(numbers.Round(float64(radius) / 100 * float64(p.opts.holePercent)))
+	if r < 2 { // Smallest possible circle radius.
+		return 0
+	}
+	return r
+}
+
+// explodeRadius returns the radius of the slices that aren't highlighted
+// while a slice is highlighted.
+func explodeRadius(radius int) int {
+	r := radius - radius/8 - 1
+	if r < 2 { // Smallest possible circle radius.
+		return radius
+	}
+	return r
+}
+
+// Draw draws the Pie widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (p *Pie) Draw(cvs *canvas.Canvas) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.circleAr, p.legendAr = p.layout(cvs.Area())
+	bc, err := braille.New(p.circleAr)
+	if err != nil {
+		return fmt.Errorf("braille.New => %v", err)
+	}
+
+	var values []float64
+	for _, s := range p.slices {
+		values = append(values, s.value)
+	}
+	p.arcs = sliceArcs(values, p.opts.startAngle, p.opts.direction)
+	p.mid, p.radius = draw.BrailleCircleMidAndRadius(bc.Area())
+	p.holeR = p.holeRadius(p.radius)
+	if p.radius < 2 {
+		return p.drawLegend(cvs)
+	}
+
+	for i, a := range p.arcs {
+		if a.empty {
+			continue
+		}
+		r := p.radius
+		if p.highlighted != -1 && p.highlighted != i {
+			r = explodeRadius(r)
+		}
+		opts := []draw.BrailleCircleOption{
+			draw.BrailleCircleFilled(),
+			draw.BrailleCircleCellOpts(p.sliceCellOpts(i)...),
+		}
+		if !a.full {
+			opts = append(opts, draw.BrailleCircleArcOnly(a.start, a.end))
+		}
+		if err := draw.BrailleCircle(bc, p.mid, r, opts...); err != nil {
+			return fmt.Errorf("failed to draw slice %q: %v", p.slices[i].label, err)
+		}
+	}
+
+	if p.holeR != 0 {
+		if err := draw.BrailleCircle(bc, p.mid, p.holeR,
+			draw.BrailleCircleFilled(),
+			draw.BrailleCircleClearPixels(),
+		); err != nil {
+			return fmt.Errorf("failed to draw the hole: %v", err)
+		}
+	}
+	if err := bc.CopyTo(cvs); err != nil {
+		return err
+	}
+
+	if err := p.drawHoleText(cvs); err != nil {
+		return err
+	}
+	return p.drawLegend(cvs)
+}
+
+// drawHoleText draws the percentage of the highlighted slice in the hole.
+// The text is only drawn if the hole is large enough to accommodate it.
+// p.mu must be held when calling this method.
+func (p *Pie) drawHoleText(cvs *canvas.Canvas) error {
+	if p.highlighted == -1 || p.holeR == 0 {
+		return nil
+	}
+
+	cells, first := draw.BrailleCircleTextCells(p.mid, p.holeR)
+	t := percentText(p.percent(p.highlighted))
+	needCells := runewidth.StringWidth(t)
+	if cells < needCells {
+		return nil
+	}
+
+	first = first.Add(p.circleAr.Min)
+	ar := image.Rect(first.X, first.Y, first.X+cells+2, first.Y+1)
+	start, err := align.Text(ar, t, align.HorizontalCenter, align.VerticalMiddle)
+	if err != nil {
+		return fmt.Errorf("align.Text => %v", err)
+	}
+	if err := draw.Text(cvs, t, start, draw.TextMaxX(start.X+needCells), draw.TextCellOpts(p.textCellOpts()...)); err != nil {
+		return fmt.Errorf("draw.Text => %v", err)
+	}
+	return nil
+}
+
+// legendMarker is drawn in front of the label of each slice in the legend.
+const legendMarker = '⣿'
+
+// legendSpacing is the number of empty cells between the circle and the
+// legend.
+const legendSpacing = 1
+
+// legendEntries returns the text of the legend entries. The labels are padded,
+// so that the percentages are aligned.
+// p.mu must be held when calling this method.
+func (p *Pie) legendEntries() []string {
+	var widest int
+	for _, s := range p.slices {
+		if w := runewidth.StringWidth(s.label); w > widest {
+			widest = w
+		}
+	}
+
+	var entries []string
+	for i, s := range p.slices {
+		entries = append(entries, fmt.Sprintf("%c %s %6s",
+			legendMarker, runewidth.FillRight(s.label, widest), percentText(p.percent(i))))
+	}
+	return entries
+}
+
+// layout splits the canvas area into the area for the circle and for the
+// legend. The legend area is empty if the legend isn't drawn or doesn't fit.
+// p.mu must be held when calling this method.
+func (p *Pie) layout(cvsAr image.Rectangle) (circleAr, legendAr image.Rectangle) {
+	if p.opts.hideLegend || len(p.slices) == 0 {
+		return cvsAr, image.ZR
+	}
+
+	var width int
+	for _, e := range p.legendEntries() {
+		if w := runewidth.StringWidth(e); w > width {
+			width = w
+		}
+	}
+	// The circle needs at least three cells.
+	avail := cvsAr.Dx() - width - legendSpacing
+	if avail < 3 {
+		return cvsAr, image.ZR
+	}
+
+	// The width of the largest circle that fits the height.
+	circleWidth := cvsAr.Dy() * braille.RowMult / braille.ColMult
+	if circleWidth > avail {
+		circleWidth = avail
+	}
+	height := len(p.slices)
+	if height > cvsAr.Dy() {
+		height = cvsAr.Dy()
+	}
+	top := cvsAr.Min.Y + (cvsAr.Dy()-height)/2
+	legendX := cvsAr.Min.X + circleWidth + legendSpacing
+	return image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Min.X+circleWidth, cvsAr.Max.Y),
+		image.Rect(legendX, top, legendX+width, top+height)
+}
+
+// drawLegend draws the legend, one slice per row. The entry of the
+// highlighted slice is drawn in the color of the slice as its background.
+// Entries that don't fit are omitted.
+// p.mu must be held when calling this method.
+func (p *Pie) drawLegend(cvs *canvas.Canvas) error {
+	if p.legendAr.Empty() {
+		return nil
+	}
+
+	for i, e := range p.legendEntries() {
+		pos := image.Point{p.legendAr.Min.X, p.legendAr.Min.Y + i}
+		if !pos.In(p.legendAr) {
+			break
+		}
+
+		cOpts := p.sliceCellOpts(i)
+		if i == p.highlighted {
+			fg := cell.NewOptions(cOpts...).FgColor
+			cOpts = append(cOpts, cell.FgColor(cell.ColorBlack), cell.BgColor(fg))
+		}
+		if err := draw.Text(cvs, e, pos,
+			draw.TextMaxX(p.legendAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(cOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the legend: %v", err)
+		}
+	}
+	return nil
+}
+
+// toggleHighlight highlights the i-th slice or removes the highlight if the
+// slice is already highlighted.
+// p.mu must be held when calling this method.
+func (p *Pie) toggleHighlight(i int) {
+	if p.highlighted == i {
+		p.highlighted = -1
+		return
+	}
+	p.highlighted = i
+}
+
+// sliceAt returns the index of the slice drawn at the point on the canvas.
+// Returns false if there isn't any slice at the point.
+// p.mu must be held when calling this method.
+func (p *Pie) sliceAt(point image.Point) (int, bool) {
+	if p.radius < 2 || !point.In(p.circleAr) {
+		return 0, false
+	}
+
+	px := cellPixel(point.Sub(p.circleAr.Min))
+	d := px.Sub(p.mid)
+	dist := math.Sqrt(float64(d.X*d.X + d.Y*d.Y))
+	if dist > float64(p.radius+1) || (p.holeR != 0 && dist < float64(p.holeR-1)) {
+		return 0, false
+	}
+
+	angle := trig.CircleAngleAtPoint(px, p.mid)
+	for i, a := range p.arcs {
+		if i < len(p.slices) && a.contains(angle) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Keyboard input isn't supported on the Pie widget.
+func (*Pie) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Pie widget doesn't support keyboard events")
+}
+
+// Mouse highlights the slice that was clicked on, either on the circle or in
+// the legend.
+// Implements widgetapi.Widget.Mouse.
+func (p *Pie) Mouse(m *terminalapi.Mouse) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if m.Button != mouse.ButtonLeft {
+		return nil
+	}
+
+	if m.Position.In(p.legendAr) {
+		if i := m.Position.Y - p.legendAr.Min.Y; i < len(p.slices) {
+			p.toggleHighlight(i)
+		}
+		return nil
+	}
+	if i, ok := p.sliceAt(m.Position); ok {
+		p.toggleHighlight(i)
+		return nil
+	}
+	p.highlighted = -1
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (p *Pie) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// The smallest circle that "looks" like a circle on the canvas.
+		MinimumSize:  image.Point{3, 3},
+		WantKeyboard: false,
+		WantMouse:    true,
+	}
+}
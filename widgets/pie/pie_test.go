@@ -0,0 +1,398 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pie
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc:    "fails on hole percent too low",
+			opts:    []Option{HolePercent(-1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on hole percent too high",
+			opts:    []Option{HolePercent(101)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on start angle too high",
+			opts:    []Option{StartAngle(360)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		value   float64
+		wantErr bool
+	}{
+		{
+			desc:  "accepts zero",
+			label: "a",
+			value: 0,
+		},
+		{
+			desc:    "fails on an empty label",
+			value:   1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on a negative value",
+			label:   "a",
+			value:   -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on NaN",
+			label:   "a",
+			value:   math.NaN(),
+			wantErr: true,
+		},
+		{
+			desc:    "fails on infinity",
+			label:   "a",
+			value:   math.Inf(1),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			err = p.Slice(tc.label, tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Slice => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// click returns a left button mouse click at the point.
+func click(x, y int) *terminalapi.Mouse {
+	return &terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonLeft}
+}
+
+func TestPie(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		// update gets called before drawing of the widget.
+		update func(*Pie) error
+		canvas image.Rectangle
+		// clicks are mouse clicks processed after the first draw, the widget
+		// is drawn again afterwards.
+		clicks []*terminalapi.Mouse
+		// wantHighlighted is the index of the highlighted slice.
+		wantHighlighted int
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:            "draws empty without slices",
+			update:          func(*Pie) error { return nil },
+			canvas:          image.Rect(0, 0, 10, 5),
+			wantHighlighted: -1,
+			golden:          "Pie_empty.golden",
+		},
+		{
+			desc: "slices with a legend",
+			update: func(p *Pie) error {
+				if err := p.Slice("root", 50); err != nil {
+					return err
+				}
+				if err := p.Slice("home", 30); err != nil {
+					return err
+				}
+				return p.Slice("var", 20)
+			},
+			canvas:          image.Rect(0, 0, 34, 8),
+			wantHighlighted: -1,
+			golden:          "Pie_legend.golden",
+		},
+		{
+			desc: "slice with custom cell options",
+			update: func(p *Pie) error {
+				if err := p.Slice("a", 1, SliceCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return p.Slice("b", 1)
+			},
+			canvas:          image.Rect(0, 0, 26, 8),
+			wantHighlighted: -1,
+			golden:          "Pie_cellopts.golden",
+		},
+		{
+			desc: "uses the colors of the theme",
+			update: func(p *Pie) error {
+				p.SetTheme(&theme.Theme{
+					Label:  cell.ColorMagenta,
+					Series: []cell.Color{cell.ColorBlue, cell.ColorGreen},
+				})
+				if err := p.Slice("root", 50); err != nil {
+					return err
+				}
+				if err := p.Slice("home", 30); err != nil {
+					return err
+				}
+				return p.Slice("var", 20)
+			},
+			canvas:          image.Rect(0, 0, 34, 8),
+			wantHighlighted: -1,
+			golden:          "Pie_theme.golden",
+		},
+		{
+			desc: "slice cell options take precedence over the theme",
+			update: func(p *Pie) error {
+				p.SetTheme(&theme.Theme{
+					Series: []cell.Color{cell.ColorYellow, cell.ColorCyan},
+				})
+				if err := p.Slice("a", 1, SliceCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return p.Slice("b", 1)
+			},
+			canvas:          image.Rect(0, 0, 26, 8),
+			wantHighlighted: -1,
+			golden:          "Pie_theme_cellopts.golden",
+		},
+		{
+			desc: "updating a slice keeps its position",
+			update: func(p *Pie) error {
+				for _, l := range []string{"a", "b", "a"} {
+					if err := p.Slice(l, 1); err != nil {
+						return err
+					}
+				}
+				return p.Slice("b", 3)
+			},
+			canvas:          image.Rect(0, 0, 26, 8),
+			wantHighlighted: -1,
+			golden:          "Pie_update.golden",
+		},
+		{
+			desc: "legend is omitted when it doesn't fit",
+			update: func(p *Pie) error {
+				if err := p.Slice("a very long label", 1); err != nil {
+					return err
+				}
+				return p.Slice("b", 1)
+			},
+			canvas:          image.Rect(0, 0, 20, 5),
+			wantHighlighted: -1,
+			golden:          "Pie_legend_omitted.golden",
+		},
+		{
+			desc: "hidden legend",
+			opts: []Option{HideLegend()},
+			update: func(p *Pie) error {
+				if err := p.Slice("a", 1); err != nil {
+					return err
+				}
+				return p.Slice("b", 3)
+			},
+			canvas:          image.Rect(0, 0, 16, 8),
+			wantHighlighted: -1,
+			golden:          "Pie_hidden_legend.golden",
+		},
+		{
+			desc: "click on the legend highlights the slice",
+			opts: []Option{HolePercent(50)},
+			update: func(p *Pie) error {
+				if err := p.Slice("root", 50); err != nil {
+					return err
+				}
+				if err := p.Slice("home", 30); err != nil {
+					return err
+				}
+				return p.Slice("var", 20)
+			},
+			canvas:          image.Rect(0, 0, 38, 10),
+			clicks:          []*terminalapi.Mouse{click(23, 4)},
+			wantHighlighted: 1,
+			golden:          "Pie_highlight.golden",
+		},
+		{
+			desc: "release outside of the canvas is ignored",
+			opts: []Option{HolePercent(50)},
+			update: func(p *Pie) error {
+				if err := p.Slice("root", 50); err != nil {
+					return err
+				}
+				if err := p.Slice("home", 30); err != nil {
+					return err
+				}
+				return p.Slice("var", 20)
+			},
+			canvas: image.Rect(0, 0, 38, 10),
+			clicks: []*terminalapi.Mouse{
+				click(23, 4),
+				{Position: image.Point{-4, -2}, Button: mouse.ButtonRelease},
+				{Position: image.Point{80, 40}, Button: mouse.ButtonRelease},
+			},
+			wantHighlighted: 1,
+			golden:          "Pie_highlight.golden",
+		},
+		{
+			desc: "second click on the legend removes the highlight",
+			update: func(p *Pie) error {
+				if err := p.Slice("root", 50); err != nil {
+					return err
+				}
+				return p.Slice("home", 50)
+			},
+			canvas:          image.Rect(0, 0, 34, 8),
+			clicks:          []*terminalapi.Mouse{click(17, 3), click(17, 3)},
+			wantHighlighted: -1,
+			golden:          "Pie_highlight_removed.golden",
+		},
+		{
+			desc: "click on the circle highlights the slice",
+			update: func(p *Pie) error {
+				if err := p.Slice("right", 50); err != nil {
+					return err
+				}
+				return p.Slice("left", 50)
+			},
+			canvas:          image.Rect(0, 0, 34, 8),
+			clicks:          []*terminalapi.Mouse{click(3, 4)},
+			wantHighlighted: 1,
+			golden:          "Pie_highlight_circle.golden",
+		},
+		{
+			desc: "click outside of the slices removes the highlight",
+			update: func(p *Pie) error {
+				if err := p.Slice("right", 50); err != nil {
+					return err
+				}
+				return p.Slice("left", 50)
+			},
+			canvas:          image.Rect(0, 0, 34, 8),
+			clicks:          []*terminalapi.Mouse{click(3, 4), click(33, 7)},
+			wantHighlighted: -1,
+			golden:          "Pie_highlight_removed_outside.golden",
+		},
+		{
+			desc: "other mouse buttons are ignored",
+			update: func(p *Pie) error {
+				return p.Slice("a", 1)
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			clicks: []*terminalapi.Mouse{
+				{Position: image.Point{17, 3}, Button: mouse.ButtonRight},
+			},
+			wantHighlighted: -1,
+			golden:          "Pie_ignored_button.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := tc.update(p); err != nil {
+				t.Fatalf("update => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := p.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, m := range tc.clicks {
+				if err := p.Mouse(m); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+			if p.highlighted != tc.wantHighlighted {
+				t.Errorf("highlighted => %d, want %d", p.highlighted, tc.wantHighlighted)
+			}
+
+			cvs, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := p.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := p.Options()
+	want := widgetapi.Options{
+		MinimumSize: image.Point{3, 3},
+		WantMouse:   true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
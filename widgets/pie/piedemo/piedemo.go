@@ -0,0 +1,115 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary piedemo displays a couple of Pie widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/pie"
+)
+
+// playPie periodically changes the values of the slices on the pie.
+// Exits when the context expires.
+func playPie(ctx context.Context, p *pie.Pie, labels []string, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, l := range labels {
+				if err := p.Slice(l, float64(10+rand.Intn(90))); err != nil {
+					panic(err)
+				}
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	disks, err := pie.New()
+	if err != nil {
+		panic(err)
+	}
+	for _, s := range []struct {
+		label string
+		value float64
+	}{
+		{"/", 42},
+		{"/home", 120},
+		{"/var", 18},
+		{"/tmp", 5},
+	} {
+		if err := disks.Slice(s.label, s.value); err != nil {
+			panic(err)
+		}
+	}
+
+	traffic, err := pie.New(pie.HolePercent(50))
+	if err != nil {
+		panic(err)
+	}
+	regions := []string{"europe", "americas", "asia", "africa"}
+	go playPie(ctx, traffic, regions, 2*time.Second)
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS Q TO QUIT, CLICK A SLICE TO HIGHLIGHT IT"),
+		container.SplitVertical(
+			container.Left(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Disk usage"),
+				container.PlaceWidget(disks),
+			),
+			container.Right(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Traffic by region"),
+				container.PlaceWidget(traffic),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(1*time.Second)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package radio is a widget that displays a group of options out of which the
+// user selects exactly one.
+package radio
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"unicode"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The buttons displayed in front of the labels.
+const (
+	buttonSelected   = "(•) "
+	buttonUnselected = "( ) "
+)
+
+// RadioGroup displays a vertical group of options out of which exactly one is
+// selected, e.g. the time range displayed by a chart.
+//
+// An option is selected by a click of the left mouse button. While the
+// container of the radio group has the keyboard focus, the arrow keys select
+// the previous or the next option and the selected option is highlighted.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type RadioGroup struct {
+	// labels are the labels of the options.
+	labels []string
+	// selected is the index of the selected option.
+	selected int
+
+	// pressed is the index of the option on which the left mouse button is
+	// held, -1 if the mouse button isn't held.
+	pressed int
+	// focused indicates whether the container of the radio group has the
+	// keyboard focus.
+	focused bool
+	// area is the area of the canvas from the last call to Draw.
+	area image.Rectangle
+
+	// pending are the callbacks to call once mu is released, so that the
+	// callbacks can access the RadioGroup.
+	pending []func() error
+
+	// mu protects the RadioGroup.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new RadioGroup with the options that have the labels. The
+// labels must be unique, at least one must be provided. The first option is
+// initially selected.
+func New(labels []string, opts ...Option) (*RadioGroup, error) {
+	if len(labels) == 0 {
+		return nil, errors.New("at least one option must be provided")
+	}
+	seen := map[string]bool{}
+	for _, l := range labels {
+		if err := validLabel(l); err != nil {
+			return nil, err
+		}
+		if seen[l] {
+			return nil, fmt.Errorf("duplicate label %q, the labels must be unique", l)
+		}
+		seen[l] = true
+	}
+
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	return &RadioGroup{
+		labels:  append([]string(nil), labels...),
+		pressed: -1,
+		opts:    o,
+	}, nil
+}
+
+// Select selects the option with the label. Returns an error if the radio
+// group doesn't have such option.
+func (rg *RadioGroup) Select(label string) error {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	for i, l := range rg.labels {
+		if l == label {
+			rg.selected = i
+			return nil
+		}
+	}
+	return fmt.Errorf("the radio group doesn't have option %q", label)
+}
+
+// Selected returns the label of the selected option and its index.
+func (rg *RadioGroup) Selected() (int, string) {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+	return rg.selected, rg.labels[rg.selected]
+}
+
+// unlockAndCall releases rg.mu and calls the pending callbacks in order.
+// Returns the error of the first callback that fails, the remaining callbacks
+// aren't called.
+// rg.mu must be held when calling this method.
+func (rg *RadioGroup) unlockAndCall() error {
+	pending := rg.pending
+	rg.pending = nil
+	rg.mu.Unlock()
+
+	for _, call := range pending {
+		if err := call(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveTo selects the option at the index and schedules the OnChange callback
+// if the selection changed. Indices outside of the options are ignored.
+// rg.mu must be held when calling this method.
+func (rg *RadioGroup) moveTo(i int) {
+	if i < 0 || i >= len(rg.labels) || i == rg.selected {
+		return
+	}
+
+	rg.selected = i
+	if fn := rg.opts.onChange; fn != nil {
+		label := rg.labels[i]
+		rg.pending = append(rg.pending, func() error { return fn(i, label) })
+	}
+}
+
+// cellOpts returns the cell options of the option at the index. The options
+// are prefixed with the colors of the theme, so that the colors in the
+// options take precedence.
+// rg.mu must be held when calling this method.
+func (rg *RadioGroup) cellOpts(i int) []cell.Option {
+	var cOpts []cell.Option
+	if rg.theme != nil {
+		cOpts = append(cOpts, cell.FgColor(rg.theme.Label))
+	}
+	cOpts = append(cOpts, rg.opts.labelCellOpts...)
+
+	switch {
+	case i == rg.pressed:
+		bg := rg.opts.pressedColor
+		if rg.theme != nil && !rg.opts.pressedColorSet {
+			bg = rg.theme.Axis
+		}
+		cOpts = append(cOpts, cell.BgColor(bg))
+
+	case i == rg.selected && rg.focused:
+		bg := rg.opts.focusedColor
+		if rg.theme != nil && !rg.opts.focusedColorSet {
+			bg = rg.theme.FocusedBorder
+		}
+		cOpts = append(cOpts, cell.BgColor(bg))
+	}
+	return cOpts
+}
+
+// Draw draws the RadioGroup widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (rg *RadioGroup) Draw(cvs *canvas.Canvas) error {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	ar := cvs.Area()
+	rg.area = ar
+	for i, l := range rg.labels {
+		if i >= ar.Dy() {
+			break
+		}
+
+		button := buttonUnselected
+		if i == rg.selected {
+			button = buttonSelected
+		}
+		if err := draw.Text(cvs, button+l, image.Point{0, i},
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(rg.cellOpts(i)...),
+		); err != nil {
+			return fmt.Errorf("failed to draw option %q: %v", l, err)
+		}
+	}
+	return nil
+}
+
+// Keyboard selects the previous or the next option when the up or the down
+// arrow key is pressed.
+// Implements widgetapi.Widget.Keyboard.
+func (rg *RadioGroup) Keyboard(k *terminalapi.Keyboard) error {
+	rg.mu.Lock()
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		rg.moveTo(rg.selected - 1)
+	case keyboard.KeyArrowDown:
+		rg.moveTo(rg.selected + 1)
+	}
+	return rg.unlockAndCall()
+}
+
+// Mouse selects the option when the left mouse button is released over the
+// same option it was pressed on. A release outside of the radio group, which
+// the container forwards to the widget that received the press, doesn't
+// select any option.
+// Implements widgetapi.Widget.Mouse.
+func (rg *RadioGroup) Mouse(m *terminalapi.Mouse) error {
+	rg.mu.Lock()
+	i := m.Position.Y
+	switch m.Button {
+	case mouse.ButtonLeft:
+		rg.pressed = -1
+		if i >= 0 && i < len(rg.labels) {
+			rg.pressed = i
+		}
+	case mouse.ButtonRelease:
+		if i == rg.pressed && m.Position.In(rg.area) {
+			rg.moveTo(i)
+		}
+		rg.pressed = -1
+	default:
+		rg.pressed = -1
+	}
+	return rg.unlockAndCall()
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (rg *RadioGroup) SetTheme(t *theme.Theme) {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+	rg.theme = t
+}
+
+// SetFocused implements widgetapi.Focusable.SetFocused.
+func (rg *RadioGroup) SetFocused(focused bool) {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+	rg.focused = focused
+	if !focused {
+		rg.pressed = -1
+	}
+}
+
+// Options implements widgetapi.Widget.Options.
+func (rg *RadioGroup) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// One line for each option.
+		MinimumSize:  image.Point{1, len(rg.labels)},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+}
+
+// validLabel validates the label of an option.
+func validLabel(label string) error {
+	if label == "" {
+		return errors.New("the labels cannot be empty")
+	}
+	for _, r := range label {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("the label %q cannot contain control characters, found: %q", label, r)
+		}
+	}
+	return nil
+}
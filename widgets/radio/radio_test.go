@@ -0,0 +1,342 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package radio
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		labels  []string
+		wantErr bool
+	}{
+		{
+			desc:   "succeeds with a single option",
+			labels: []string{"1h"},
+		},
+		{
+			desc:    "fails without options",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an empty label",
+			labels:  []string{"1h", ""},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on duplicate labels",
+			labels:  []string{"1h", "1h"},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on control characters in the label",
+			labels:  []string{"1\th"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.labels)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	rg, err := New([]string{"1h", "1d"})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if i, l := rg.Selected(); i != 0 || l != "1h" {
+		t.Errorf("Selected => %d, %q, want 0, \"1h\"", i, l)
+	}
+	if err := rg.Select("1w"); err == nil {
+		t.Errorf("Select(1w) => got nil err, wanted one")
+	}
+	if err := rg.Select("1d"); err != nil {
+		t.Fatalf("Select(1d) => unexpected error: %v", err)
+	}
+	if i, l := rg.Selected(); i != 1 || l != "1d" {
+		t.Errorf("Selected => %d, %q, want 1, \"1d\"", i, l)
+	}
+}
+
+// event is a keyboard or a mouse event processed by the widget.
+type event struct {
+	k *terminalapi.Keyboard
+	m *terminalapi.Mouse
+}
+
+// key returns a keyboard event.
+func key(k keyboard.Key) *event {
+	return &event{k: &terminalapi.Keyboard{Key: k}}
+}
+
+// button returns a mouse event with the button on the row.
+func button(b mouse.Button, y int) *event {
+	return &event{m: &terminalapi.Mouse{Position: image.Point{1, y}, Button: b}}
+}
+
+// buttonAt returns a mouse event with the button at the position.
+func buttonAt(b mouse.Button, p image.Point) *event {
+	return &event{m: &terminalapi.Mouse{Position: p, Button: b}}
+}
+
+// ranges are options used in the tests.
+var ranges = []string{"1h", "1d", "1w"}
+
+func TestRadioGroup(t *testing.T) {
+	tests := []struct {
+		desc    string
+		labels  []string
+		opts    []Option
+		theme   *theme.Theme
+		focused bool
+		events  []*event
+		canvas  image.Rectangle
+		// wantSelected is the index of the selected option.
+		wantSelected int
+		// wantChanges are the calls of the OnChange callback.
+		wantChanges []string
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "first option selected",
+			labels: ranges,
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "RadioGroup_default.golden",
+		},
+		{
+			desc:   "trims long labels and options that don't fit",
+			labels: []string{"last hour", "last day", "last week"},
+			canvas: image.Rect(0, 0, 8, 2),
+			golden: "RadioGroup_trimmed.golden",
+		},
+		{
+			desc:         "arrow keys select options",
+			labels:       ranges,
+			focused:      true,
+			events:       []*event{key(keyboard.KeyArrowDown), key(keyboard.KeyArrowDown), key(keyboard.KeyArrowDown), key(keyboard.KeyArrowUp)},
+			canvas:       image.Rect(0, 0, 8, 3),
+			wantSelected: 1,
+			wantChanges:  []string{"1 1d", "2 1w", "1 1d"},
+			golden:       "RadioGroup_focused.golden",
+		},
+		{
+			desc:         "up arrow on the first option is ignored",
+			labels:       ranges,
+			events:       []*event{key(keyboard.KeyArrowUp), key(keyboard.KeyEnter)},
+			canvas:       image.Rect(0, 0, 8, 3),
+			wantSelected: 0,
+			golden:       "RadioGroup_default.golden",
+		},
+		{
+			desc:         "held mouse button highlights the option",
+			labels:       ranges,
+			events:       []*event{button(mouse.ButtonLeft, 2)},
+			canvas:       image.Rect(0, 0, 8, 3),
+			wantSelected: 0,
+			golden:       "RadioGroup_pressed.golden",
+		},
+		{
+			desc:         "release of the mouse button selects the option",
+			labels:       ranges,
+			focused:      true,
+			events:       []*event{button(mouse.ButtonLeft, 2), button(mouse.ButtonRelease, 2)},
+			canvas:       image.Rect(0, 0, 8, 3),
+			wantSelected: 2,
+			wantChanges:  []string{"2 1w"},
+			golden:       "RadioGroup_clicked.golden",
+		},
+		{
+			desc:         "release over a different option is ignored",
+			labels:       ranges,
+			events:       []*event{button(mouse.ButtonLeft, 2), button(mouse.ButtonRelease, 1)},
+			canvas:       image.Rect(0, 0, 8, 3),
+			wantSelected: 0,
+			golden:       "RadioGroup_default.golden",
+		},
+		{
+			desc:   "release inside after a release outside doesn't select the option",
+			labels: ranges,
+			events: []*event{
+				button(mouse.ButtonLeft, 1),
+				buttonAt(mouse.ButtonRelease, image.Point{8, 1}),
+				button(mouse.ButtonRelease, 1),
+			},
+			canvas:       image.Rect(0, 0, 8, 3),
+			wantSelected: 0,
+			golden:       "RadioGroup_default.golden",
+		},
+		{
+			desc:         "press below the options is ignored",
+			labels:       ranges,
+			events:       []*event{button(mouse.ButtonLeft, 3), button(mouse.ButtonRelease, 3)},
+			canvas:       image.Rect(0, 0, 8, 4),
+			wantSelected: 0,
+			golden:       "RadioGroup_below.golden",
+		},
+		{
+			desc:   "custom colors",
+			labels: ranges,
+			opts: []Option{
+				LabelCellOpts(cell.FgColor(cell.ColorRed)),
+				FocusedColor(cell.ColorYellow),
+			},
+			focused: true,
+			canvas:  image.Rect(0, 0, 8, 3),
+			golden:  "RadioGroup_custom_colors.golden",
+		},
+		{
+			desc:    "theme colors",
+			labels:  ranges,
+			theme:   theme.Dark(),
+			focused: true,
+			canvas:  image.Rect(0, 0, 8, 3),
+			golden:  "RadioGroup_theme.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotChanges []string
+			opts := append([]Option{
+				OnChange(func(i int, label string) error {
+					gotChanges = append(gotChanges, fmt.Sprintf("%d %s", i, label))
+					return nil
+				}),
+			}, tc.opts...)
+			rg, err := New(tc.labels, opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				rg.SetTheme(tc.theme)
+			}
+			rg.SetFocused(tc.focused)
+
+			// The widget learns the area of its canvas when drawn.
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := rg.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				var err error
+				if ev.k != nil {
+					err = rg.Keyboard(ev.k)
+				} else {
+					err = rg.Mouse(ev.m)
+				}
+				if err != nil {
+					t.Fatalf("processing event %+v => unexpected error: %v", ev, err)
+				}
+			}
+			if got, _ := rg.Selected(); got != tc.wantSelected {
+				t.Errorf("Selected => %d, want %d", got, tc.wantSelected)
+			}
+			if diff := pretty.Compare(tc.wantChanges, gotChanges); diff != "" {
+				t.Errorf("OnChange => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			cvs, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := rg.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCallbackCanAccessRadioGroup(t *testing.T) {
+	var rg *RadioGroup
+	var got []string
+	rg, err := New(ranges, OnChange(func(int, string) error {
+		i, label := rg.Selected()
+		got = append(got, fmt.Sprintf("%d %s", i, label))
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- rg.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Keyboard => timed out, the callback deadlocked")
+	}
+
+	if diff := pretty.Compare([]string{"1 1d"}, got); diff != "" {
+		t.Errorf("OnChange => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	rg, err := New(ranges)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := rg.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 3},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
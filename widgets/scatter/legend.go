@@ -0,0 +1,52 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scatter
+
+// legend.go contains code that places and draws the legend.
+
+import "github.com/mum4k/termdash/widgets/linechart/legend"
+
+// LegendPlacement determines where the legend is drawn.
+// The Scatter supports only the placements defined in this package, the
+// legend isn't drawn for the other placements.
+type LegendPlacement = legend.Placement
+
+// Supported legend placements.
+const (
+	// LegendNone means the legend isn't drawn.
+	LegendNone = legend.None
+
+	// LegendTop places the legend in a row above the scatter plot.
+	LegendTop = legend.Top
+	// LegendBottom places the legend in a row below the labels of the X axis.
+	LegendBottom = legend.Bottom
+	// LegendRight places the legend in a column to the right of the scatter
+	// plot, one series per row.
+	LegendRight = legend.Right
+)
+
+// legendEntries returns the legend entries of the series with the names.
+// s.mu must be held when calling this method.
+func (s *Scatter) legendEntries(names []string) []*legend.Entry {
+	var res []*legend.Entry
+	for si, name := range names {
+		res = append(res, &legend.Entry{
+			Name:     name,
+			Marker:   s.series[name].marker,
+			CellOpts: s.seriesCellOpts(si, name),
+		})
+	}
+	return res
+}
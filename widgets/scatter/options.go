@@ -0,0 +1,143 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scatter
+
+// options.go contains configurable options for Scatter.
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// valueRange is a fixed range of values displayed on an axis.
+type valueRange struct {
+	set      bool
+	min, max float64
+}
+
+// validate validates the range, the name identifies the axis.
+func (vr valueRange) validate(name string) error {
+	if !vr.set {
+		return nil
+	}
+	for _, v := range []float64{vr.min, vr.max} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("invalid %s range %v..%v, the values must be finite numbers", name, vr.min, vr.max)
+		}
+	}
+	if vr.min >= vr.max {
+		return fmt.Errorf("invalid %s range %v..%v, the minimum must be smaller than the maximum", name, vr.min, vr.max)
+	}
+	return nil
+}
+
+// options holds the provided options.
+type options struct {
+	axesCellOpts   []cell.Option
+	xLabelCellOpts []cell.Option
+	yLabelCellOpts []cell.Option
+
+	xRange valueRange
+	yRange valueRange
+	legend LegendPlacement
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if err := o.xRange.validate("X"); err != nil {
+		return err
+	}
+	return o.yRange.validate("Y")
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		legend: LegendNone,
+	}
+}
+
+// AxesCellOpts set the cell options for the X and Y axes.
+// The axes use the axis color of the theme set on the container unless the
+// cell options specify another color.
+func AxesCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.axesCellOpts = co
+	})
+}
+
+// XLabelCellOpts set the cell options for the labels on the X axis.
+// The labels use the label color of the theme set on the container unless the
+// cell options specify another color.
+func XLabelCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.xLabelCellOpts = co
+	})
+}
+
+// YLabelCellOpts set the cell options for the labels on the Y axis.
+// The labels use the label color of the theme set on the container unless the
+// cell options specify another color.
+func YLabelCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.yLabelCellOpts = co
+	})
+}
+
+// XRange fixes the range of values displayed on the X axis. Points outside of
+// the range aren't drawn.
+// If not provided, the X axis starts at the smallest and ends at the largest X
+// value among all the series.
+func XRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.xRange = valueRange{set: true, min: min, max: max}
+	})
+}
+
+// YRange fixes the range of values displayed on the Y axis. Points outside of
+// the range aren't drawn.
+// If not provided, the Y axis starts at the smallest and ends at the largest Y
+// value among all the series.
+func YRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.yRange = valueRange{set: true, min: min, max: max}
+	})
+}
+
+// Legend draws a legend with the name of each series in its color at the
+// specified placement. The legend isn't drawn if the canvas is too small to
+// fit both the scatter plot and the legend.
+// Defaults to LegendNone.
+func Legend(lp LegendPlacement) Option {
+	return option(func(opts *options) {
+		opts.legend = lp
+	})
+}
@@ -0,0 +1,437 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scatter is a widget that plots pairs of values as points.
+package scatter
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/linechart/axes"
+	"github.com/mum4k/termdash/widgets/linechart/legend"
+)
+
+// Point is a pair of values plotted on the scatter plot.
+type Point struct {
+	X, Y float64
+}
+
+// series is one named series of points.
+type series struct {
+	points   []Point
+	cellOpts []cell.Option
+	// marker is drawn in the cells with points if set, otherwise the points
+	// are drawn as braille pixels.
+	marker rune
+}
+
+// Scatter plots pairs of values as points, e.g. the size of requests against
+// their latency. Both the X and the Y axis display numeric values. Points are
+// drawn at the resolution of braille pixels, each cell displays 2x4 of them.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Scatter struct {
+	// series are the plotted series by their names.
+	series map[string]*series
+
+	// yAxis is the Y axis of the scatter plot.
+	yAxis *axes.Y
+
+	// mu protects the Scatter.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new Scatter.
+func New(opts ...Option) (*Scatter, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	s := &Scatter{
+		series: map[string]*series{},
+		opts:   opt,
+	}
+	s.yAxis = axes.NewY(s.yRange())
+	return s, nil
+}
+
+// SeriesOption is used to provide options to Series.
+type SeriesOption interface {
+	// set sets the provided option.
+	set(*series)
+}
+
+// seriesOption implements SeriesOption.
+type seriesOption func(*series)
+
+// set implements SeriesOption.set.
+func (so seriesOption) set(s *series) {
+	so(s)
+}
+
+// DefaultColors are the colors of the series when neither the theme set on
+// the container nor the SeriesCellOpts option specify one.
+var DefaultColors = []cell.Color{
+	cell.ColorGreen,
+	cell.ColorBlue,
+	cell.ColorYellow,
+	cell.ColorMagenta,
+	cell.ColorCyan,
+	cell.ColorRed,
+}
+
+// SeriesCellOpts sets the cell options for this series.
+// Note that the braille canvas has resolution of 2x4 pixels per cell, but each
+// cell can only have one set of cell options set. Meaning that where series
+// share a cell, the last drawn series sets the cell options. Series are drawn
+// in alphabetical order based on their name.
+// Series without a color in the cell options use the series colors of the
+// theme set on the container or the DefaultColors in the order in which they
+// are drawn.
+func SeriesCellOpts(co ...cell.Option) SeriesOption {
+	return seriesOption(func(s *series) {
+		s.cellOpts = co
+	})
+}
+
+// SeriesMarker draws the points of this series as the provided rune at the
+// resolution of cells instead of braille pixels, e.g. 'x' or '●'. Points that
+// share a cell are displayed as one marker. Markers are drawn over the braille
+// pixels of other series.
+func SeriesMarker(r rune) SeriesOption {
+	return seriesOption(func(s *series) {
+		s.marker = r
+	})
+}
+
+// Series sets the points that should be displayed as the series with the
+// provided label. The coordinates of the points must be finite numbers.
+// Subsequent calls with the same label replace any previously provided points
+// and options.
+func (s *Scatter) Series(label string, points []Point, opts ...SeriesOption) error {
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	for i, p := range points {
+		if !finite(p.X) || !finite(p.Y) {
+			return fmt.Errorf("invalid point %v at index %d, the coordinates must be finite numbers", p, i)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sv := &series{points: points}
+	for _, opt := range opts {
+		opt.set(sv)
+	}
+	s.series[label] = sv
+	s.yAxis.Update(s.yRange())
+	return nil
+}
+
+// RemoveSeries removes the series with the provided label. Removing a series
+// that doesn't exist isn't an error.
+func (s *Scatter) RemoveSeries(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.series, label)
+	s.yAxis.Update(s.yRange())
+}
+
+// finite asserts whether the value is a finite number.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// dataRange returns the smallest and the largest coordinate among the points
+// of all the series. The coordinate function selects the coordinate. Returns
+// false if there aren't any points.
+// s.mu must be held when calling this method.
+func (s *Scatter) dataRange(coord func(Point) float64) (min, max float64, ok bool) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, sv := range s.series {
+		for _, p := range sv.points {
+			min = math.Min(min, coord(p))
+			max = math.Max(max, coord(p))
+		}
+	}
+	if min > max {
+		return 0, 0, false
+	}
+	return min, max, true
+}
+
+// xRange returns the range of values displayed on the X axis.
+// s.mu must be held when calling this method.
+func (s *Scatter) xRange() (min, max float64) {
+	if s.opts.xRange.set {
+		return s.opts.xRange.min, s.opts.xRange.max
+	}
+	min, max, ok := s.dataRange(func(p Point) float64 { return p.X })
+	if !ok {
+		return 0, 1
+	}
+	if min == max {
+		// Place the points at the start of a range of one unit.
+		max++
+	}
+	return min, max
+}
+
+// yRange returns the range of values displayed on the Y axis.
+// s.mu must be held when calling this method.
+func (s *Scatter) yRange() (min, max float64) {
+	if s.opts.yRange.set {
+		return s.opts.yRange.min, s.opts.yRange.max
+	}
+	min, max, _ = s.dataRange(func(p Point) float64 { return p.Y })
+	return min, max
+}
+
+// seriesNames returns the names of the series in the order in which they are
+// drawn.
+// s.mu must be held when calling this method.
+func (s *Scatter) seriesNames() []string {
+	var names []string
+	for name := range s.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// seriesCellOpts returns the cell options of the series with the name, the si
+// is the index of the series in the order in which they are drawn.
+// s.mu must be held when calling this method.
+func (s *Scatter) seriesCellOpts(si int, name string) []cell.Option {
+	c := DefaultColors[si%len(DefaultColors)]
+	if s.theme != nil {
+		c = s.theme.SeriesColor(si)
+	}
+	return withFgColor(c, s.series[name].cellOpts)
+}
+
+// axesCellOpts returns the cell options for the axes and the labels on the X
+// and Y axes.
+// s.mu must be held when calling this method.
+func (s *Scatter) axesCellOpts() (axesOpts, xLabelOpts, yLabelOpts []cell.Option) {
+	axesOpts = s.opts.axesCellOpts
+	xLabelOpts = s.opts.xLabelCellOpts
+	yLabelOpts = s.opts.yLabelCellOpts
+	if t := s.theme; t != nil {
+		axesOpts = withFgColor(t.Axis, axesOpts)
+		xLabelOpts = withFgColor(t.Label, xLabelOpts)
+		yLabelOpts = withFgColor(t.Label, yLabelOpts)
+	}
+	return axesOpts, xLabelOpts, yLabelOpts
+}
+
+// withFgColor returns the cell options prefixed with the foreground color, so
+// that a color in the options takes precedence.
+func withFgColor(c cell.Color, cOpts []cell.Option) []cell.Option {
+	return append([]cell.Option{cell.FgColor(c)}, cOpts...)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (s *Scatter) SetTheme(t *theme.Theme) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.theme = t
+}
+
+// Draw draws the points and the axes.
+// Implements widgetapi.Widget.Draw.
+func (s *Scatter) Draw(cvs *canvas.Canvas) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := s.seriesNames()
+	entries := s.legendEntries(names)
+	chartAr, legendAr := legend.Layout(cvs.Area(), s.opts.legend, entries, s.minSize())
+	chart, err := canvas.New(chartAr)
+	if err != nil {
+		return fmt.Errorf("canvas.New => %v", err)
+	}
+
+	yd, err := s.yAxis.Details(chart.Area(), axes.YScaleModeAdaptive)
+	if err != nil {
+		return fmt.Errorf("s.yAxis.Details => %v", err)
+	}
+	xMin, xMax := s.xRange()
+	xd, err := axes.NewXDetailsValues(xMin, xMax, yd.Start, chart.Area())
+	if err != nil {
+		return fmt.Errorf("NewXDetailsValues => %v", err)
+	}
+
+	if err := s.drawAxes(chart, xd, yd); err != nil {
+		return err
+	}
+	if err := s.drawPoints(chart, xd, yd, names); err != nil {
+		return err
+	}
+	if err := chart.CopyTo(cvs); err != nil {
+		return fmt.Errorf("chart.CopyTo => %v", err)
+	}
+	return legend.Draw(cvs, legendAr, s.opts.legend, entries)
+}
+
+// drawAxes draws the X and Y axes and their labels.
+// s.mu must be held when calling this method.
+func (s *Scatter) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
+	axesOpts, xLabelOpts, yLabelOpts := s.axesCellOpts()
+	lines := []draw.HVLine{
+		{Start: yd.Start, End: yd.End},
+		{Start: xd.Start, End: xd.End},
+	}
+	if err := draw.HVLines(cvs, lines, draw.HVLineCellOpts(axesOpts...)); err != nil {
+		return fmt.Errorf("failed to draw the axes: %v", err)
+	}
+
+	for _, l := range yd.Labels {
+		if err := draw.Text(cvs, l.Value.Text(), l.Pos,
+			draw.TextMaxX(yd.Start.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(yLabelOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the Y labels: %v", err)
+		}
+	}
+	for _, l := range xd.Labels {
+		if err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(xLabelOpts...)); err != nil {
+			return fmt.Errorf("failed to draw the X labels: %v", err)
+		}
+	}
+	return nil
+}
+
+// pixel returns the braille pixel that represents the point. Returns false if
+// the point is outside of the displayed ranges.
+func pixel(xd *axes.XDetails, yd *axes.YDetails, p Point) (image.Point, bool) {
+	if p.X < xd.Scale.Min.Value || p.X > xd.Scale.Max.Value || p.Y < yd.Scale.Min.Value || p.Y > yd.Scale.Max.Value {
+		return image.ZP, false
+	}
+	x, err := xd.Scale.FloatValueToPixel(p.X)
+	if err != nil {
+		return image.ZP, false
+	}
+	y, err := yd.Scale.ValueToPixel(p.Y)
+	if err != nil {
+		return image.ZP, false
+	}
+	return image.Point{x, y}, true
+}
+
+// drawPoints draws the points of all the series. Points are drawn as braille
+// pixels, points of series with a marker are drawn as the marker in their
+// cells over the braille pixels.
+// s.mu must be held when calling this method.
+func (s *Scatter) drawPoints(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails, names []string) error {
+	graphAr := image.Rect(yd.Start.X+1, yd.Start.Y, xd.End.X+1, xd.End.Y)
+	bc, err := braille.New(graphAr)
+	if err != nil {
+		return fmt.Errorf("braille.New => %v", err)
+	}
+
+	type mark struct {
+		cell     image.Point
+		r        rune
+		cellOpts []cell.Option
+	}
+	var marks []*mark
+	for si, name := range names {
+		sv := s.series[name]
+		cOpts := s.seriesCellOpts(si, name)
+		for _, p := range sv.points {
+			px, ok := pixel(xd, yd, p)
+			if !ok {
+				continue
+			}
+			if sv.marker != 0 {
+				c := image.Point{px.X / braille.ColMult, px.Y / braille.RowMult}
+				marks = append(marks, &mark{cell: c.Add(graphAr.Min), r: sv.marker, cellOpts: cOpts})
+				continue
+			}
+			if err := bc.SetPixel(px, cOpts...); err != nil {
+				return fmt.Errorf("failed to draw point %v of series %q: %v", p, name, err)
+			}
+		}
+	}
+	if err := bc.CopyTo(cvs); err != nil {
+		return fmt.Errorf("bc.CopyTo => %v", err)
+	}
+
+	for _, m := range marks {
+		if _, err := cvs.SetCell(m.cell, m.r, m.cellOpts...); err != nil {
+			return fmt.Errorf("failed to draw a marker: %v", err)
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the Scatter widget.
+func (*Scatter) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Scatter widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Scatter widget.
+func (*Scatter) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Scatter widget doesn't support mouse events")
+}
+
+// minSize determines the minimum required size to draw the scatter plot.
+// s.mu must be held when calling this method.
+func (s *Scatter) minSize() image.Point {
+	// At the very least we need:
+	// - n cells width for the Y axis and its labels as reported by it.
+	// - at least 1 cell width for the graph.
+	reqWidth := s.yAxis.RequiredWidth(axes.YScaleModeAdaptive) + 1
+	// - 2 cells height the X axis and its values and 2 for min and max labels on Y.
+	const reqHeight = 4
+	return image.Point{reqWidth, reqHeight}
+}
+
+// Options implements widgetapi.Widget.Options.
+func (s *Scatter) Options() widgetapi.Options {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return widgetapi.Options{
+		MinimumSize:  s.minSize(),
+		WantKeyboard: false,
+		WantMouse:    false,
+	}
+}
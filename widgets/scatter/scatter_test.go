@@ -0,0 +1,358 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scatter
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc: "succeeds with ranges",
+			opts: []Option{XRange(-1, 1), YRange(0, 10)},
+		},
+		{
+			desc:    "fails on an empty X range",
+			opts:    []Option{XRange(1, 1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an inverted Y range",
+			opts:    []Option{YRange(1, 0)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an infinite Y range",
+			opts:    []Option{YRange(0, math.Inf(1))},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeriesErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		points  []Point
+		wantErr bool
+	}{
+		{
+			desc:   "succeeds without points",
+			label:  "series",
+			points: nil,
+		},
+		{
+			desc:    "fails on an empty label",
+			points:  []Point{{1, 2}},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on a NaN coordinate",
+			label:   "series",
+			points:  []Point{{1, 2}, {math.NaN(), 1}},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an infinite coordinate",
+			label:   "series",
+			points:  []Point{{1, math.Inf(-1)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			err = s.Series(tc.label, tc.points)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Series => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// seriesArgs are the arguments of a call to Series.
+type seriesArgs struct {
+	label  string
+	points []Point
+	opts   []SeriesOption
+}
+
+func TestScatter(t *testing.T) {
+	latency := seriesArgs{
+		label:  "latency",
+		points: []Point{{0, 0}, {1, 2}, {2.5, 1}, {4, 8}, {7, 3.5}, {10, 10}},
+	}
+	errs := seriesArgs{
+		label:  "errors",
+		points: []Point{{1, 9}, {3, 6}, {5, 5}, {8, 1}},
+	}
+
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		series []seriesArgs
+		theme  *theme.Theme
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws only the axes without points",
+			canvas: image.Rect(0, 0, 20, 8),
+			golden: "Scatter_empty.golden",
+		},
+		{
+			desc:   "draws points at braille resolution",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{latency},
+			golden: "Scatter_points.golden",
+		},
+		{
+			desc:   "draws negative and fractional values",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{{
+				label:  "values",
+				points: []Point{{-0.5, -2}, {0, 0}, {0.25, 1}, {0.5, 2}},
+			}},
+			golden: "Scatter_negative.golden",
+		},
+		{
+			desc:   "draws a single point",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{{
+				label:  "single",
+				points: []Point{{3, 4}},
+			}},
+			golden: "Scatter_single.golden",
+		},
+		{
+			desc:   "draws multiple series in their colors",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{latency, errs},
+			golden: "Scatter_multiple.golden",
+		},
+		{
+			desc:   "series cell options",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{{
+				label:  latency.label,
+				points: latency.points,
+				opts:   []SeriesOption{SeriesCellOpts(cell.FgColor(cell.ColorRed))},
+			}},
+			golden: "Scatter_series_cellopts.golden",
+		},
+		{
+			desc:   "series colors from the theme",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{latency, errs},
+			theme:  theme.Dark(),
+			golden: "Scatter_theme.golden",
+		},
+		{
+			desc:   "draws markers at cell resolution",
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{
+				latency,
+				{
+					label:  errs.label,
+					points: errs.points,
+					opts:   []SeriesOption{SeriesMarker('x')},
+				},
+			},
+			golden: "Scatter_markers.golden",
+		},
+		{
+			desc:   "fixed ranges omit points outside of them",
+			opts:   []Option{XRange(0, 5), YRange(0, 5)},
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{latency},
+			golden: "Scatter_ranges.golden",
+		},
+		{
+			desc: "custom axes cell options",
+			opts: []Option{
+				AxesCellOpts(cell.FgColor(cell.ColorRed)),
+				XLabelCellOpts(cell.FgColor(cell.ColorGreen)),
+				YLabelCellOpts(cell.FgColor(cell.ColorBlue)),
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			series: []seriesArgs{latency},
+			golden: "Scatter_axes_cellopts.golden",
+		},
+		{
+			desc:   "legend on the right",
+			opts:   []Option{Legend(LegendRight)},
+			canvas: image.Rect(0, 0, 30, 8),
+			series: []seriesArgs{
+				latency,
+				{
+					label:  errs.label,
+					points: errs.points,
+					opts:   []SeriesOption{SeriesMarker('x')},
+				},
+			},
+			golden: "Scatter_legend_right.golden",
+		},
+		{
+			desc:   "legend at the top",
+			opts:   []Option{Legend(LegendTop)},
+			canvas: image.Rect(0, 0, 30, 8),
+			series: []seriesArgs{latency, errs},
+			golden: "Scatter_legend_top.golden",
+		},
+		{
+			desc:   "legend at the bottom",
+			opts:   []Option{Legend(LegendBottom)},
+			canvas: image.Rect(0, 0, 30, 8),
+			series: []seriesArgs{latency, errs},
+			golden: "Scatter_legend_bottom.golden",
+		},
+		{
+			desc:   "legend is omitted if it doesn't fit",
+			opts:   []Option{Legend(LegendRight)},
+			canvas: image.Rect(0, 0, 12, 8),
+			series: []seriesArgs{latency, errs},
+			golden: "Scatter_legend_omitted.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				s.SetTheme(tc.theme)
+			}
+			for _, sa := range tc.series {
+				if err := s.Series(sa.label, sa.points, sa.opts...); err != nil {
+					t.Fatalf("Series => unexpected error: %v", err)
+				}
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := s.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRemoveSeries(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := s.Series("first", []Point{{0, 0}, {1, 100}}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	if err := s.Series("second", []Point{{0, 0}, {1, 1}}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	wide := s.Options().MinimumSize
+
+	s.RemoveSeries("first")
+	s.RemoveSeries("unknown")
+	if got := s.seriesNames(); len(got) != 1 || got[0] != "second" {
+		t.Errorf("RemoveSeries => series %v, want [second]", got)
+	}
+	if got := s.Options().MinimumSize; got.X >= wide.X {
+		t.Errorf("RemoveSeries => minimum size %v, want narrower than %v after the Y axis shrinks", got, wide)
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := s.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := s.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := s.Series("series", []Point{{0, 0}, {1, 100}}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	got := s.Options()
+	want := widgetapi.Options{
+		// Three cells for the widest label "100", one for the Y axis and one
+		// for the graph.
+		MinimumSize: image.Point{5, 4},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary scatterdemo displays a Scatter widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/scatter"
+)
+
+// requests returns random points of request size in KiB against the latency
+// in milliseconds.
+func requests(n int, msPerKiB float64) []scatter.Point {
+	var points []scatter.Point
+	for i := 0; i < n; i++ {
+		size := rand.ExpFloat64() * 200
+		latency := 5 + size*msPerKiB + math.Abs(rand.NormFloat64()*20)
+		points = append(points, scatter.Point{X: size, Y: latency})
+	}
+	return points
+}
+
+// playScatter periodically replaces the points of the series.
+// Exits when the context expires.
+func playScatter(ctx context.Context, s *scatter.Scatter, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Series("cached", requests(300, 0.05)); err != nil {
+				panic(err)
+			}
+			if err := s.Series("uncached", requests(100, 0.4)); err != nil {
+				panic(err)
+			}
+			if err := s.Series("errors", requests(10, 0.8), scatter.SeriesMarker('x')); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := scatter.New(scatter.Legend(scatter.LegendRight))
+	if err != nil {
+		panic(err)
+	}
+	go playScatter(ctx, s, 2*time.Second)
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(s),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(1*time.Second)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmentdisplay
+
+// glyph.go contains the segments of the supported characters and draws them.
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+)
+
+// segment is one of the segments of a sixteen segment display.
+//
+//	 ─A1── ─A2──
+//	│ \   │   / │
+//	F  H  I  K  B
+//	│   \ │ /   │
+//	 ─G1── ─G2──
+//	│   / │ \   │
+//	E  L  M  N  C
+//	│ /   │   \ │
+//	 ─D1── ─D2──
+type segment int
+
+const (
+	segA1 segment = 1 << iota
+	segA2
+	segB
+	segC
+	segD1
+	segD2
+	segE
+	segF
+	segG1
+	segG2
+	segH
+	segI
+	segK
+	segL
+	segM
+	segN
+)
+
+// Groups of segments used by multiple characters.
+const (
+	segA     = segA1 | segA2
+	segD     = segD1 | segD2
+	segG     = segG1 | segG2
+	segFrame = segA | segB | segC | segD | segE | segF
+)
+
+// dot is a small square drawn in a glyph in addition to the segments.
+type dot int
+
+const (
+	// dotBottom is at the bottom in the middle, e.g. a decimal point.
+	dotBottom dot = 1 << iota
+	// dotUpper and dotLower are in the middle of the upper and the lower half,
+	// e.g. a colon.
+	dotUpper
+	dotLower
+	// dotTopLeft and dotBottomRight are in the corners, e.g. a percent sign.
+	dotTopLeft
+	dotBottomRight
+)
+
+// glyph describes how a character is displayed.
+type glyph struct {
+	segments segment
+	dots     dot
+}
+
+// glyphs are the supported characters. Letters are displayed in upper case.
+var glyphs = map[rune]glyph{
+	' ': {},
+	'0': {segments: segFrame},
+	'1': {segments: segB | segC},
+	'2': {segments: segA | segB | segG | segE | segD},
+	'3': {segments: segA | segB | segG | segC | segD},
+	'4': {segments: segF | segG | segB | segC},
+	'5': {segments: segA | segF | segG | segC | segD},
+	'6': {segments: segA | segF | segG | segE | segC | segD},
+	'7': {segments: segA | segB | segC},
+	'8': {segments: segFrame | segG},
+	'9': {segments: segA | segB | segC | segD | segF | segG},
+	'A': {segments: segA | segB | segC | segE | segF | segG},
+	'B': {segments: segA | segB | segC | segD | segI | segM | segG2},
+	'C': {segments: segA | segF | segE | segD},
+	'D': {segments: segA | segB | segC | segD | segI | segM},
+	'E': {segments: segA | segF | segE | segD | segG1},
+	'F': {segments: segA | segF | segE | segG1},
+	'G': {segments: segA | segF | segE | segD | segC | segG2},
+	'H': {segments: segF | segE | segB | segC | segG},
+	'I': {segments: segA | segI | segM | segD},
+	'J': {segments: segB | segC | segD | segE},
+	'K': {segments: segF | segE | segG1 | segK | segN},
+	'L': {segments: segF | segE | segD},
+	'M': {segments: segF | segE | segH | segK | segB | segC},
+	'N': {segments: segF | segE | segH | segN | segC | segB},
+	'O': {segments: segFrame},
+	'P': {segments: segA | segB | segF | segE | segG},
+	'Q': {segments: segFrame | segN},
+	'R': {segments: segA | segB | segF | segE | segG | segN},
+	'S': {segments: segA | segF | segG | segC | segD},
+	'T': {segments: segA | segI | segM},
+	'U': {segments: segF | segE | segD | segC | segB},
+	'V': {segments: segF | segE | segL | segK},
+	'W': {segments: segF | segE | segL | segN | segC | segB},
+	'X': {segments: segH | segK | segL | segN},
+	'Y': {segments: segH | segK | segM},
+	'Z': {segments: segA | segK | segL | segD},
+	'-': {segments: segG},
+	'+': {segments: segG | segI | segM},
+	'=': {segments: segG | segD},
+	'_': {segments: segD},
+	'/': {segments: segK | segL},
+	'.': {dots: dotBottom},
+	':': {dots: dotUpper | dotLower},
+	'%': {segments: segK | segL, dots: dotTopLeft | dotBottomRight},
+}
+
+// glyphFor returns the glyph of the character.
+func glyphFor(r rune) (glyph, bool) {
+	g, ok := glyphs[unicode.ToUpper(r)]
+	return g, ok
+}
+
+// SupportedChars returns all characters the segment display can display,
+// letters are displayed in upper case.
+func SupportedChars() string {
+	var chars []string
+	for r := range glyphs {
+		chars = append(chars, string(r))
+	}
+	sort.Strings(chars)
+	return strings.Join(chars, "")
+}
+
+// line is a line between two pixels.
+type line struct {
+	start, end image.Point
+}
+
+// glyphLayout are the coordinates of the segments within a glyph of a size.
+type glyphLayout struct {
+	// The edges and the middle of the glyph in pixels.
+	left, mid, right    int
+	top, middle, bottom int
+	// thickness of the segments in pixels.
+	thickness int
+}
+
+// newGlyphLayout returns the layout of a glyph that occupies the area in
+// braille pixels.
+func newGlyphLayout(ar image.Rectangle) *glyphLayout {
+	t := ar.Dx() / 8
+	if t < 1 {
+		t = 1
+	}
+	return &glyphLayout{
+		left:      ar.Min.X,
+		mid:       ar.Min.X + (ar.Dx()-1)/2,
+		right:     ar.Max.X - 1,
+		top:       ar.Min.Y,
+		middle:    ar.Min.Y + (ar.Dy()-1)/2,
+		bottom:    ar.Max.Y - 1,
+		thickness: t,
+	}
+}
+
+// lines returns the lines of the segment, one for each pixel of thickness.
+// The lines of thick segments grow towards the inside of the glyph, the middle
+// segments grow to both sides.
+func (gl *glyphLayout) lines(s segment) []line {
+	var res []line
+	for i := 0; i < gl.thickness; i++ {
+		var l line
+		switch s {
+		case segA1:
+			l = line{image.Point{gl.left, gl.top + i}, image.Point{gl.mid, gl.top + i}}
+		case segA2:
+			l = line{image.Point{gl.mid, gl.top + i}, image.Point{gl.right, gl.top + i}}
+		case segB:
+			l = line{image.Point{gl.right - i, gl.top}, image.Point{gl.right - i, gl.middle}}
+		case segC:
+			l = line{image.Point{gl.right - i, gl.middle}, image.Point{gl.right - i, gl.bottom}}
+		case segD1:
+			l = line{image.Point{gl.left, gl.bottom - i}, image.Point{gl.mid, gl.bottom - i}}
+		case segD2:
+			l = line{image.Point{gl.mid, gl.bottom - i}, image.Point{gl.right, gl.bottom - i}}
+		case segE:
+			l = line{image.Point{gl.left + i, gl.middle}, image.Point{gl.left + i, gl.bottom}}
+		case segF:
+			l = line{image.Point{gl.left + i, gl.top}, image.Point{gl.left + i, gl.middle}}
+		case segG1:
+			l = line{image.Point{gl.left, gl.middle + i/2}, image.Point{gl.mid, gl.middle + i/2}}
+			if i%2 == 1 {
+				l = line{image.Point{gl.left, gl.middle - (i+1)/2}, image.Point{gl.mid, gl.middle - (i+1)/2}}
+			}
+		case segG2:
+			l = line{image.Point{gl.mid, gl.middle + i/2}, image.Point{gl.right, gl.middle + i/2}}
+			if i%2 == 1 {
+				l = line{image.Point{gl.mid, gl.middle - (i+1)/2}, image.Point{gl.right, gl.middle - (i+1)/2}}
+			}
+		case segI:
+			l = line{image.Point{gl.mid + offset(i), gl.top}, image.Point{gl.mid + offset(i), gl.middle}}
+		case segM:
+			l = line{image.Point{gl.mid + offset(i), gl.middle}, image.Point{gl.mid + offset(i), gl.bottom}}
+		case segH:
+			l = line{image.Point{gl.left + i, gl.top}, image.Point{gl.mid + i, gl.middle}}
+		case segK:
+			l = line{image.Point{gl.right - i, gl.top}, image.Point{gl.mid - i, gl.middle}}
+		case segL:
+			l = line{image.Point{gl.mid + i, gl.middle}, image.Point{gl.left + i, gl.bottom}}
+		case segN:
+			l = line{image.Point{gl.mid - i, gl.middle}, image.Point{gl.right - i, gl.bottom}}
+		}
+		res = append(res, l)
+	}
+	return res
+}
+
+// offset returns the offset of the i-th pixel of thickness of a segment that
+// grows to both sides, i.e. 0, -1, 1, -2, 2, ...
+func offset(i int) int {
+	if i%2 == 1 {
+		return -(i + 1) / 2
+	}
+	return i / 2
+}
+
+// dotArea returns the area in pixels occupied by the dot.
+func (gl *glyphLayout) dotArea(d dot) image.Rectangle {
+	size := 2 * gl.thickness
+	var center image.Point
+	switch d {
+	case dotBottom:
+		center = image.Point{gl.mid, gl.bottom - size/2}
+	case dotUpper:
+		center = image.Point{gl.mid, (gl.top + gl.middle) / 2}
+	case dotLower:
+		center = image.Point{gl.mid, (gl.middle + gl.bottom + 1) / 2}
+	case dotTopLeft:
+		center = image.Point{gl.left + size/2, gl.top + size/2}
+	case dotBottomRight:
+		center = image.Point{gl.right - size/2, gl.bottom - size/2}
+	}
+	min := center.Sub(image.Point{size / 2, size / 2})
+	return image.Rectangle{min, min.Add(image.Point{size, size})}
+}
+
+// allSegments lists the segments in the order in which they are drawn.
+var allSegments = []segment{
+	segA1, segA2, segB, segC, segD1, segD2, segE, segF,
+	segG1, segG2, segH, segI, segK, segL, segM, segN,
+}
+
+// allDots lists the dots in the order in which they are drawn.
+var allDots = []dot{dotBottom, dotUpper, dotLower, dotTopLeft, dotBottomRight}
+
+// drawGlyph draws the character into the area in braille pixels.
+func drawGlyph(bc *braille.Canvas, r rune, ar image.Rectangle, cOpts ...cell.Option) error {
+	g, ok := glyphFor(r)
+	if !ok {
+		return fmt.Errorf("unsupported character %q", r)
+	}
+
+	gl := newGlyphLayout(ar)
+	for _, s := range allSegments {
+		if g.segments&s == 0 {
+			continue
+		}
+		for _, l := range gl.lines(s) {
+			if err := draw.BrailleLine(bc, l.start, l.end, draw.BrailleLineCellOpts(cOpts...)); err != nil {
+				return fmt.Errorf("failed to draw a segment of %q: %v", r, err)
+			}
+		}
+	}
+
+	for _, d := range allDots {
+		if g.dots&d == 0 {
+			continue
+		}
+		dotAr := gl.dotArea(d).Intersect(ar)
+		for y := dotAr.Min.Y; y < dotAr.Max.Y; y++ {
+			for x := dotAr.Min.X; x < dotAr.Max.X; x++ {
+				if err := bc.SetPixel(image.Point{x, y}, cOpts...); err != nil {
+					return fmt.Errorf("failed to draw a dot of %q: %v", r, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmentdisplay
+
+// options.go contains configurable options for SegmentDisplay.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/align"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	hAlign   align.Horizontal
+	vAlign   align.Vertical
+	gapCells int
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min := 0; o.gapCells < min {
+		return fmt.Errorf("invalid gap %d, must be in range %d <= gap", o.gapCells, min)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		hAlign:   DefaultAlignHorizontal,
+		vAlign:   DefaultAlignVertical,
+		gapCells: DefaultGapCells,
+	}
+}
+
+// DefaultAlignHorizontal is the default value for the AlignHorizontal option.
+const DefaultAlignHorizontal = align.HorizontalCenter
+
+// AlignHorizontal sets the horizontal alignment of the text on the canvas.
+func AlignHorizontal(h align.Horizontal) Option {
+	return option(func(opts *options) {
+		opts.hAlign = h
+	})
+}
+
+// DefaultAlignVertical is the default value for the AlignVertical option.
+const DefaultAlignVertical = align.VerticalMiddle
+
+// AlignVertical sets the vertical alignment of the text on the canvas.
+func AlignVertical(v align.Vertical) Option {
+	return option(func(opts *options) {
+		opts.vAlign = v
+	})
+}
+
+// DefaultGapCells is the default value for the GapCells option.
+const DefaultGapCells = 1
+
+// GapCells sets the number of empty cells between the characters.
+func GapCells(cells int) Option {
+	return option(func(opts *options) {
+		opts.gapCells = cells
+	})
+}
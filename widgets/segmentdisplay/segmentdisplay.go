@@ -0,0 +1,222 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package segmentdisplay is a widget that displays text as large characters
+// built from segments, like a sixteen segment display.
+package segmentdisplay
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// char is a character written to the display along with its options.
+type char struct {
+	r        rune
+	cellOpts []cell.Option
+}
+
+// SegmentDisplay displays text as large characters that remain readable from
+// a distance, e.g. a clock, a request count or an error rate on a wallboard.
+// The characters are drawn with braille pixels and scale with the size of the
+// canvas. See SupportedChars for the characters that can be displayed.
+//
+// The characters keep their proportions, the widget uses the largest size at
+// which all of them fit onto the canvas. If the canvas is too narrow even for
+// the smallest size, the characters that don't fit are omitted from the
+// right.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type SegmentDisplay struct {
+	// chars are the characters to display.
+	chars []*char
+
+	// mu protects the SegmentDisplay.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new SegmentDisplay.
+func New(opts ...Option) (*SegmentDisplay, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &SegmentDisplay{
+		opts: o,
+	}, nil
+}
+
+// Write writes text for the widget to display. Multiple calls append
+// additional text unless the WriteReplace option is provided. Each call can
+// provide different cell options, e.g. to display characters in different
+// colors. Returns an error if the text contains characters that aren't
+// supported, in which case the displayed text isn't changed.
+func (sd *SegmentDisplay) Write(text string, wOpts ...WriteOption) error {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	wo := newWriteOptions(wOpts...)
+	var chars []*char
+	for _, r := range text {
+		if _, ok := glyphFor(r); !ok {
+			return fmt.Errorf("the segment display doesn't support character %q, supported characters are %q", r, SupportedChars())
+		}
+		chars = append(chars, &char{
+			r:        r,
+			cellOpts: wo.cellOpts,
+		})
+	}
+
+	if wo.replace {
+		sd.chars = nil
+	}
+	sd.chars = append(sd.chars, chars...)
+	return nil
+}
+
+// Reset resets the widget back to empty content.
+func (sd *SegmentDisplay) Reset() {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.chars = nil
+}
+
+// minGlyphSize is the size in cells of the smallest character the widget
+// draws.
+var minGlyphSize = image.Point{3, 2}
+
+// layout returns the size of a single character in cells and the number of
+// characters that fit onto the canvas. Returns zero count if not even a
+// single character fits.
+// sd.mu must be held when calling this method.
+func (sd *SegmentDisplay) layout(cvsAr image.Rectangle) (image.Point, int) {
+	count := len(sd.chars)
+	if count == 0 || cvsAr.Dx() < minGlyphSize.X || cvsAr.Dy() < minGlyphSize.Y {
+		return image.ZP, 0
+	}
+
+	gap := sd.opts.gapCells
+	// The width of the characters in pixels is 3/5 of their height, braille
+	// cells are two pixels wide and four pixels tall.
+	height := cvsAr.Dy()
+	width := (6*height + 2) / 5
+	if avail := (cvsAr.Dx() - (count-1)*gap) / count; width > avail {
+		width = avail
+		height = (5*width + 3) / 6
+	}
+
+	if width < minGlyphSize.X {
+		width = minGlyphSize.X
+		height = (5*width + 3) / 6
+		count = (cvsAr.Dx() + gap) / (width + gap)
+	}
+	if height > cvsAr.Dy() {
+		height = cvsAr.Dy()
+	}
+	if height < minGlyphSize.Y {
+		height = minGlyphSize.Y
+	}
+	return image.Point{width, height}, count
+}
+
+// Draw draws the SegmentDisplay widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas) error {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	size, count := sd.layout(cvs.Area())
+	if count == 0 {
+		return nil
+	}
+
+	gap := sd.opts.gapCells
+	textAr := image.Rect(0, 0, count*size.X+(count-1)*gap, size.Y)
+	textAr, err := align.Rectangle(cvs.Area(), textAr, sd.opts.hAlign, sd.opts.vAlign)
+	if err != nil {
+		return err
+	}
+
+	bc, err := braille.New(cvs.Area())
+	if err != nil {
+		return fmt.Errorf("braille.New => %v", err)
+	}
+	for i, c := range sd.chars[:count] {
+		cellAr := image.Rect(0, 0, size.X, size.Y).Add(image.Point{textAr.Min.X + i*(size.X+gap), textAr.Min.Y})
+		pixelAr := image.Rect(
+			cellAr.Min.X*braille.ColMult, cellAr.Min.Y*braille.RowMult,
+			cellAr.Max.X*braille.ColMult, cellAr.Max.Y*braille.RowMult,
+		)
+		if err := drawGlyph(bc, c.r, pixelAr, sd.charCellOpts(c)...); err != nil {
+			return err
+		}
+	}
+	return bc.CopyTo(cvs)
+}
+
+// charCellOpts returns the cell options of the character. The options are
+// prefixed with the first series color of the theme, so that colors provided
+// when writing the character take precedence.
+// sd.mu must be held when calling this method.
+func (sd *SegmentDisplay) charCellOpts(c *char) []cell.Option {
+	if sd.theme == nil {
+		return c.cellOpts
+	}
+	return append([]cell.Option{cell.FgColor(sd.theme.SeriesColor(0))}, c.cellOpts...)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (sd *SegmentDisplay) SetTheme(t *theme.Theme) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.theme = t
+}
+
+// Keyboard input isn't supported on the SegmentDisplay widget.
+func (*SegmentDisplay) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the SegmentDisplay widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the SegmentDisplay widget.
+func (*SegmentDisplay) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the SegmentDisplay widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*SegmentDisplay) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  minGlyphSize,
+		WantKeyboard: false,
+		WantMouse:    false,
+	}
+}
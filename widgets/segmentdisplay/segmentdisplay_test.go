@@ -0,0 +1,323 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segmentdisplay
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc: "succeeds with zero gap",
+			opts: []Option{GapCells(0)},
+		},
+		{
+			desc:    "fails on negative gap",
+			opts:    []Option{GapCells(-1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		desc string
+		// writes are the texts written in order, the last one can fail.
+		writes  []string
+		wOpts   []WriteOption
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:   "appends to the text",
+			writes: []string{"12", ":3"},
+			want:   "12:3",
+		},
+		{
+			desc:   "replaces the text",
+			writes: []string{"12", "34"},
+			wOpts:  []WriteOption{WriteReplace()},
+			want:   "34",
+		},
+		{
+			desc:   "accepts lower case letters",
+			writes: []string{"ok"},
+			want:   "ok",
+		},
+		{
+			desc:    "fails on unsupported characters and keeps the text",
+			writes:  []string{"12", "3#"},
+			want:    "12",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on newline",
+			writes:  []string{"1\n2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for i, text := range tc.writes {
+				err := sd.Write(text, tc.wOpts...)
+				if last := i == len(tc.writes)-1; last {
+					if (err != nil) != tc.wantErr {
+						t.Errorf("Write(%q) => unexpected error: %v, wantErr: %v", text, err, tc.wantErr)
+					}
+				} else if err != nil {
+					t.Fatalf("Write(%q) => unexpected error: %v", text, err)
+				}
+			}
+
+			var got []rune
+			for _, c := range sd.chars {
+				got = append(got, c.r)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Write => displays %q, want %q", string(got), tc.want)
+			}
+		})
+	}
+}
+
+func TestSegmentDisplay(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		theme  *theme.Theme
+		update func(*SegmentDisplay) error
+		canvas image.Rectangle
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws empty without text",
+			update: func(*SegmentDisplay) error { return nil },
+			canvas: image.Rect(0, 0, 10, 5),
+			golden: "SegmentDisplay_empty.golden",
+		},
+		{
+			desc: "digits sized by the height of the canvas",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("0123456789")
+			},
+			canvas: image.Rect(0, 0, 60, 5),
+			golden: "SegmentDisplay_digits.golden",
+		},
+		{
+			desc: "letters sized by the width of the canvas",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("ABCDEFGHIJKLM")
+			},
+			canvas: image.Rect(0, 0, 64, 10),
+			golden: "SegmentDisplay_letters.golden",
+		},
+		{
+			desc: "remaining letters",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("NOPQRSTUVWXYZ")
+			},
+			canvas: image.Rect(0, 0, 64, 10),
+			golden: "SegmentDisplay_letters2.golden",
+		},
+		{
+			desc: "symbols",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("12:34 5.6% -+=_/")
+			},
+			canvas: image.Rect(0, 0, 96, 6),
+			golden: "SegmentDisplay_symbols.golden",
+		},
+		{
+			desc: "large characters",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("8.%")
+			},
+			canvas: image.Rect(0, 0, 50, 16),
+			golden: "SegmentDisplay_large.golden",
+		},
+		{
+			desc: "per character colors",
+			update: func(sd *SegmentDisplay) error {
+				if err := sd.Write("1", WriteCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return sd.Write("2", WriteCellOpts(cell.FgColor(cell.ColorBlue)))
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			golden: "SegmentDisplay_colors.golden",
+		},
+		{
+			desc:  "theme colors the characters without cell options",
+			theme: theme.Dark(),
+			update: func(sd *SegmentDisplay) error {
+				if err := sd.Write("1"); err != nil {
+					return err
+				}
+				return sd.Write("2", WriteCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			golden: "SegmentDisplay_theme.golden",
+		},
+		{
+			desc: "aligns to the top left",
+			opts: []Option{
+				AlignHorizontal(align.HorizontalLeft),
+				AlignVertical(align.VerticalTop),
+			},
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("42")
+			},
+			canvas: image.Rect(0, 0, 20, 12),
+			golden: "SegmentDisplay_align_top_left.golden",
+		},
+		{
+			desc: "aligns to the bottom right",
+			opts: []Option{
+				AlignHorizontal(align.HorizontalRight),
+				AlignVertical(align.VerticalBottom),
+			},
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("42")
+			},
+			canvas: image.Rect(0, 0, 20, 12),
+			golden: "SegmentDisplay_align_bottom_right.golden",
+		},
+		{
+			desc: "characters without gap",
+			opts: []Option{GapCells(0)},
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("88")
+			},
+			canvas: image.Rect(0, 0, 8, 3),
+			golden: "SegmentDisplay_no_gap.golden",
+		},
+		{
+			desc: "omits characters that don't fit",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("123456")
+			},
+			canvas: image.Rect(0, 0, 11, 4),
+			golden: "SegmentDisplay_omitted.golden",
+		},
+		{
+			desc: "draws nothing on a canvas that is too small",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write("1")
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			golden: "SegmentDisplay_too_small.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				sd.SetTheme(tc.theme)
+			}
+			if err := tc.update(sd); err != nil {
+				t.Fatalf("update => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := sd.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	sd, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := sd.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	sd, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := sd.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	sd, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := sd.Options()
+	want := widgetapi.Options{
+		MinimumSize: image.Point{3, 2},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary segmentdisplaydemo displays a couple of SegmentDisplay widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/segmentdisplay"
+)
+
+// clock displays the current time, the colon blinks every second.
+func clock(ctx context.Context, sd *segmentdisplay.SegmentDisplay) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			text := now.Format("15:04")
+			if now.Second()%2 == 0 {
+				text = now.Format("15 04")
+			}
+			if err := sd.Write(text, segmentdisplay.WriteReplace()); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// requests displays a random request count and error rate. The error rate is
+// colored by its severity.
+func requests(ctx context.Context, count, rate *segmentdisplay.SegmentDisplay) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	total := 0
+	for {
+		select {
+		case <-ticker.C:
+			total += rand.Intn(1000)
+			if err := count.Write(fmt.Sprintf("%d", total), segmentdisplay.WriteReplace()); err != nil {
+				panic(err)
+			}
+
+			errRate := rand.Float64() * 10
+			color := cell.ColorGreen
+			switch {
+			case errRate > 8:
+				color = cell.ColorRed
+			case errRate > 5:
+				color = cell.ColorYellow
+			}
+			if err := rate.Write(fmt.Sprintf("%.1f", errRate), segmentdisplay.WriteReplace(), segmentdisplay.WriteCellOpts(cell.FgColor(color))); err != nil {
+				panic(err)
+			}
+			if err := rate.Write("%"); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	clockSD, err := segmentdisplay.New()
+	if err != nil {
+		panic(err)
+	}
+	go clock(ctx, clockSD)
+
+	count, err := segmentdisplay.New(segmentdisplay.AlignHorizontal(align.HorizontalRight))
+	if err != nil {
+		panic(err)
+	}
+	rate, err := segmentdisplay.New(segmentdisplay.AlignHorizontal(align.HorizontalRight))
+	if err != nil {
+		panic(err)
+	}
+	go requests(ctx, count, rate)
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Clock"),
+				container.PlaceWidget(clockSD),
+			),
+			container.Bottom(
+				container.SplitVertical(
+					container.Left(
+						container.Border(draw.LineStyleLight),
+						container.BorderTitle("Requests"),
+						container.PlaceWidget(count),
+					),
+					container.Right(
+						container.Border(draw.LineStyleLight),
+						container.BorderTitle("Error rate"),
+						container.PlaceWidget(rate),
+					),
+				),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(500*time.Millisecond)); err != nil {
+		panic(err)
+	}
+}
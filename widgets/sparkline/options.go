@@ -16,7 +16,13 @@ package sparkline
 
 // options.go contains configurable options for SparkLine.
 
-import "github.com/mum4k/termdash/cell"
+import (
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/ringbuffer"
+	"github.com/mum4k/termdash/threshold"
+)
 
 // Option is used to provide options.
 type Option interface {
@@ -38,16 +44,27 @@ type options struct {
 	labelCellOpts []cell.Option
 	height        int
 	color         cell.Color
+	colorSet      bool
+	capacity      int
+	maxAge        time.Duration
+	max           float64
+	maxSet        bool
+	baseline      float64
+	thresholds    threshold.Colors
+	labelValues   bool
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		color: DefaultColor,
+		color:    DefaultColor,
+		capacity: DefaultCapacity,
 	}
 }
 
 // Label adds a label above the SparkLine.
+// The label uses the label color of the theme set on the container unless
+// the cell options specify another color.
 func Label(text string, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.label = text
@@ -68,9 +85,79 @@ func Height(h int) Option {
 const DefaultColor = cell.ColorGreen
 
 // Color sets the color of the SparkLine.
-// Defaults to DefaultColor if not set.
+// If not set, the SparkLine uses the first series color of the theme set on
+// its container or DefaultColor if there is no theme.
 func Color(c cell.Color) Option {
 	return option(func(opts *options) {
 		opts.color = c
+		opts.colorSet = true
+	})
+}
+
+// DefaultCapacity is the default value for the Capacity option.
+const DefaultCapacity = ringbuffer.DefaultCapacity
+
+// Capacity sets the maximum number of data points the SparkLine retains.
+// When adding data points to a full SparkLine, the oldest data points are
+// dropped. This keeps the memory used by the SparkLine constant when data
+// points are added continuously.
+// Zero or a negative number resets the capacity to DefaultCapacity.
+func Capacity(n int) Option {
+	return option(func(opts *options) {
+		if n <= 0 {
+			n = DefaultCapacity
+		}
+		opts.capacity = n
+	})
+}
+
+// MaxAge sets the maximum age of the data points the SparkLine retains.
+// Adding data points drops those that were added earlier than the duration.
+// Zero or a negative duration means the age of data points isn't limited,
+// which is the default.
+func MaxAge(d time.Duration) Option {
+	return option(func(opts *options) {
+		if d < 0 {
+			d = 0
+		}
+		opts.maxAge = d
+	})
+}
+
+// Max sets a fixed value that is represented by a bar of the full height.
+// Larger values are displayed as the full height. If not set, the scale
+// adjusts dynamically based on the largest visible value.
+func Max(v float64) Option {
+	return option(func(opts *options) {
+		opts.max = v
+		opts.maxSet = true
+	})
+}
+
+// Baseline sets the value that is represented by an empty bar. The bars show
+// how much the values exceed the baseline, values at or below the baseline
+// are represented by an empty space.
+// Defaults to zero.
+func Baseline(v float64) Option {
+	return option(func(opts *options) {
+		opts.baseline = v
+	})
+}
+
+// Threshold colors the bars of values that reach the specified value with the
+// provided color. Can be specified multiple times, see threshold.Colors for
+// how the color of a bar is chosen. Bars of values below all the thresholds
+// have the color set by the Color option.
+func Threshold(v float64, c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.thresholds.Set(v, c)
+	})
+}
+
+// LabelValues displays the last value and the minimum and the maximum of the
+// visible values next to the label.
+func LabelValues() Option {
+	return option(func(opts *options) {
+		opts.labelValues = true
 	})
 }
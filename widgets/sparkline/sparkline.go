@@ -19,30 +19,42 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/canvas"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/numbers"
+	"github.com/mum4k/termdash/ringbuffer"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
 // SparkLine draws a graph showing a series of values as vertical bars.
 //
 // Bars can have sub-cell height. The graphs scale adjusts dynamically based on
-// the largest visible value.
+// the largest visible value unless a fixed maximum is set.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type SparkLine struct {
 	// data are the data points the SparkLine displays.
-	data []int
+	data *ringbuffer.Buffer
+	// The capacity and the maximum age the data buffer was created with.
+	dataCapacity int
+	dataMaxAge   time.Duration
 
 	// mu protects the SparkLine.
 	mu sync.Mutex
 
 	// opts are the provided options.
 	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
 }
 
 // New returns a new SparkLine.
@@ -51,9 +63,33 @@ func New(opts ...Option) *SparkLine {
 	for _, o := range opts {
 		o.set(opt)
 	}
-	return &SparkLine{
+	sl := &SparkLine{
 		opts: opt,
 	}
+	sl.resizeData()
+	return sl
+}
+
+// resizeData creates the buffer for the data points if it doesn't exist or
+// if the Capacity or the MaxAge options changed. Data points that fit into
+// the new buffer are retained.
+// sl.mu must be held when calling this method.
+func (sl *SparkLine) resizeData() {
+	if sl.data != nil && sl.dataCapacity == sl.opts.capacity && sl.dataMaxAge == sl.opts.maxAge {
+		return
+	}
+
+	// The capacity is always positive and the maximum age is zero or
+	// positive, so this never fails.
+	buf, _ := ringbuffer.New(ringbuffer.Capacity(sl.opts.capacity), ringbuffer.MaxAge(sl.opts.maxAge))
+	if sl.data != nil {
+		for _, v := range sl.data.Values() {
+			buf.Append(v)
+		}
+	}
+	sl.data = buf
+	sl.dataCapacity = sl.opts.capacity
+	sl.dataMaxAge = sl.opts.maxAge
 }
 
 // Draw draws the SparkLine widget onto the canvas.
@@ -63,7 +99,10 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 	defer sl.mu.Unlock()
 
 	ar := sl.area(cvs)
-	visible, max := visibleMax(sl.data, ar.Dx())
+	visible, max := visibleMax(sl.data.Last(ar.Dx()), ar.Dx(), sl.opts.baseline)
+	if sl.opts.maxSet {
+		max = sl.opts.max
+	}
 	var curX int
 	if len(visible) < ar.Dx() {
 		curX = ar.Max.X - len(visible)
@@ -72,13 +111,14 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 	}
 
 	for _, v := range visible {
-		blocks := toBlocks(v, max, ar.Dy())
+		color := sl.valueColor(v)
+		blocks := toBlocks(v-sl.opts.baseline, max-sl.opts.baseline, ar.Dy())
 		curY := ar.Max.Y - 1
 		for i := 0; i < blocks.full; i++ {
 			if _, err := cvs.SetCell(
 				image.Point{curX, curY},
 				sparks[len(sparks)-1], // Last spark represents full cell.
-				cell.FgColor(sl.opts.color),
+				cell.FgColor(color),
 			); err != nil {
 				return err
 			}
@@ -90,7 +130,7 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 			if _, err := cvs.SetCell(
 				image.Point{curX, curY},
 				blocks.partSpark,
-				cell.FgColor(sl.opts.color),
+				cell.FgColor(color),
 			); err != nil {
 				return err
 			}
@@ -99,11 +139,11 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 		curX++
 	}
 
-	if sl.opts.label != "" {
+	if label := sl.labelText(visible); label != "" {
 		// Label is placed immediately above the SparkLine.
 		lStart := image.Point{ar.Min.X, ar.Min.Y - 1}
-		if err := draw.Text(cvs, sl.opts.label, lStart,
-			draw.TextCellOpts(sl.opts.labelCellOpts...),
+		if err := draw.Text(cvs, label, lStart,
+			draw.TextCellOpts(sl.labelCellOpts()...),
 			draw.TextOverrunMode(draw.OverrunModeThreeDot),
 		); err != nil {
 			return err
@@ -112,6 +152,74 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 	return nil
 }
 
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (sl *SparkLine) SetTheme(t *theme.Theme) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.theme = t
+}
+
+// color returns the color of the SparkLine.
+// sl.mu must be held when calling this method.
+func (sl *SparkLine) color() cell.Color {
+	if !sl.opts.colorSet && sl.theme != nil {
+		return sl.theme.SeriesColor(0)
+	}
+	return sl.opts.color
+}
+
+// valueColor returns the color of the bar that represents the value.
+// sl.mu must be held when calling this method.
+func (sl *SparkLine) valueColor(v float64) cell.Color {
+	if c, ok := sl.opts.thresholds.Color(v); ok {
+		return c
+	}
+	return sl.color()
+}
+
+// hasLabel asserts whether the SparkLine displays a label.
+// sl.mu must be held when calling this method.
+func (sl *SparkLine) hasLabel() bool {
+	return sl.opts.label != "" || sl.opts.labelValues
+}
+
+// labelText returns the text of the label, including the last value and the
+// minimum and the maximum of the visible values if requested.
+// sl.mu must be held when calling this method.
+func (sl *SparkLine) labelText(visible []float64) string {
+	if !sl.opts.labelValues || len(visible) == 0 {
+		return sl.opts.label
+	}
+
+	min, max := numbers.MinMax(visible)
+	values := fmt.Sprintf("%s (min %s, max %s)",
+		formatValue(visible[len(visible)-1]), formatValue(min), formatValue(max))
+	if sl.opts.label == "" {
+		return values
+	}
+	return fmt.Sprintf("%s %s", sl.opts.label, values)
+}
+
+// labelValuePlaces is the number of non-zero decimal places the values
+// displayed in the label are rounded to.
+const labelValuePlaces = 2
+
+// formatValue formats the value for display in the label.
+func formatValue(v float64) string {
+	r, _ := numbers.RoundToNonZeroPlaces(v, labelValuePlaces)
+	return strconv.FormatFloat(r, 'f', -1, 64)
+}
+
+// labelCellOpts returns the cell options of the label prefixed with the color
+// from the theme, so that colors in the options take precedence.
+// sl.mu must be held when calling this method.
+func (sl *SparkLine) labelCellOpts() []cell.Option {
+	if sl.theme == nil {
+		return sl.opts.labelCellOpts
+	}
+	return append([]cell.Option{cell.FgColor(sl.theme.Label)}, sl.opts.labelCellOpts...)
+}
+
 // Add adds data points to the SparkLine.
 // Each data point is represented by one bar on the SparkLine. Zero value data
 // points are valid and are represented by an empty space on the SparkLine
@@ -123,7 +231,8 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 // The last added data point will be the one displayed all the way on the right
 // of the SparkLine. If there are more data points than we can fit bars to the
 // width of the SparkLine, only the last n data points that fit will be
-// visible.
+// visible. Data points are retained until they are dropped due to the
+// Capacity or the MaxAge options.
 //
 // Provided options override values set when New() was called.
 func (sl *SparkLine) Add(data []int, opts ...Option) error {
@@ -139,7 +248,34 @@ func (sl *SparkLine) Add(data []int, opts ...Option) error {
 			return fmt.Errorf("data point[%d]: %v must be a positive integer", i, d)
 		}
 	}
-	sl.data = append(sl.data, data...)
+	sl.resizeData()
+	for _, d := range data {
+		sl.data.Append(float64(d))
+	}
+	return nil
+}
+
+// AddFloat is like Add, but accepts data points with fractional values.
+// Data points can be negative, values at or below the Baseline are represented
+// by an empty space on the SparkLine. The data points cannot be NaN or
+// infinite.
+func (sl *SparkLine) AddFloat(data []float64, opts ...Option) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	for _, opt := range opts {
+		opt.set(sl.opts)
+	}
+
+	for i, d := range data {
+		if math.IsNaN(d) || math.IsInf(d, 0) {
+			return fmt.Errorf("data point[%d]: %v must be a finite number", i, d)
+		}
+	}
+	sl.resizeData()
+	for _, d := range data {
+		sl.data.Append(d)
+	}
 	return nil
 }
 
@@ -149,7 +285,7 @@ func (sl *SparkLine) Clear() {
 	sl.mu.Lock()
 	defer sl.mu.Unlock()
 
-	sl.data = nil
+	sl.data.Clear()
 }
 
 // Keyboard input isn't supported on the SparkLine widget.
@@ -174,7 +310,7 @@ func (sl *SparkLine) area(cvs *canvas.Canvas) image.Rectangle {
 	} else {
 		minY = cvsAr.Min.Y
 
-		if sl.opts.label != "" {
+		if sl.hasLabel() {
 			minY++ // Reserve one line for the label.
 		}
 	}
@@ -197,7 +333,7 @@ func (sl *SparkLine) minSize() image.Point {
 		minHeight = 1 // At least one line of characters.
 	}
 
-	if sl.opts.label != "" {
+	if sl.hasLabel() {
 		minHeight++ // One line for the text label.
 	}
 	return image.Point{minWidth, minHeight}
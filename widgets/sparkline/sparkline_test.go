@@ -16,6 +16,7 @@ package sparkline
 
 import (
 	"image"
+	"math"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -25,6 +26,7 @@ import (
 	"github.com/mum4k/termdash/draw"
 	"github.com/mum4k/termdash/draw/testdraw"
 	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -174,6 +176,63 @@ func TestSparkLine(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "uses colors from the theme",
+			sparkLine: New(
+				Label("Hello"),
+			),
+			update: func(sl *SparkLine) error {
+				sl.SetTheme(&theme.Theme{
+					Label:  cell.ColorBlue,
+					Series: []cell.Color{cell.ColorMagenta},
+				})
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 1})
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Hello", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+				testdraw.MustText(c, "▁▂▃█▃▂▁▁", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorMagenta),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "explicit colors take precedence over the theme",
+			sparkLine: New(
+				Label("Hello", cell.FgColor(cell.ColorRed)),
+				Color(cell.ColorCyan),
+			),
+			update: func(sl *SparkLine) error {
+				sl.SetTheme(&theme.Theme{
+					Label:  cell.ColorBlue,
+					Series: []cell.Color{cell.ColorMagenta},
+				})
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 1})
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Hello", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testdraw.MustText(c, "▁▂▃█▃▂▁▁", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorCyan),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "too long label is trimmed",
 			sparkLine: New(
@@ -386,6 +445,272 @@ func TestSparkLine(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:      "capacity drops the oldest data points",
+			sparkLine: New(Capacity(2)),
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{8, 4, 8})
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{2, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:      "capacity provided to Add retains the newest data points",
+			sparkLine: New(),
+			update: func(sl *SparkLine) error {
+				if err := sl.Add([]int{8, 4}); err != nil {
+					return err
+				}
+				return sl.Add([]int{8}, Capacity(2))
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{2, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:      "zero capacity resets the capacity to the default",
+			sparkLine: New(Capacity(2)),
+			update: func(sl *SparkLine) error {
+				if err := sl.Add([]int{8, 4}, Capacity(0)); err != nil {
+					return err
+				}
+				return sl.Add([]int{8})
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "█▄█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:      "draws fractional data points",
+			sparkLine: New(),
+			update: func(sl *SparkLine) error {
+				return sl.AddFloat([]float64{0, 0.5, 1})
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:      "fails on NaN data points",
+			sparkLine: New(),
+			update: func(sl *SparkLine) error {
+				return sl.AddFloat([]float64{0, math.NaN()})
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fixed max",
+			sparkLine: New(
+				Max(8),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{1, 2, 4})
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁▂▄", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "values larger than the fixed max have full height",
+			sparkLine: New(
+				Max(4),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{2, 8})
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "bars start at the baseline",
+			sparkLine: New(
+				Baseline(10),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.AddFloat([]float64{-5, 10, 12, 14, 18})
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▂▄█", image.Point{2, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "negative values are scaled between a negative baseline and the visible max",
+			sparkLine: New(
+				Baseline(-100),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.AddFloat([]float64{-50, -10})
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "colors bars by thresholds",
+			sparkLine: New(
+				Threshold(8, cell.ColorRed),
+				Threshold(4, cell.ColorYellow),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{2, 4, 6, 8})
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▂", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, "▄▆", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorYellow),
+				))
+				testdraw.MustText(c, "█", image.Point{3, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "repeated threshold replaces its color",
+			sparkLine: New(
+				Threshold(4, cell.ColorYellow),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{2, 4}, Threshold(4, cell.ColorRed))
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, "█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays values next to the label",
+			sparkLine: New(
+				Label("CPU"),
+				LabelValues(),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.AddFloat([]float64{2, 8, 4.256})
+			},
+			canvas: image.Rect(0, 0, 30, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "CPU 4.26 (min 2, max 8)", image.Point{0, 0})
+				testdraw.MustText(c, "▂█▄", image.Point{27, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays values without a label",
+			sparkLine: New(
+				LabelValues(),
+			),
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{2, 8})
+			},
+			canvas: image.Rect(0, 0, 20, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "8 (min 2, max 8)", image.Point{0, 0})
+				testdraw.MustText(c, "▂█", image.Point{18, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -454,6 +779,17 @@ func TestOptions(t *testing.T) {
 				WantMouse:    false,
 			},
 		},
+		{
+			desc: "label values and no fixed height",
+			sparkLine: New(
+				LabelValues(),
+			),
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 2},
+				WantKeyboard: false,
+				WantMouse:    false,
+			},
+		},
 		{
 			desc: "no label and fixed height",
 			sparkLine: New(
@@ -29,22 +29,26 @@ var sparks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
 // visibleMax determines the maximum visible data point given the canvas width.
 // Returns a slice that contains only visible data points and the maximum value
-// among them.
-func visibleMax(data []int, width int) ([]int, int) {
+// among them. The maximum is never smaller than the baseline, the baseline is
+// returned if there are no visible data points.
+func visibleMax(data []float64, width int, baseline float64) ([]float64, float64) {
 	if width <= 0 || len(data) == 0 {
-		return nil, 0
+		return nil, baseline
 	}
 
 	if width < len(data) {
 		data = data[len(data)-width:]
 	}
 
-	var max int
+	max := data[0]
 	for _, v := range data {
 		if v > max {
 			max = v
 		}
 	}
+	if max < baseline {
+		max = baseline
+	}
 	return data, max
 }
 
@@ -61,21 +65,25 @@ type blocks struct {
 
 // toBlocks determines the number of full and partial vertical blocks required
 // to represent the provided value given the specified max visible value and
-// number of vertical cells available to the SparkLine.
-func toBlocks(value, max, vertCells int) blocks {
+// number of vertical cells available to the SparkLine. Values larger than the
+// max are represented by the same blocks as the max.
+func toBlocks(value, max float64, vertCells int) blocks {
 	if value <= 0 || max <= 0 || vertCells <= 0 {
 		return blocks{}
 	}
+	if value > max {
+		value = max
+	}
 
 	// How many of the smallest spark elements fit into a cell.
 	cellSparks := len(sparks)
 
 	// Scale is how much of the max does one smallest spark element represent,
 	// given the vertical cells that will be used to represent the value.
-	scale := float64(cellSparks) * float64(vertCells) / float64(max)
+	scale := float64(cellSparks) * float64(vertCells) / max
 
 	// How many smallest spark elements are needed to represent the value.
-	elements := int(numbers.Round(float64(value) * scale))
+	elements := int(numbers.Round(value * scale))
 
 	b := blocks{
 		full: elements / cellSparks,
@@ -23,10 +23,11 @@ import (
 func TestVisibleMax(t *testing.T) {
 	tests := []struct {
 		desc     string
-		data     []int
+		data     []float64
 		width    int
-		wantData []int
-		wantMax  int
+		baseline float64
+		wantData []float64
+		wantMax  float64
 	}{
 		{
 			desc:     "zero for no data",
@@ -36,58 +37,80 @@ func TestVisibleMax(t *testing.T) {
 		},
 		{
 			desc:     "zero for zero width",
-			data:     []int{0, 1},
+			data:     []float64{0, 1},
 			width:    0,
 			wantData: nil,
 			wantMax:  0,
 		},
 		{
 			desc:     "zero for negative width",
-			data:     []int{0, 1},
+			data:     []float64{0, 1},
 			width:    -1,
 			wantData: nil,
 			wantMax:  0,
 		},
 		{
 			desc:     "all values are zero",
-			data:     []int{0, 0, 0},
+			data:     []float64{0, 0, 0},
 			width:    3,
-			wantData: []int{0, 0, 0},
+			wantData: []float64{0, 0, 0},
 			wantMax:  0,
 		},
 		{
 			desc:     "all values are visible",
-			data:     []int{8, 0, 1},
+			data:     []float64{8, 0, 1},
 			width:    3,
-			wantData: []int{8, 0, 1},
+			wantData: []float64{8, 0, 1},
 			wantMax:  8,
 		},
 		{
 			desc:     "width greater than number of values",
-			data:     []int{8, 0, 1},
+			data:     []float64{8, 0, 1},
 			width:    10,
-			wantData: []int{8, 0, 1},
+			wantData: []float64{8, 0, 1},
 			wantMax:  8,
 		},
 		{
 			desc:     "only some values are visible",
-			data:     []int{8, 2, 1},
+			data:     []float64{8, 2, 1},
 			width:    2,
-			wantData: []int{2, 1},
+			wantData: []float64{2, 1},
 			wantMax:  2,
 		},
 		{
 			desc:     "only one value is visible",
-			data:     []int{8, 2, 1},
+			data:     []float64{8, 2, 1},
 			width:    1,
-			wantData: []int{1},
+			wantData: []float64{1},
 			wantMax:  1,
 		},
+		{
+			desc:     "baseline for no data",
+			width:    3,
+			baseline: -100,
+			wantData: nil,
+			wantMax:  -100,
+		},
+		{
+			desc:     "all values are negative and above the baseline",
+			data:     []float64{-50, -10, -30},
+			width:    3,
+			baseline: -100,
+			wantData: []float64{-50, -10, -30},
+			wantMax:  -10,
+		},
+		{
+			desc:     "all values are below the baseline",
+			data:     []float64{-50, -10, -30},
+			width:    3,
+			wantData: []float64{-50, -10, -30},
+			wantMax:  0,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			gotData, gotMax := visibleMax(tc.data, tc.width)
+			gotData, gotMax := visibleMax(tc.data, tc.width, tc.baseline)
 			if diff := pretty.Compare(tc.wantData, gotData); diff != "" {
 				t.Errorf("visibleMax => unexpected visible data, diff (-want, +got):\n%s", diff)
 			}
@@ -101,8 +124,8 @@ func TestVisibleMax(t *testing.T) {
 func TestToBlocks(t *testing.T) {
 	tests := []struct {
 		desc      string
-		value     int
-		max       int
+		value     float64
+		max       float64
 		vertCells int
 		want      blocks
 	}{
@@ -239,6 +262,20 @@ func TestToBlocks(t *testing.T) {
 			vertCells: 3,
 			want:      blocks{full: 2, partSpark: sparks[3]},
 		},
+		{
+			desc:      "fractional value",
+			value:     2.5,
+			max:       10,
+			vertCells: 1,
+			want:      blocks{full: 0, partSpark: sparks[1]},
+		},
+		{
+			desc:      "value larger than max is represented as max",
+			value:     30,
+			max:       24,
+			vertCells: 3,
+			want:      blocks{full: 3, partSpark: 0},
+		},
 	}
 
 	for _, tc := range tests {
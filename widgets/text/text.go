@@ -24,7 +24,9 @@ import (
 	"unicode"
 
 	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -37,6 +39,9 @@ import (
 // By default the widget supports scrolling of content with either the keyboard
 // or mouse. See the options for the default keys and mouse buttons.
 //
+// Text written without a foreground color and the scroll markers use the label
+// color of the theme set on the container.
+//
 // Implements widgetapi.Widget. This object is thread-safe.
 type Text struct {
 	// buff contains the text to be displayed in the widget.
@@ -63,6 +68,9 @@ type Text struct {
 
 	// opts are the provided options.
 	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
 }
 
 // New returns a new text widget.
@@ -121,7 +129,7 @@ const minLinesForMarkers = 3
 func (t *Text) drawScrollUp(cvs *canvas.Canvas, cur image.Point, fromLine int) (bool, error) {
 	height := cvs.Area().Dy()
 	if cur.Y == 0 && height >= minLinesForMarkers && fromLine > 0 {
-		cells, err := cvs.SetCell(cur, '⇧')
+		cells, err := cvs.SetCell(cur, '⇧', t.markerCellOpts()...)
 		if err != nil {
 			return false, err
 		}
@@ -140,7 +148,7 @@ func (t *Text) drawScrollDown(cvs *canvas.Canvas, cur image.Point, fromLine int)
 	height := cvs.Area().Dy()
 	lines := len(t.lines)
 	if cur.Y == height-1 && height >= minLinesForMarkers && height < lines-fromLine {
-		cells, err := cvs.SetCell(cur, '⇩')
+		cells, err := cvs.SetCell(cur, '⇩', t.markerCellOpts()...)
 		if err != nil {
 			return false, err
 		}
@@ -205,7 +213,7 @@ func (t *Text) draw(text string, cvs *canvas.Canvas) error {
 		if i >= optRange.high { // Get the next write options.
 			optRange = t.givenWOpts.forPosition(i)
 		}
-		cells, err := cvs.SetCell(cur, r, optRange.opts.cellOpts)
+		cells, err := cvs.SetCell(cur, r, t.textCellOpts(optRange.opts.cellOpts)...)
 		if err != nil {
 			return err
 		}
@@ -214,6 +222,32 @@ func (t *Text) draw(text string, cvs *canvas.Canvas) error {
 	return nil
 }
 
+// textCellOpts returns the cell options for text written with the provided
+// options. Text without a foreground color uses the label color of the theme.
+// t.mu must be held when calling this method.
+func (t *Text) textCellOpts(co *cell.Options) []cell.Option {
+	if t.theme == nil || co.FgColor != cell.ColorDefault {
+		return []cell.Option{co}
+	}
+	return []cell.Option{cell.FgColor(t.theme.Label), cell.BgColor(co.BgColor)}
+}
+
+// markerCellOpts returns the cell options for the scroll markers.
+// t.mu must be held when calling this method.
+func (t *Text) markerCellOpts() []cell.Option {
+	if t.theme == nil {
+		return nil
+	}
+	return []cell.Option{cell.FgColor(t.theme.Label)}
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (t *Text) SetTheme(th *theme.Theme) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.theme = th
+}
+
 // Draw draws the text onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (t *Text) Draw(cvs *canvas.Canvas) error {
@@ -28,6 +28,7 @@ import (
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/faketerm"
 	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -239,6 +240,82 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "uses the label color of the theme for text without a foreground color",
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				widget.SetTheme(&theme.Theme{Label: cell.ColorRed})
+				if err := widget.Write("hello", WriteCellOpts(cell.BgColor(cell.ColorBlue))); err != nil {
+					return err
+				}
+				return widget.Write("world", WriteCellOpts(cell.FgColor(cell.ColorGreen)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "hello", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+					cell.BgColor(cell.ColorBlue),
+				))
+				testdraw.MustText(c, "world", image.Point{5, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorGreen),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "uses the label color of the theme for the scroll markers",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: func(widget *Text) error {
+				widget.SetTheme(&theme.Theme{Label: cell.ColorRed})
+				return widget.Write("line0\nline1\nline2\nline3", WriteCellOpts(cell.FgColor(cell.ColorGreen)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorGreen),
+				))
+				testdraw.MustText(c, "line1", image.Point{0, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorGreen),
+				))
+				testdraw.MustText(c, "⇩", image.Point{0, 2}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "ignores a release outside of the canvas",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{-3, -1},
+					Button:   mouse.ButtonRelease,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{40, 9},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "scrolls down on mouse wheel down a line at a time",
 			canvas: image.Rect(0, 0, 10, 3),
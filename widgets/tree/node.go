@@ -0,0 +1,150 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+// node.go contains the nodes of the tree.
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// Node is a node of the tree.
+type Node struct {
+	// Label is the text displayed for the node. The labels of nodes with the
+	// same parent must be unique.
+	Label string
+
+	// Leaf indicates that the node doesn't have children and cannot be
+	// expanded.
+	Leaf bool
+
+	// Children are the children of the node. If nil, the children are loaded
+	// when the node is expanded for the first time using the function
+	// provided via the LoadChildren option.
+	Children []*Node
+}
+
+// node is a node of the displayed tree.
+type node struct {
+	label string
+	leaf  bool
+
+	// parent is the parent node, nil for the roots.
+	parent *node
+	// depth is the distance from the root, zero for the roots.
+	depth int
+
+	// children are the children of the node, valid only if loaded is true.
+	children []*node
+	loaded   bool
+	// expanded indicates that the children of the node are displayed.
+	expanded bool
+}
+
+// newNodes validates the provided nodes and converts them to nodes of the
+// displayed tree with the parent.
+func newNodes(nodes []*Node, parent *node) ([]*node, error) {
+	depth := 0
+	if parent != nil {
+		depth = parent.depth + 1
+	}
+
+	var res []*node
+	seen := map[string]bool{}
+	for _, n := range nodes {
+		if n == nil {
+			return nil, errors.New("the nodes cannot be nil")
+		}
+		if err := validLabel(n.Label); err != nil {
+			return nil, err
+		}
+		if seen[n.Label] {
+			return nil, fmt.Errorf("duplicate label %q, the labels of nodes with the same parent must be unique", n.Label)
+		}
+		seen[n.Label] = true
+
+		nd := &node{
+			label:  n.Label,
+			leaf:   n.Leaf,
+			parent: parent,
+			depth:  depth,
+		}
+		if n.Children != nil && !n.Leaf {
+			children, err := newNodes(n.Children, nd)
+			if err != nil {
+				return nil, err
+			}
+			nd.children = children
+			nd.loaded = true
+		}
+		res = append(res, nd)
+	}
+	return res, nil
+}
+
+// path returns the labels of the nodes from the root to this node.
+func (n *node) path() []string {
+	var res []string
+	for cur := n; cur != nil; cur = cur.parent {
+		res = append([]string{cur.label}, res...)
+	}
+	return res
+}
+
+// find returns the node at the path among the nodes or nil if there isn't
+// such node. Only searches the loaded children.
+func find(nodes []*node, path []string) *node {
+	if len(path) == 0 {
+		return nil
+	}
+	for _, n := range nodes {
+		if n.label != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return n
+		}
+		return find(n.children, path[1:])
+	}
+	return nil
+}
+
+// visible returns the nodes displayed on the rows of the tree, i.e. the
+// nodes whose parents are all expanded, in the order of the rows.
+func visible(nodes []*node) []*node {
+	var res []*node
+	for _, n := range nodes {
+		res = append(res, n)
+		if n.expanded {
+			res = append(res, visible(n.children)...)
+		}
+	}
+	return res
+}
+
+// validLabel validates the label of a node.
+func validLabel(label string) error {
+	if label == "" {
+		return errors.New("the labels cannot be empty")
+	}
+	for _, r := range label {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("the label %q cannot contain control characters, found: %q", label, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+// options.go contains configurable options for Tree.
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	loadChildren     LoadFn
+	onSelect         SelectFn
+	selectedCellOpts []cell.Option
+	guideCellOpts    []cell.Option
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{}
+}
+
+// LoadFn is a function that returns the children of the node at the path.
+// The path contains the labels of the nodes from the root to the node.
+//
+// The function is called when the user expands a node for the first time, it
+// must be thread-safe as the keyboard and mouse events are processed in a
+// separate goroutine. The function blocks the processing of further events,
+// so it should return quickly. The function is called after the widget
+// released its lock, so it can call the methods of the widget. The loaded
+// children are discarded if the node was removed from the tree in the
+// meantime.
+//
+// If the function returns an error, the node isn't expanded and the widget
+// will forward the error back to the termdash infrastructure which causes a
+// panic, unless the user provided a termdash.ErrorHandler.
+type LoadFn func(path []string) ([]*Node, error)
+
+// LoadChildren sets a function that loads the children of nodes whose
+// children weren't provided.
+func LoadChildren(fn LoadFn) Option {
+	return option(func(opts *options) {
+		opts.loadChildren = fn
+	})
+}
+
+// SelectFn is a function called with the path of the node the user selected.
+// The path contains the labels of the nodes from the root to the node.
+// The same rules as for LoadFn apply.
+type SelectFn func(path []string) error
+
+// OnSelect sets a function that is called when the user selects a different
+// node.
+func OnSelect(fn SelectFn) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
+
+// DefaultSelectedColor is the background color of the selected node when the
+// container doesn't have a theme. Otherwise the color of the focused border
+// is used.
+const DefaultSelectedColor = cell.ColorBlue
+
+// SelectedCellOpts sets the cell options of the selected node. These take
+// precedence over the default background color and the colors of the theme.
+func SelectedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectedCellOpts = cOpts
+	})
+}
+
+// GuideCellOpts sets the cell options of the guide lines that connect the
+// nodes. These take precedence over the border color of the theme.
+func GuideCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.guideCellOpts = cOpts
+	})
+}
@@ -0,0 +1,595 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tree is a widget that displays hierarchical data as a tree of
+// nodes the user can expand and collapse.
+package tree
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The markers displayed in front of the labels of nodes that have children.
+const (
+	collapsedMarker = '▸'
+	expandedMarker  = '▾'
+)
+
+// indent is the number of cells each level of the tree is indented by.
+const indent = 2
+
+// minLinesForMarkers are the minimum amount of lines required on the canvas in
+// order to draw the scroll markers ('⇧' and '⇩').
+const minLinesForMarkers = 3
+
+// Tree displays hierarchical data, e.g. Kubernetes namespaces, their
+// deployments and pods or a file tree. Nodes are connected by guide lines and
+// their children can be provided upfront or loaded when the node is expanded
+// for the first time, see the LoadChildren option.
+//
+// The selection cursor is moved with the arrow keys, page up and page down,
+// home and end or the mouse wheel and a click selects the node under the
+// mouse pointer. The right arrow key expands the selected node or moves to its
+// first child, the left arrow key collapses it or moves to its parent. The
+// enter and the space key and a click on the marker in front of the label
+// expand or collapse the node. The tree scrolls so that the selected node is
+// always visible.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Tree struct {
+	// roots are the nodes at the top level of the tree.
+	roots []*node
+	// selected is the selected node, nil if the tree is empty.
+	selected *node
+
+	// first is the index of the first row displayed on the canvas.
+	first int
+	// height is the number of rows on the canvas during the last draw.
+	height int
+
+	// pending are the callbacks to call once mu is released, so that the
+	// callbacks can access the Tree.
+	pending []func() error
+
+	// mu protects the Tree.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+
+	// theme is the theme of the container, nil if not set.
+	theme *theme.Theme
+}
+
+// New returns a new Tree.
+func New(opts ...Option) (*Tree, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	return &Tree{
+		height: 1,
+		opts:   o,
+	}, nil
+}
+
+// SetRoots sets the nodes at the top level of the tree, replacing the
+// previous tree. All the nodes are initially collapsed. If the new tree
+// contains the selected node among the provided nodes, it remains selected
+// and its parents are expanded, otherwise the first root is selected.
+func (t *Tree) SetRoots(roots []*Node) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nodes, err := newNodes(roots, nil)
+	if err != nil {
+		return err
+	}
+
+	var sel *node
+	if t.selected != nil {
+		sel = find(nodes, t.selected.path())
+	}
+	if sel == nil && len(nodes) > 0 {
+		sel = nodes[0]
+	}
+	for p := parentOf(sel); p != nil; p = p.parent {
+		p.expanded = true
+	}
+
+	t.roots = nodes
+	t.selected = sel
+	t.first = 0
+	return nil
+}
+
+// parentOf returns the parent of the node or nil if the node is nil.
+func parentOf(n *node) *node {
+	if n == nil {
+		return nil
+	}
+	return n.parent
+}
+
+// Selected returns the path of the selected node, i.e. the labels of the
+// nodes from the root to the selected node. Returns nil if the tree is empty.
+func (t *Tree) Selected() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.selected == nil {
+		return nil
+	}
+	return t.selected.path()
+}
+
+// Reload discards the children of the node at the path and collapses it. The
+// children are loaded again when the node is expanded, use this when the
+// children of a node changed. If a child of the node was selected, the node
+// becomes selected. Returns an error if the tree doesn't contain the node.
+func (t *Tree) Reload(path ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := find(t.roots, path)
+	if n == nil {
+		return fmt.Errorf("the tree doesn't contain node %q", strings.Join(path, "/"))
+	}
+	if isDescendant(t.selected, n) {
+		t.selected = n
+	}
+	n.children = nil
+	n.loaded = false
+	n.expanded = false
+	return nil
+}
+
+// isDescendant asserts whether the node n is a descendant of the node of.
+func isDescendant(n, of *node) bool {
+	for p := parentOf(n); p != nil; p = p.parent {
+		if p == of {
+			return true
+		}
+	}
+	return false
+}
+
+// unlockAndCall releases t.mu and calls the pending callbacks in order.
+// Returns the error of the first callback that fails, the remaining callbacks
+// aren't called.
+// t.mu must be held when calling this method.
+func (t *Tree) unlockAndCall() error {
+	pending := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+
+	for _, call := range pending {
+		if err := call(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveTo selects the node and schedules the OnSelect callback if the
+// selection changed.
+// t.mu must be held when calling this method.
+func (t *Tree) moveTo(n *node) {
+	if n == nil || n == t.selected {
+		return
+	}
+	t.selected = n
+	if fn := t.opts.onSelect; fn != nil {
+		path := n.path()
+		t.pending = append(t.pending, func() error { return fn(path) })
+	}
+}
+
+// moveBy moves the selection by the number of rows, the resulting row is
+// clamped to the rows of the tree.
+// t.mu must be held when calling this method.
+func (t *Tree) moveBy(rows int) {
+	vis := visible(t.roots)
+	if len(vis) == 0 {
+		return
+	}
+	i := indexOf(vis, t.selected) + rows
+	if i < 0 {
+		i = 0
+	}
+	if max := len(vis) - 1; i > max {
+		i = max
+	}
+	t.moveTo(vis[i])
+}
+
+// indexOf returns the index of the node among the nodes or -1 if it isn't
+// present.
+func indexOf(nodes []*node, n *node) int {
+	for i, nd := range nodes {
+		if nd == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// expand expands the node. If its children need to be loaded, schedules the
+// loading and the node is expanded once the children are attached.
+// t.mu must be held when calling this method.
+func (t *Tree) expand(n *node) {
+	if n.leaf || n.expanded {
+		return
+	}
+	if n.loaded || t.opts.loadChildren == nil {
+		n.loaded = true
+		n.expanded = true
+		return
+	}
+
+	fn := t.opts.loadChildren
+	path := n.path()
+	t.pending = append(t.pending, func() error {
+		nodes, err := fn(path)
+		if err != nil {
+			return err
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.attach(n, path, nodes)
+	})
+}
+
+// attach sets the loaded children of the node at the path and expands it.
+// Does nothing if the children were loaded in the meantime or if the node
+// was removed from the tree while its children were loading.
+// t.mu must be held when calling this method.
+func (t *Tree) attach(n *node, path []string, nodes []*Node) error {
+	if n.loaded || find(t.roots, path) != n {
+		return nil
+	}
+	children, err := newNodes(nodes, n)
+	if err != nil {
+		return fmt.Errorf("invalid children loaded for node %q: %v", strings.Join(path, "/"), err)
+	}
+	n.children = children
+	n.loaded = true
+	n.expanded = true
+	return nil
+}
+
+// collapse collapses the node. If one of its descendants was selected, the
+// node becomes selected.
+// t.mu must be held when calling this method.
+func (t *Tree) collapse(n *node) {
+	n.expanded = false
+	if isDescendant(t.selected, n) {
+		t.moveTo(n)
+	}
+}
+
+// toggle expands a collapsed node or collapses an expanded one.
+// t.mu must be held when calling this method.
+func (t *Tree) toggle(n *node) {
+	if n.expanded {
+		t.collapse(n)
+		return
+	}
+	t.expand(n)
+}
+
+// Keyboard processes keyboard events, see the documentation of Tree for the
+// supported keys.
+// Implements widgetapi.Widget.Keyboard.
+func (t *Tree) Keyboard(k *terminalapi.Keyboard) error {
+	t.mu.Lock()
+	t.keyboard(k)
+	return t.unlockAndCall()
+}
+
+// keyboard processes the keyboard event.
+// t.mu must be held when calling this method.
+func (t *Tree) keyboard(k *terminalapi.Keyboard) {
+	sel := t.selected
+	if sel == nil {
+		return
+	}
+
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		t.moveBy(-1)
+	case keyboard.KeyArrowDown:
+		t.moveBy(1)
+	case keyboard.KeyPgUp:
+		t.moveBy(-t.height)
+	case keyboard.KeyPgDn:
+		t.moveBy(t.height)
+	case keyboard.KeyHome:
+		t.moveTo(t.roots[0])
+	case keyboard.KeyEnd:
+		vis := visible(t.roots)
+		t.moveTo(vis[len(vis)-1])
+
+	case keyboard.KeyArrowRight:
+		if !sel.expanded {
+			t.expand(sel)
+		} else if len(sel.children) > 0 {
+			t.moveTo(sel.children[0])
+		}
+	case keyboard.KeyArrowLeft:
+		if sel.expanded {
+			t.collapse(sel)
+		} else {
+			t.moveTo(sel.parent)
+		}
+
+	case keyboard.KeyEnter, keyboard.KeySpace:
+		t.toggle(sel)
+	}
+}
+
+// Mouse processes mouse events. The left button selects the node under the
+// mouse pointer and expands or collapses it if the marker in front of its
+// label was clicked. The mouse wheel moves the selection.
+// Implements widgetapi.Widget.Mouse.
+func (t *Tree) Mouse(m *terminalapi.Mouse) error {
+	t.mu.Lock()
+	t.mouse(m)
+	return t.unlockAndCall()
+}
+
+// mouse processes the mouse event.
+// t.mu must be held when calling this method.
+func (t *Tree) mouse(m *terminalapi.Mouse) {
+	switch m.Button {
+	case mouse.ButtonLeft:
+		vis := visible(t.roots)
+		i := t.first + m.Position.Y
+		if m.Position.Y < 0 || m.Position.Y >= t.height || i >= len(vis) {
+			return
+		}
+		n := vis[i]
+		t.moveTo(n)
+		if m.Position.X == n.depth*indent && !n.leaf {
+			t.toggle(n)
+		}
+	case mouse.ButtonWheelUp:
+		t.moveBy(-1)
+	case mouse.ButtonWheelDown:
+		t.moveBy(1)
+	}
+}
+
+// scroll updates the index of the first displayed row so that the selected
+// node is visible.
+// t.mu must be held when calling this method.
+func (t *Tree) scroll(vis []*node) {
+	sel := indexOf(vis, t.selected)
+	if sel < t.first {
+		t.first = sel
+	}
+	if sel >= t.first+t.height {
+		t.first = sel - t.height + 1
+	}
+	if max := len(vis) - t.height; t.first > max {
+		t.first = max
+	}
+	if t.first < 0 {
+		t.first = 0
+	}
+}
+
+// guideLines returns the guide lines that connect the nodes on the rows. The
+// lines are positioned on a canvas that has an extra row above and below the
+// displayed rows, so that the lines that continue outside of the displayed
+// rows are joined correctly.
+// t.mu must be held when calling this method.
+func (t *Tree) guideLines(vis []*node, maxX int) []draw.HVLine {
+	rows := map[*node]int{}
+	for r, n := range vis {
+		rows[n] = r
+	}
+	top, bottom := t.first-1, t.first+t.height
+
+	var lines []draw.HVLine
+	for r, n := range vis {
+		if n.depth > 0 && r >= top && r <= bottom {
+			// Connects the node to the vertical line of its parent.
+			start := image.Point{(n.depth - 1) * indent, r - top}
+			end := image.Point{start.X + 1, start.Y}
+			if end.X <= maxX {
+				lines = append(lines, draw.HVLine{Start: start, End: end})
+			}
+		}
+
+		if !n.expanded || len(n.children) == 0 {
+			continue
+		}
+		// Connects the children of the node. Starts on the row of the node,
+		// that cell is later replaced by the marker.
+		x := n.depth * indent
+		startY, endY := r, rows[n.children[len(n.children)-1]]
+		if startY < top {
+			startY = top
+		}
+		if endY > bottom {
+			endY = bottom
+		}
+		if x <= maxX && endY > startY {
+			lines = append(lines, draw.HVLine{
+				Start: image.Point{x, startY - top},
+				End:   image.Point{x, endY - top},
+			})
+		}
+	}
+	return lines
+}
+
+// drawGuides draws the guide lines that connect the nodes.
+// t.mu must be held when calling this method.
+func (t *Tree) drawGuides(cvs *canvas.Canvas, vis []*node, maxX int) error {
+	lines := t.guideLines(vis, maxX)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	// The guides are drawn onto a canvas with an extra row above and below,
+	// only the displayed rows are copied.
+	gc, err := canvas.New(image.Rect(0, 0, maxX+1, t.height+2))
+	if err != nil {
+		return err
+	}
+	if err := draw.HVLines(gc, lines, draw.HVLineCellOpts(t.guideCellOpts()...)); err != nil {
+		return fmt.Errorf("failed to draw the guide lines: %v", err)
+	}
+	for y := 0; y < t.height; y++ {
+		for x := 0; x <= maxX; x++ {
+			c, err := gc.Cell(image.Point{x, y + 1})
+			if err != nil {
+				return err
+			}
+			if c.Rune == 0 {
+				continue
+			}
+			if _, err := cvs.SetCell(image.Point{x, y}, c.Rune, c.Opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Draw draws the Tree widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (t *Tree) Draw(cvs *canvas.Canvas) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ar := cvs.Area()
+	t.height = ar.Dy()
+	vis := visible(t.roots)
+	t.scroll(vis)
+
+	textMaxX := ar.Dx()
+	markers := len(vis) > t.height && t.height >= minLinesForMarkers && ar.Dx() > 1
+	if markers {
+		textMaxX--
+	}
+
+	if err := t.drawGuides(cvs, vis, textMaxX-1); err != nil {
+		return err
+	}
+
+	for y := 0; y < t.height && t.first+y < len(vis); y++ {
+		n := vis[t.first+y]
+		x := n.depth * indent
+		if x >= textMaxX {
+			continue
+		}
+
+		var cOpts []cell.Option
+		if n == t.selected {
+			cOpts = t.selectedCellOpts()
+			row := image.Rect(x, y, textMaxX, y+1)
+			if err := draw.Rectangle(cvs, row, draw.RectCellOpts(cOpts...)); err != nil {
+				return err
+			}
+		}
+
+		if !n.leaf {
+			marker := collapsedMarker
+			if n.expanded {
+				marker = expandedMarker
+			}
+			if _, err := cvs.SetCell(image.Point{x, y}, marker, cOpts...); err != nil {
+				return err
+			}
+		}
+		if labelX := x + indent; labelX < textMaxX {
+			if err := draw.Text(cvs, n.label, image.Point{labelX, y},
+				draw.TextMaxX(textMaxX),
+				draw.TextOverrunMode(draw.OverrunModeThreeDot),
+				draw.TextCellOpts(cOpts...),
+			); err != nil {
+				return fmt.Errorf("failed to draw node %q: %v", n.label, err)
+			}
+		}
+	}
+
+	if !markers {
+		return nil
+	}
+	if t.first > 0 {
+		if _, err := cvs.SetCell(image.Point{textMaxX, 0}, '⇧'); err != nil {
+			return err
+		}
+	}
+	if t.first+t.height < len(vis) {
+		if _, err := cvs.SetCell(image.Point{textMaxX, t.height - 1}, '⇩'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectedCellOpts returns the cell options of the selected node. The options
+// are prefixed with the default background color or the color of the focused
+// border of the theme, so that the colors in the options take precedence.
+// t.mu must be held when calling this method.
+func (t *Tree) selectedCellOpts() []cell.Option {
+	bg := DefaultSelectedColor
+	if t.theme != nil {
+		bg = t.theme.FocusedBorder
+	}
+	return append([]cell.Option{cell.BgColor(bg)}, t.opts.selectedCellOpts...)
+}
+
+// guideCellOpts returns the cell options of the guide lines. The options are
+// prefixed with the border color of the theme, so that the colors in the
+// options take precedence.
+// t.mu must be held when calling this method.
+func (t *Tree) guideCellOpts() []cell.Option {
+	if t.theme == nil {
+		return t.opts.guideCellOpts
+	}
+	return append([]cell.Option{cell.FgColor(t.theme.Border)}, t.opts.guideCellOpts...)
+}
+
+// SetTheme implements widgetapi.Themed.SetTheme.
+func (t *Tree) SetTheme(th *theme.Theme) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.theme = th
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*Tree) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+}
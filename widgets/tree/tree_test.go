@@ -0,0 +1,827 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/faketerm"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/theme"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// cluster returns nodes used in the tests. The children of "default/db" and
+// "monitoring" are loaded lazily.
+func cluster() []*Node {
+	return []*Node{
+		{
+			Label: "default",
+			Children: []*Node{
+				{
+					Label: "api",
+					Children: []*Node{
+						{Label: "api-1", Leaf: true},
+						{Label: "api-2", Leaf: true},
+					},
+				},
+				{Label: "db"},
+			},
+		},
+		{
+			Label: "kube-system",
+			Children: []*Node{
+				{Label: "dns", Leaf: true},
+			},
+		},
+		{Label: "monitoring"},
+	}
+}
+
+// pods is a loader that returns two pods for any node.
+func pods(path []string) ([]*Node, error) {
+	last := path[len(path)-1]
+	return []*Node{
+		{Label: last + "-0", Leaf: true},
+		{Label: last + "-1", Leaf: true},
+	}, nil
+}
+
+// pressKeys processes keyboard events with the keys.
+func pressKeys(tr *Tree, keys ...keyboard.Key) error {
+	for _, k := range keys {
+		if err := tr.Keyboard(&terminalapi.Keyboard{Key: k}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "succeeds with default options",
+		},
+		{
+			desc: "succeeds with all the options",
+			opts: []Option{
+				LoadChildren(pods),
+				OnSelect(func([]string) error { return nil }),
+				SelectedCellOpts(cell.FgColor(cell.ColorRed)),
+				GuideCellOpts(cell.FgColor(cell.ColorGreen)),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetRoots(t *testing.T) {
+	tests := []struct {
+		desc string
+		// sets are the roots set in order, only the last one can fail.
+		sets [][]*Node
+		// keysBefore are pressed before the last roots are set.
+		keysBefore   []keyboard.Key
+		wantSelected []string
+		// wantVisible are the paths of the visible nodes.
+		wantVisible []string
+		wantErr     bool
+	}{
+		{
+			desc: "empty tree doesn't have a selection",
+			sets: [][]*Node{nil},
+		},
+		{
+			desc:         "selects the first root and collapses all the nodes",
+			sets:         [][]*Node{cluster()},
+			wantSelected: []string{"default"},
+			wantVisible:  []string{"default", "kube-system", "monitoring"},
+		},
+		{
+			desc:         "keeps the selected node and expands its parents",
+			sets:         [][]*Node{cluster(), cluster()},
+			keysBefore:   []keyboard.Key{keyboard.KeyArrowRight, keyboard.KeyArrowRight, keyboard.KeyArrowRight, keyboard.KeyArrowDown},
+			wantSelected: []string{"default", "api", "api-1"},
+			wantVisible:  []string{"default", "default/api", "default/api/api-1", "default/api/api-2", "default/db", "kube-system", "monitoring"},
+		},
+		{
+			desc: "selects the first root when the selected node is removed",
+			sets: [][]*Node{
+				cluster(),
+				{{Label: "staging"}, {Label: "prod"}},
+			},
+			keysBefore:   []keyboard.Key{keyboard.KeyArrowDown},
+			wantSelected: []string{"staging"},
+			wantVisible:  []string{"staging", "prod"},
+		},
+		{
+			desc: "fails on a nil node",
+			sets: [][]*Node{
+				cluster(),
+				{{Label: "a"}, nil},
+			},
+			wantSelected: []string{"default"},
+			wantVisible:  []string{"default", "kube-system", "monitoring"},
+			wantErr:      true,
+		},
+		{
+			desc: "fails on an empty label",
+			sets: [][]*Node{
+				cluster(),
+				{{Label: ""}},
+			},
+			wantSelected: []string{"default"},
+			wantVisible:  []string{"default", "kube-system", "monitoring"},
+			wantErr:      true,
+		},
+		{
+			desc: "fails on a label with control characters",
+			sets: [][]*Node{
+				cluster(),
+				{{Label: "a\nb"}},
+			},
+			wantSelected: []string{"default"},
+			wantVisible:  []string{"default", "kube-system", "monitoring"},
+			wantErr:      true,
+		},
+		{
+			desc: "fails on duplicate labels of siblings",
+			sets: [][]*Node{
+				cluster(),
+				{{Label: "a", Children: []*Node{{Label: "b"}, {Label: "b"}}}},
+			},
+			wantSelected: []string{"default"},
+			wantVisible:  []string{"default", "kube-system", "monitoring"},
+			wantErr:      true,
+		},
+		{
+			desc: "accepts the same labels under different parents",
+			sets: [][]*Node{
+				{
+					{Label: "a", Children: []*Node{{Label: "c"}}},
+					{Label: "b", Children: []*Node{{Label: "c"}}},
+				},
+			},
+			wantSelected: []string{"a"},
+			wantVisible:  []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			for i, roots := range tc.sets {
+				if i == len(tc.sets)-1 {
+					if err := pressKeys(tr, tc.keysBefore...); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				}
+
+				err := tr.SetRoots(roots)
+				if i < len(tc.sets)-1 {
+					if err != nil {
+						t.Fatalf("SetRoots => unexpected error: %v", err)
+					}
+					continue
+				}
+				if (err != nil) != tc.wantErr {
+					t.Errorf("SetRoots => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+			}
+
+			if diff := pretty.Compare(tc.wantSelected, tr.Selected()); diff != "" {
+				t.Errorf("Selected => unexpected diff (-want, +got):\n%s", diff)
+			}
+			var gotVisible []string
+			for _, n := range visible(tr.roots) {
+				gotVisible = append(gotVisible, strings.Join(n.path(), "/"))
+			}
+			if diff := pretty.Compare(tc.wantVisible, gotVisible); diff != "" {
+				t.Errorf("visible => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	var loaded []string
+	tr, err := New(
+		LoadChildren(func(path []string) ([]*Node, error) {
+			loaded = append(loaded, strings.Join(path, "/"))
+			return pods(path)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tr.SetRoots(cluster()); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+
+	// Selects the first pod of "monitoring".
+	if err := pressKeys(tr, keyboard.KeyEnd, keyboard.KeyArrowRight, keyboard.KeyArrowRight); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if want := []string{"monitoring", "monitoring-0"}; !pathsEqual(tr.Selected(), want) {
+		t.Fatalf("Selected => %v, want %v", tr.Selected(), want)
+	}
+
+	if err := tr.Reload("unknown"); err == nil {
+		t.Errorf("Reload(unknown) => got nil err, wanted one")
+	}
+	if err := tr.Reload("monitoring"); err != nil {
+		t.Fatalf("Reload(monitoring) => unexpected error: %v", err)
+	}
+	if want := []string{"monitoring"}; !pathsEqual(tr.Selected(), want) {
+		t.Errorf("Selected => %v, want %v", tr.Selected(), want)
+	}
+
+	if err := pressKeys(tr, keyboard.KeyArrowRight); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	wantLoaded := []string{"monitoring", "monitoring"}
+	if diff := pretty.Compare(wantLoaded, loaded); diff != "" {
+		t.Errorf("LoadChildren => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCallbacksCanAccessTree(t *testing.T) {
+	var tr *Tree
+	var got []string
+	tr, err := New(
+		LoadChildren(func(path []string) ([]*Node, error) {
+			got = append(got, fmt.Sprintf("load %s", strings.Join(tr.Selected(), "/")))
+			return pods(path)
+		}),
+		OnSelect(func([]string) error {
+			got = append(got, fmt.Sprintf("select %s", strings.Join(tr.Selected(), "/")))
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tr.SetRoots(cluster()); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- pressKeys(tr, keyboard.KeyEnd, keyboard.KeyArrowRight, keyboard.KeyArrowRight)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Keyboard => timed out, the callbacks deadlocked")
+	}
+
+	want := []string{"select monitoring", "load monitoring", "select monitoring/monitoring-0"}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("callbacks => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestLoadedChildrenDiscardedWhenNodeRemoved(t *testing.T) {
+	var tr *Tree
+	tr, err := New(
+		LoadChildren(func(path []string) ([]*Node, error) {
+			// Replaces the tree while the children are loading.
+			if err := tr.SetRoots(cluster()); err != nil {
+				return nil, err
+			}
+			return pods(path)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tr.SetRoots(cluster()); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+
+	if err := pressKeys(tr, keyboard.KeyEnd, keyboard.KeyArrowRight); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	n := find(tr.roots, []string{"monitoring"})
+	if n.loaded || n.expanded || len(n.children) > 0 {
+		t.Errorf("node monitoring => loaded:%v, expanded:%v, children:%d, want it unchanged", n.loaded, n.expanded, len(n.children))
+	}
+}
+
+// pathsEqual asserts whether the two paths are equal.
+func pathsEqual(a, b []string) bool {
+	return strings.Join(a, "/") == strings.Join(b, "/")
+}
+
+// event is a keyboard or a mouse event processed by the widget.
+type event struct {
+	k *terminalapi.Keyboard
+	m *terminalapi.Mouse
+}
+
+// key returns a keyboard event.
+func key(k keyboard.Key) *event {
+	return &event{k: &terminalapi.Keyboard{Key: k}}
+}
+
+// button returns a mouse event with the button at the point.
+func button(b mouse.Button, x, y int) *event {
+	return &event{m: &terminalapi.Mouse{Position: image.Point{x, y}, Button: b}}
+}
+
+func TestEvents(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  []Option
+		roots []*Node
+		// height is the height of the canvas the widget is drawn on before
+		// the events are processed.
+		height       int
+		events       []*event
+		wantSelected []string
+		// wantCalls are the calls of the callbacks.
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			desc:   "ignores events on an empty tree",
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowDown),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyEnter),
+				button(mouse.ButtonLeft, 0, 0),
+				button(mouse.ButtonWheelDown, 0, 0),
+			},
+		},
+		{
+			desc:   "arrow keys move the selection",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowDown),
+				key(keyboard.KeyArrowDown),
+				key(keyboard.KeyArrowUp),
+			},
+			wantSelected: []string{"kube-system"},
+			wantCalls:    []string{"select kube-system", "select monitoring", "select kube-system"},
+		},
+		{
+			desc:   "selection stops at the edges",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowUp),
+				key(keyboard.KeyEnd),
+				key(keyboard.KeyArrowDown),
+			},
+			wantSelected: []string{"monitoring"},
+			wantCalls:    []string{"select monitoring"},
+		},
+		{
+			desc:   "page keys move by the height of the canvas",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyPgDn),
+				key(keyboard.KeyPgDn),
+				key(keyboard.KeyPgUp),
+			},
+			wantSelected: []string{"default", "api"},
+			wantCalls:    []string{"select kube-system", "select monitoring", "select default/api"},
+		},
+		{
+			desc:   "home and end",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyEnd),
+				key(keyboard.KeyHome),
+			},
+			wantSelected: []string{"default"},
+			wantCalls:    []string{"select monitoring", "select default"},
+		},
+		{
+			desc:   "right arrow expands and then moves to the first child",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+			},
+			wantSelected: []string{"default", "api", "api-1"},
+			wantCalls:    []string{"select default/api", "select default/api/api-1"},
+		},
+		{
+			desc:   "left arrow collapses and then moves to the parent",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowLeft),
+				key(keyboard.KeyArrowLeft),
+				key(keyboard.KeyArrowLeft),
+				key(keyboard.KeyArrowLeft),
+			},
+			wantSelected: []string{"default"},
+			wantCalls:    []string{"select default/api", "select default"},
+		},
+		{
+			desc:   "leaves don't expand",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowDown),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyEnter),
+			},
+			wantSelected: []string{"kube-system", "dns"},
+			wantCalls:    []string{"select kube-system", "select kube-system/dns"},
+		},
+		{
+			desc:   "enter and space expand and collapse",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyEnter),
+				key(keyboard.KeySpace),
+				key(keyboard.KeySpace),
+				key(keyboard.KeyArrowDown),
+			},
+			wantSelected: []string{"default", "api"},
+			wantCalls:    []string{"select default/api"},
+		},
+		{
+			desc:   "children are loaded once with the path of the node",
+			opts:   []Option{LoadChildren(pods)},
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowDown),
+				key(keyboard.KeyArrowDown),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowLeft),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+			},
+			wantSelected: []string{"default", "db", "db-0"},
+			wantCalls:    []string{"select default/api", "select default/db", "load default/db", "select default/db/db-0"},
+		},
+		{
+			desc:   "nodes without children and without a loader expand empty",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				key(keyboard.KeyEnd),
+				key(keyboard.KeyArrowRight),
+				key(keyboard.KeyArrowRight),
+			},
+			wantSelected: []string{"monitoring"},
+			wantCalls:    []string{"select monitoring"},
+		},
+		{
+			desc:   "mouse wheel moves the selection",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				button(mouse.ButtonWheelDown, 0, 0),
+				button(mouse.ButtonWheelDown, 0, 0),
+				button(mouse.ButtonWheelUp, 0, 0),
+			},
+			wantSelected: []string{"kube-system"},
+			wantCalls:    []string{"select kube-system", "select monitoring", "select kube-system"},
+		},
+		{
+			desc:   "click selects the node under the mouse",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				button(mouse.ButtonLeft, 4, 2),
+				button(mouse.ButtonLeft, 4, 2),
+			},
+			wantSelected: []string{"monitoring"},
+			wantCalls:    []string{"select monitoring"},
+		},
+		{
+			desc:   "click on the marker expands and collapses the node",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				button(mouse.ButtonLeft, 0, 0),
+				button(mouse.ButtonLeft, 2, 1),
+				button(mouse.ButtonLeft, 6, 2),
+				button(mouse.ButtonLeft, 0, 0),
+			},
+			wantSelected: []string{"default"},
+			wantCalls:    []string{"select default/api", "select default/api/api-1", "select default"},
+		},
+		{
+			desc:   "release outside of the canvas is ignored",
+			roots:  cluster(),
+			height: 3,
+			events: []*event{
+				button(mouse.ButtonLeft, 4, 2),
+				button(mouse.ButtonRelease, -3, -1),
+				button(mouse.ButtonRelease, 40, 9),
+			},
+			wantSelected: []string{"monitoring"},
+			wantCalls:    []string{"select monitoring"},
+		},
+		{
+			desc:   "click outside of the nodes is ignored",
+			roots:  cluster(),
+			height: 5,
+			events: []*event{
+				button(mouse.ButtonLeft, 0, 4),
+				button(mouse.ButtonRight, 0, 1),
+			},
+			wantSelected: []string{"default"},
+		},
+		{
+			desc: "forwards errors from the loader",
+			opts: []Option{
+				LoadChildren(func([]string) ([]*Node, error) { return nil, errors.New("load failed") }),
+			},
+			roots:        cluster(),
+			height:       3,
+			events:       []*event{key(keyboard.KeyEnd), key(keyboard.KeyArrowRight)},
+			wantSelected: []string{"monitoring"},
+			wantCalls:    []string{"select monitoring", "load monitoring"},
+			wantErr:      true,
+		},
+		{
+			desc: "fails on invalid loaded children",
+			opts: []Option{
+				LoadChildren(func([]string) ([]*Node, error) {
+					return []*Node{{Label: "a"}, {Label: "a"}}, nil
+				}),
+			},
+			roots:        cluster(),
+			height:       3,
+			events:       []*event{key(keyboard.KeyEnd), key(keyboard.KeyArrowRight)},
+			wantSelected: []string{"monitoring"},
+			wantCalls:    []string{"select monitoring", "load monitoring"},
+			wantErr:      true,
+		},
+		{
+			desc: "forwards errors from the select callback",
+			opts: []Option{
+				OnSelect(func([]string) error { return errors.New("select failed") }),
+			},
+			roots:        cluster(),
+			height:       3,
+			events:       []*event{key(keyboard.KeyArrowDown)},
+			wantSelected: []string{"kube-system"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotCalls []string
+			opts := []Option{
+				OnSelect(func(path []string) error {
+					gotCalls = append(gotCalls, fmt.Sprintf("select %s", strings.Join(path, "/")))
+					return nil
+				}),
+			}
+			tr, err := New(append(opts, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tr.opts.loadChildren != nil {
+				load := tr.opts.loadChildren
+				tr.opts.loadChildren = func(path []string) ([]*Node, error) {
+					gotCalls = append(gotCalls, fmt.Sprintf("load %s", strings.Join(path, "/")))
+					return load(path)
+				}
+			}
+			if err := tr.SetRoots(tc.roots); err != nil {
+				t.Fatalf("SetRoots => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(image.Rect(0, 0, 20, tc.height))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := tr.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				var err error
+				if ev.k != nil {
+					err = tr.Keyboard(ev.k)
+				} else {
+					err = tr.Mouse(ev.m)
+				}
+				if err != nil {
+					if !tc.wantErr {
+						t.Fatalf("processing event %+v => unexpected error: %v", ev, err)
+					}
+					break
+				}
+				// Redraw so that the tree scrolls like in a running
+				// application.
+				if err := tr.Draw(cvs); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if diff := pretty.Compare(tc.wantSelected, tr.Selected()); diff != "" {
+				t.Errorf("Selected => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantCalls, gotCalls); diff != "" {
+				t.Errorf("callbacks => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  []Option
+		theme *theme.Theme
+		roots []*Node
+		// keys are pressed before drawing of the widget.
+		keys   []keyboard.Key
+		canvas image.Rectangle
+		// golden is the name of the golden file with the expected content.
+		golden string
+	}{
+		{
+			desc:   "draws empty without nodes",
+			canvas: image.Rect(0, 0, 10, 3),
+			golden: "Tree_empty.golden",
+		},
+		{
+			desc:   "collapsed roots",
+			roots:  cluster(),
+			canvas: image.Rect(0, 0, 16, 4),
+			golden: "Tree_collapsed.golden",
+		},
+		{
+			desc:  "expanded nodes are connected by guide lines",
+			roots: cluster(),
+			keys: []keyboard.Key{
+				keyboard.KeyArrowRight, keyboard.KeyArrowDown, keyboard.KeyArrowRight,
+				keyboard.KeyEnd, keyboard.KeyArrowUp, keyboard.KeyArrowRight,
+			},
+			canvas: image.Rect(0, 0, 16, 9),
+			golden: "Tree_guides.golden",
+		},
+		{
+			desc:  "lazily loaded children",
+			opts:  []Option{LoadChildren(pods)},
+			roots: cluster(),
+			keys: []keyboard.Key{
+				keyboard.KeyEnd, keyboard.KeyArrowRight, keyboard.KeyArrowRight,
+			},
+			canvas: image.Rect(0, 0, 16, 5),
+			golden: "Tree_lazy.golden",
+		},
+		{
+			desc:  "scrolls to the selected node and marks nodes on both sides",
+			roots: cluster(),
+			keys: []keyboard.Key{
+				keyboard.KeyArrowRight, keyboard.KeyArrowDown, keyboard.KeyArrowRight,
+				keyboard.KeyEnd, keyboard.KeyArrowUp, keyboard.KeyArrowRight,
+				keyboard.KeyHome, keyboard.KeyArrowDown, keyboard.KeyArrowDown, keyboard.KeyArrowDown,
+				keyboard.KeyArrowDown,
+			},
+			canvas: image.Rect(0, 0, 16, 4),
+			golden: "Tree_scrolled.golden",
+		},
+		{
+			desc:  "long labels and deep nodes are trimmed",
+			roots: cluster(),
+			keys: []keyboard.Key{
+				keyboard.KeyArrowRight, keyboard.KeyArrowDown, keyboard.KeyArrowRight,
+			},
+			canvas: image.Rect(0, 0, 6, 5),
+			golden: "Tree_trimmed.golden",
+		},
+		{
+			desc: "custom cell options",
+			opts: []Option{
+				SelectedCellOpts(cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorYellow)),
+				GuideCellOpts(cell.FgColor(cell.ColorGreen)),
+			},
+			roots:  cluster(),
+			keys:   []keyboard.Key{keyboard.KeyArrowRight},
+			canvas: image.Rect(0, 0, 16, 5),
+			golden: "Tree_cellopts.golden",
+		},
+		{
+			desc:   "theme colors the selected node and the guide lines",
+			theme:  theme.Dark(),
+			roots:  cluster(),
+			keys:   []keyboard.Key{keyboard.KeyArrowRight},
+			canvas: image.Rect(0, 0, 16, 5),
+			golden: "Tree_theme.golden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.theme != nil {
+				tr.SetTheme(tc.theme)
+			}
+			if err := tr.SetRoots(tc.roots); err != nil {
+				t.Fatalf("SetRoots => unexpected error: %v", err)
+			}
+			if err := pressKeys(tr, tc.keys...); err != nil {
+				t.Fatalf("Keyboard => unexpected error: %v", err)
+			}
+
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := tr.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if err := faketerm.Golden(filepath.Join("testdata", tc.golden), got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tr, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := tr.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: true,
+		WantMouse:    true,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
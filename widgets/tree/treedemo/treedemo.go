@@ -0,0 +1,146 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary treedemo displays a Tree widget that browses the namespaces,
+// deployments and pods of a made up Kubernetes cluster.
+// Exist when Esc is pressed, other keys are used by the Tree.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgets/text"
+	"github.com/mum4k/termdash/widgets/tree"
+)
+
+// deployments are the deployments in each namespace.
+var deployments = map[string][]string{
+	"default":     {"api", "auth", "frontend", "worker"},
+	"kube-system": {"coredns", "kube-proxy", "metrics-server"},
+	"monitoring":  {"grafana", "prometheus"},
+}
+
+// load returns the children of the node at the path. The deployments of a
+// namespace and the pods of a deployment are made up.
+func load(path []string) ([]*tree.Node, error) {
+	switch len(path) {
+	case 1:
+		var res []*tree.Node
+		for _, d := range deployments[path[0]] {
+			res = append(res, &tree.Node{Label: d})
+		}
+		return res, nil
+
+	case 2:
+		var res []*tree.Node
+		for i := 0; i < 1+rand.Intn(4); i++ {
+			res = append(res, &tree.Node{
+				Label: fmt.Sprintf("%s-%x", path[1], rand.Int31()),
+				Leaf:  true,
+			})
+		}
+		return res, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected path %v", path)
+	}
+}
+
+// describe writes made up details about the node at the path into the text
+// widget.
+func describe(t *text.Text, path []string) error {
+	t.Reset()
+	kinds := []string{"Namespace", "Deployment", "Pod"}
+	if err := t.Write(fmt.Sprintf("%s: %s\n\n", kinds[len(path)-1], strings.Join(path, "/"))); err != nil {
+		return err
+	}
+	if len(path) < 3 {
+		return nil
+	}
+	return t.Write(fmt.Sprintf("Status:   Running\nRestarts: %d\nCPU:      %dm\nMemory:   %dMi\n",
+		rand.Intn(5), 10+rand.Intn(500), 64+rand.Intn(1024)))
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	details := text.New()
+	cluster, err := tree.New(
+		tree.LoadChildren(load),
+		tree.OnSelect(func(path []string) error {
+			return describe(details, path)
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	var namespaces []*tree.Node
+	for _, ns := range []string{"default", "kube-system", "monitoring"} {
+		namespaces = append(namespaces, &tree.Node{Label: ns})
+	}
+	if err := cluster.SetRoots(namespaces); err != nil {
+		panic(err)
+	}
+	if err := describe(details, cluster.Selected()); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(draw.LineStyleLight),
+		container.BorderTitle("PRESS ESC TO QUIT, ARROWS OR ENTER TO EXPAND AND COLLAPSE"),
+		container.SplitVertical(
+			container.Left(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Cluster"),
+				container.PlaceWidget(cluster),
+			),
+			container.Right(
+				container.Border(draw.LineStyleLight),
+				container.BorderTitle("Details"),
+				container.PlaceWidget(details),
+			),
+			container.SplitPercent(40),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == keyboard.KeyEsc {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(100*time.Millisecond)); err != nil {
+		panic(err)
+	}
+}